@@ -33,19 +33,229 @@ const (
 	ProtectionModeFail ProtectionMode = "fail"
 )
 
+// OverwritePolicy defines whether the operator may overwrite namespace labels it did not apply
+// +kubebuilder:validation:Enum=always;ifOwned
+type OverwritePolicy string
+
+const (
+	// OverwritePolicyAlways sets every desired label, overwriting values the operator did not apply
+	OverwritePolicyAlways OverwritePolicy = "always"
+	// OverwritePolicyIfOwned only sets labels that are absent or were applied by the operator
+	OverwritePolicyIfOwned OverwritePolicy = "ifOwned"
+)
+
+// RegexPatternPrefix marks a protection pattern as a regular expression instead of a glob
+const RegexPatternPrefix = "regex:"
+
+// NegatedPatternPrefix marks a protectedLabelPatterns entry as an exception: keys it matches are never protected
+// by key, whichever rule or pattern would otherwise protect them
+const NegatedPatternPrefix = "!"
+
+// ProtectedLabelRule protects label keys matching a pattern with its own protection mode
+type ProtectedLabelRule struct {
+	// Pattern is a glob pattern for label keys, or a Go regular expression prefixed with "regex:"
+	Pattern string `json:"pattern"`
+
+	// Mode overrides spec.protectionMode for label keys matching Pattern
+	// +optional
+	Mode ProtectionMode `json:"mode,omitempty"`
+}
+
+// TierProtectedLabelPatterns are extra protection patterns for namespaces in one tier
+type TierProtectedLabelPatterns struct {
+	// Tier is the value of the namespace's tier label these patterns apply to
+	Tier string `json:"tier"`
+
+	// Patterns are glob (or "regex:" prefixed) patterns protected in addition to protectedLabelPatterns
+	Patterns []string `json:"patterns"`
+}
+
+// LabelChangeAction is how a label differs between two spec revisions
+// +kubebuilder:validation:Enum=Add;Update;Remove
+type LabelChangeAction string
+
+const (
+	// LabelChangeAdd is a label that is new in the current spec
+	LabelChangeAdd LabelChangeAction = "Add"
+	// LabelChangeUpdate is a label whose value changed in the current spec
+	LabelChangeUpdate LabelChangeAction = "Update"
+	// LabelChangeRemove is a label that was dropped from the current spec
+	LabelChangeRemove LabelChangeAction = "Remove"
+)
+
+// LabelChange describes a single label that differs from the last applied spec
+type LabelChange struct {
+	// Key is the label key
+	Key string `json:"key"`
+
+	// Action is how the label changed
+	Action LabelChangeAction `json:"action"`
+
+	// OldValue is the value in the last applied spec
+	// +optional
+	OldValue string `json:"oldValue,omitempty"`
+
+	// NewValue is the value in the current spec
+	// +optional
+	NewValue string `json:"newValue,omitempty"`
+}
+
+// LabelChanges is the diff a reconcile applied to the namespace labels
+type LabelChanges struct {
+	// Added lists the labels the reconcile created
+	// +optional
+	Added []LabelChange `json:"added,omitempty"`
+
+	// Updated lists the labels whose value the reconcile changed, with the old and new value
+	// +optional
+	Updated []LabelChange `json:"updated,omitempty"`
+
+	// Removed lists the labels the reconcile removed, with the value they had
+	// +optional
+	Removed []LabelChange `json:"removed,omitempty"`
+}
+
+// ReconcileStats counts the outcome of the last reconcile, so dashboards and printer columns need not parse lists
+type ReconcileStats struct {
+	// Requested is the number of labels the spec asks for, from every source
+	Requested int32 `json:"requested"`
+
+	// Applied is the number of labels applied to the namespace
+	Applied int32 `json:"applied"`
+
+	// Skipped is the number of requested labels left unchanged by protection
+	Skipped int32 `json:"skipped"`
+
+	// Failed is the number of requested labels whose fail-mode protection conflict failed the reconcile
+	Failed int32 `json:"failed"`
+
+	// Removed is the number of labels the reconcile removed from the namespace
+	Removed int32 `json:"removed"`
+
+	// Protected is the number of namespace labels covered by the CR's protection patterns and rules
+	Protected int32 `json:"protected"`
+}
+
+// HashLabelSpec sets a label to a content hash of a ConfigMap
+type HashLabelSpec struct {
+	// Key is the label key that receives the hash
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// ConfigMapName is the ConfigMap in the CR's namespace whose content is hashed
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	ConfigMapName string `json:"configMapName"`
+}
+
+// LabelEntry is a single label in spec.labelEntries
+type LabelEntry struct {
+	// Key is the label key
+	Key string `json:"key"`
+
+	// Value is the label value
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// NamespaceCondition requires a label on another namespace
+type NamespaceCondition struct {
+	// Name is the namespace that must carry the label
+	Name string `json:"name"`
+
+	// LabelKey is the label the namespace must carry
+	LabelKey string `json:"labelKey"`
+
+	// LabelValue is the value the label must have. Any value satisfies the condition when empty.
+	// +optional
+	LabelValue string `json:"labelValue,omitempty"`
+}
+
 // NamespaceLabelSpec defines the desired state of NamespaceLabel
 type NamespaceLabelSpec struct {
 	// Labels is a map of key-value pairs to apply to the namespace where this CR is created.
 	// The target namespace is always the same as the CR's metadata.namespace for security.
+	// Values containing "{{" are Go templates evaluated against the target namespace,
+	// e.g. "{{ .Namespace.Labels.team }}".
 	Labels map[string]string `json:"labels,omitempty"`
 
+	// LabelEntries are labels like those in labels, kept in the order they are listed. The ordered applied
+	// annotation lists them in that order, followed by the other labels sorted by key. A key may appear only
+	// once across labels and labelEntries.
+	// +optional
+	LabelEntries []LabelEntry `json:"labelEntries,omitempty"`
+
+	// LabelsTemplate is a Go template rendering a YAML map of labels, evaluated against the target
+	// namespace as .Namespace and this CR as .NamespaceLabel. Unlike templated values it can generate
+	// the set of keys itself. Labels in labels win over rendered labels with the same key.
+	// +optional
+	LabelsTemplate string `json:"labelsTemplate,omitempty"`
+
+	// RemoveLabels lists label keys to delete from the namespace whoever set them, e.g. to clean up a legacy
+	// key the operator never applied. Keys are used as written, without keyPrefix, and may not also be in labels.
+	// +optional
+	RemoveLabels []string `json:"removeLabels,omitempty"`
+
+	// Annotations is a map of key-value pairs to apply as annotations on the namespace.
+	// Previously applied annotations that are removed from the spec are cleaned up like labels.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// AllowedLabelPatterns restricts the label keys this CR may set to those matching a glob (or "regex:"
+	// prefixed) pattern. Other keys are dropped and listed in status.disallowedLabels. Empty allows every key.
+	// +optional
+	AllowedLabelPatterns []string `json:"allowedLabelPatterns,omitempty"`
+
 	// ProtectedLabelPatterns is a list of glob patterns for label keys that should not be overwritten.
 	// If a label in the spec matches any of these patterns and the label already exists on the namespace
 	// with a different value, the behavior is controlled by protectionMode.
 	// Common patterns: "kubernetes.io/*", "*.k8s.io/*", "istio.io/*", "pod-security.kubernetes.io/*"
+	// Patterns prefixed with "regex:" are matched as Go regular expressions, e.g. "regex:^.*\.secret\..*$".
+	// Patterns prefixed with "!" are exceptions, e.g. "!example.com/public" next to "example.com/*": a key is
+	// protected when it matches a rule or pattern and no exception, whatever the order of the entries.
 	// +optional
 	ProtectedLabelPatterns []string `json:"protectedLabelPatterns,omitempty"`
 
+	// AllowMatchAll permits protection patterns that match every key or value, such as a bare "*".
+	// They protect everything and are almost always a mistake, so they are rejected unless this is set.
+	// +optional
+	AllowMatchAll bool `json:"allowMatchAll,omitempty"`
+
+	// ProtectedValuePatterns lists glob (or "regex:" prefixed) patterns for label values that must not be
+	// overwritten, whatever the key, e.g. "critical" so tier=critical is never downgraded. A label whose
+	// current namespace value matches is protected with protectionMode like a protected key.
+	// +optional
+	ProtectedValuePatterns []string `json:"protectedValuePatterns,omitempty"`
+
+	// RemovalProtectionPatterns lists glob (or "regex:" prefixed) patterns for label keys the operator
+	// must never remove once present. Matching labels dropped from the spec are left on the namespace
+	// and reported in status.removalProtected. Additions and value changes are not affected.
+	// +optional
+	RemovalProtectionPatterns []string `json:"removalProtectionPatterns,omitempty"`
+
+	// PruneStaleLabels removes labels the operator applied once they are dropped from the spec (default).
+	// When false labels are only added and updated: dropped labels stay on the namespace, still tracked,
+	// until the NamespaceLabel is deleted.
+	// +kubebuilder:default=true
+	// +optional
+	PruneStaleLabels *bool `json:"pruneStaleLabels,omitempty"`
+
+	// ProtectedLabelRules are protection patterns that each carry their own mode, e.g.
+	// {pattern: "kubernetes.io/*", mode: fail}. Rules are checked in order before protectedLabelPatterns
+	// and the first matching pattern decides the mode; rules without a mode use protectionMode.
+	// +optional
+	ProtectedLabelRules []ProtectedLabelRule `json:"protectedLabelRules,omitempty"`
+
+	// ProtectionTierLabel is the namespace label whose value selects the tier, e.g. "quota-tier".
+	// Patterns listed for that tier in tierProtectedLabelPatterns are protected with protectionMode.
+	// +optional
+	ProtectionTierLabel string `json:"protectionTierLabel,omitempty"`
+
+	// TierProtectedLabelPatterns lists extra protection patterns per tier, so e.g. gold-tier namespaces
+	// can protect more labels than bronze ones
+	// +optional
+	TierProtectedLabelPatterns []TierProtectedLabelPatterns `json:"tierProtectedLabelPatterns,omitempty"`
+
 	// ProtectionMode controls behavior when attempting to modify protected labels.
 	// - skip: Silently skip protected labels (default)
 	// - warn: Skip protected labels but log warnings and update status
@@ -53,6 +263,102 @@ type NamespaceLabelSpec struct {
 	// +kubebuilder:default=skip
 	// +optional
 	ProtectionMode ProtectionMode `json:"protectionMode,omitempty"`
+
+	// ProtectCreation extends protection to labels that do not exist on the namespace yet, so a
+	// protected key can't be created by this CR. By default only changing an existing value is blocked.
+	// +optional
+	ProtectCreation bool `json:"protectCreation,omitempty"`
+
+	// PreserveProtectedValues keeps a namespace value once protection has kept it, even after the protection
+	// mode is relaxed or the pattern removed, for as long as the namespace still carries that value.
+	// By default a label no longer protected is set to the desired value on the next reconcile.
+	// +optional
+	PreserveProtectedValues bool `json:"preserveProtectedValues,omitempty"`
+
+	// OverwritePolicy controls labels already on the namespace that the operator did not apply.
+	// - always: Overwrite them with the desired value (default)
+	// - ifOwned: Leave them untouched and report them in status.overwriteConflicts
+	// +kubebuilder:default=always
+	// +optional
+	OverwritePolicy OverwritePolicy `json:"overwritePolicy,omitempty"`
+
+	// HashLabels sets labels to a hash of a referenced ConfigMap's content and keeps them updated
+	// when the ConfigMap changes, so rollouts can be triggered off the label.
+	// Hash labels take precedence over labels with the same key.
+	// +optional
+	HashLabels []HashLabelSpec `json:"hashLabels,omitempty"`
+
+	// InheritFrom is a namespace whose InheritKeys labels are copied onto the target namespace, so new
+	// namespaces can pick up a curated subset of a parent's labels. Labels set in the spec win.
+	// It must be one of the namespaces the operator allows inheriting from.
+	// +optional
+	InheritFrom string `json:"inheritFrom,omitempty"`
+
+	// InheritKeys are the label keys copied from InheritFrom. Keys the source does not carry are skipped.
+	// +optional
+	InheritKeys []string `json:"inheritKeys,omitempty"`
+
+	// ApplyAfter delays applying labels until this long after the CR was created,
+	// giving other systems time to set up during staged onboarding.
+	// +optional
+	ApplyAfter *metav1.Duration `json:"applyAfter,omitempty"`
+
+	// ConditionalOnNamespace holds off applying labels until another namespace carries a label,
+	// e.g. a feature flag on a shared control namespace. Labels already applied are left in place meanwhile.
+	// A namespace other than the CR's own must be one of the namespaces the operator allows gating on.
+	// +optional
+	ConditionalOnNamespace *NamespaceCondition `json:"conditionalOnNamespace,omitempty"`
+
+	// ReconcileInterval re-applies the labels this often after a successful reconcile, overriding the
+	// operator's --resync-interval for this CR. Useful for templated labels derived from fast-changing state.
+	// +optional
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+
+	// DryRun computes and reports the label changes in status.wouldApply and status.wouldRemove
+	// without modifying the namespace.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// ListMergeKeys lists label keys whose values are underscore-separated lists, e.g. "backend_ops", since a
+	// comma is not allowed in label values. For these keys the items in labels are merged with the items already
+	// on the namespace instead of overwriting them, and only the items contributed by this CR are removed when
+	// they are dropped from the spec.
+	// +optional
+	ListMergeKeys []string `json:"listMergeKeys,omitempty"`
+
+	// KeyPrefix is prepended to every key from labels and hashLabels when applied to the namespace,
+	// e.g. "team.example.com/". Protection patterns match the prefixed keys stored on the namespace.
+	// +optional
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+
+	// NamespaceSelector applies the labels to every namespace matching the selector instead of the CR's
+	// own namespace. Only honoured for the NamespaceLabel in the operator's configured selector namespace.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ReportUnprefixedKeys strips KeyPrefix from the label keys reported in status,
+	// so status lines up with the keys written in the spec
+	// +optional
+	ReportUnprefixedKeys bool `json:"reportUnprefixedKeys,omitempty"`
+
+	// TTLSeconds removes the applied labels once this many seconds have passed since they were first applied for the current generation.
+	// The NamespaceLabel itself is kept and reports Expired until its spec changes. Zero disables the TTL.
+	// Not supported together with namespaceSelector.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TTLSeconds int64 `json:"ttlSeconds,omitempty"`
+
+	// ExportConfigMap names a ConfigMap in the CR's namespace that mirrors the applied labels after every
+	// successful apply, for tooling that cannot read NamespaceLabels. The ConfigMap is owned by the CR and
+	// deleted with it. Not supported together with namespaceSelector.
+	// +optional
+	ExportConfigMap string `json:"exportConfigMap,omitempty"`
+
+	// Priority decides which NamespaceLabel sets a label that several in the same namespace set in spec.labels,
+	// when the operator allows more than one per namespace. The highest priority wins, then the alphabetically
+	// first name; the others leave the label alone.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // NamespaceLabelStatus defines the observed state of NamespaceLabel
@@ -60,6 +366,10 @@ type NamespaceLabelStatus struct {
 	// Applied indicates whether the labels were successfully applied
 	Applied bool `json:"applied,omitempty"`
 
+	// ObservedGeneration is the CR generation the status was last computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Conditions represent the latest available observations of the resource's state
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
@@ -67,13 +377,128 @@ type NamespaceLabelStatus struct {
 	// +optional
 	ProtectedLabelsSkipped []string `json:"protectedLabelsSkipped,omitempty"`
 
+	// ProtectedLabelsSkippedCount is the number of protectedLabelsSkipped entries, for the printer columns
+	// +optional
+	ProtectedLabelsSkippedCount int32 `json:"protectedLabelsSkippedCount"`
+
+	// ProtectedLabelReasons maps each protected label key that was skipped, or failed the reconcile in fail
+	// mode, to the key or value pattern that protected it
+	// +optional
+	ProtectedLabelReasons map[string]string `json:"protectedLabelReasons,omitempty"`
+
 	// LabelsApplied lists the label keys that were successfully applied
 	// +optional
 	LabelsApplied []string `json:"labelsApplied,omitempty"`
+
+	// LabelsAppliedCount is the number of labelsApplied entries, for the printer columns
+	// +optional
+	LabelsAppliedCount int32 `json:"labelsAppliedCount"`
+
+	// AllowedLabels is the exact label set the operator intends to apply after protection filtering
+	// +optional
+	AllowedLabels map[string]string `json:"allowedLabels,omitempty"`
+
+	// RemovalProtected lists label keys that were dropped from the spec but kept on the namespace
+	// because they match removalProtectionPatterns
+	// +optional
+	RemovalProtected []string `json:"removalProtected,omitempty"`
+
+	// WouldApply lists the label keys a dry run would add or change on the namespace
+	// +optional
+	WouldApply []string `json:"wouldApply,omitempty"`
+
+	// WouldRemove lists the label keys a dry run would remove from the namespace
+	// +optional
+	WouldRemove []string `json:"wouldRemove,omitempty"`
+
+	// PendingChanges lists the spec labels a dry run would change compared to the last applied spec
+	// +optional
+	PendingChanges []LabelChange `json:"pendingChanges,omitempty"`
+
+	// LabelSources maps each applied label key to its source: "spec" for labels and labelEntries, "template" for
+	// labelsTemplate, "configmap/<name>" for hash labels, "namespace/<name>" for labels inherited from inheritFrom
+	// and "operator" for the protection status label
+	// +optional
+	LabelSources map[string]string `json:"labelSources,omitempty"`
+
+	// DisallowedLabels lists label keys dropped because they match none of allowedLabelPatterns
+	// +optional
+	DisallowedLabels []string `json:"disallowedLabels,omitempty"`
+
+	// LabelsRemoved lists the removeLabels keys deleted from the namespace by the latest reconcile that deleted any
+	// +optional
+	LabelsRemoved []string `json:"labelsRemoved,omitempty"`
+
+	// PreservedLabels maps the keys, as stored on the namespace, whose existing value protection kept to that
+	// value. With preserveProtectedValues they keep it on later reconciles even once no longer protected.
+	// +optional
+	PreservedLabels map[string]string `json:"preservedLabels,omitempty"`
+
+	// OverwriteConflicts lists label keys left unchanged under overwritePolicy ifOwned because the namespace
+	// already had a different value the operator did not apply
+	// +optional
+	OverwriteConflicts []string `json:"overwriteConflicts,omitempty"`
+
+	// ClaimedBy maps each spec label key left to a higher-priority NamespaceLabel in the same namespace
+	// to the name of that NamespaceLabel
+	// +optional
+	ClaimedBy map[string]string `json:"claimedBy,omitempty"`
+
+	// SelectedNamespaces lists the namespaces labeled through spec.namespaceSelector
+	// +optional
+	SelectedNamespaces []string `json:"selectedNamespaces,omitempty"`
+
+	// LastAppliedTime is when a reconcile last changed the namespace labels
+	// +optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+
+	// TTLStartTime is when the labels of generation ttlStartGeneration were first applied. spec.ttlSeconds
+	// counts from it, so re-applying them, e.g. to correct drift, does not extend the TTL.
+	// +optional
+	TTLStartTime *metav1.Time `json:"ttlStartTime,omitempty"`
+
+	// TTLStartGeneration is the CR generation ttlStartTime was recorded for
+	// +optional
+	TTLStartGeneration int64 `json:"ttlStartGeneration,omitempty"`
+
+	// LabelChanges is what the last reconcile that changed the namespace labels added, updated and removed
+	// +optional
+	LabelChanges *LabelChanges `json:"labelChanges,omitempty"`
+
+	// LastDriftDetected is when the namespace labels were last found diverged from the applied labels
+	// +optional
+	LastDriftDetected *metav1.Time `json:"lastDriftDetected,omitempty"`
+
+	// IntegrityOK is true when, before the last reconcile re-applied them, every label in the applied
+	// annotation was still on the namespace with its recorded value, and false after external modification
+	IntegrityOK bool `json:"integrityOK"`
+
+	// ConfigHash is a stable hash of the effective configuration the labels were reconciled with: the
+	// resolved protection patterns and modes, label sources and the operator-wide settings. It changes
+	// when behavior may differ even though the spec did not.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// NextReconcileAt is approximately when the controller will reconcile the CR again on its own, from the
+	// requeue interval of the last reconcile. Unset when no requeue was scheduled.
+	// +optional
+	NextReconcileAt *metav1.Time `json:"nextReconcileAt,omitempty"`
+
+	// Stats counts the requested, applied, skipped, failed, removed and protected labels of the last reconcile
+	// +optional
+	Stats *ReconcileStats `json:"stats,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Applied",type=boolean,JSONPath=`.status.applied`
+//+kubebuilder:printcolumn:name="Labels",type=integer,JSONPath=`.status.labelsAppliedCount`
+//+kubebuilder:printcolumn:name="Skipped",type=integer,JSONPath=`.status.protectedLabelsSkippedCount`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+//+kubebuilder:printcolumn:name="Generation",type=integer,JSONPath=`.metadata.generation`
+//+kubebuilder:printcolumn:name="Observed",type=integer,JSONPath=`.status.observedGeneration`
+//+kubebuilder:printcolumn:name="Last Applied",type=date,JSONPath=`.status.lastAppliedTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // NamespaceLabel is the Schema for the namespacelabels API
 type NamespaceLabel struct {
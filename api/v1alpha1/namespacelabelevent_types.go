@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespaceLabelEventAction is the reconcile outcome captured by a NamespaceLabelEvent
+// +kubebuilder:validation:Enum=Applied;Skipped;Failed
+type NamespaceLabelEventAction string
+
+const (
+	// EventActionApplied records that labels were changed on the namespace
+	EventActionApplied NamespaceLabelEventAction = "Applied"
+	// EventActionSkipped records that protected labels were skipped
+	EventActionSkipped NamespaceLabelEventAction = "Skipped"
+	// EventActionFailed records that reconciliation failed due to protected label conflicts
+	EventActionFailed NamespaceLabelEventAction = "Failed"
+)
+
+// NamespaceLabelEventSpec describes a single significant action taken for a NamespaceLabel
+type NamespaceLabelEventSpec struct {
+	// NamespaceLabel is the name of the NamespaceLabel that produced this event
+	NamespaceLabel string `json:"namespaceLabel"`
+
+	// Action is the outcome that was recorded
+	Action NamespaceLabelEventAction `json:"action"`
+
+	// Labels lists the label keys affected by the action
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// Message is a human readable description of the action
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Timestamp is when the action happened. Events older than the operator's TTL are cleaned up.
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Action",type=string,JSONPath=`.spec.action`
+//+kubebuilder:printcolumn:name="NamespaceLabel",type=string,JSONPath=`.spec.namespaceLabel`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NamespaceLabelEvent is a queryable audit record of a reconcile decision for a NamespaceLabel
+type NamespaceLabelEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NamespaceLabelEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NamespaceLabelEventList contains a list of NamespaceLabelEvent
+type NamespaceLabelEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespaceLabelEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NamespaceLabelEvent{}, &NamespaceLabelEventList{})
+}
@@ -25,6 +25,96 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HashLabelSpec) DeepCopyInto(out *HashLabelSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HashLabelSpec.
+func (in *HashLabelSpec) DeepCopy() *HashLabelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HashLabelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelChange) DeepCopyInto(out *LabelChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelChange.
+func (in *LabelChange) DeepCopy() *LabelChange {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelChanges) DeepCopyInto(out *LabelChanges) {
+	*out = *in
+	if in.Added != nil {
+		in, out := &in.Added, &out.Added
+		*out = make([]LabelChange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Updated != nil {
+		in, out := &in.Updated, &out.Updated
+		*out = make([]LabelChange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Removed != nil {
+		in, out := &in.Removed, &out.Removed
+		*out = make([]LabelChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelChanges.
+func (in *LabelChanges) DeepCopy() *LabelChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelEntry) DeepCopyInto(out *LabelEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelEntry.
+func (in *LabelEntry) DeepCopy() *LabelEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceCondition) DeepCopyInto(out *NamespaceCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceCondition.
+func (in *NamespaceCondition) DeepCopy() *NamespaceCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceLabel) DeepCopyInto(out *NamespaceLabel) {
 	*out = *in
@@ -52,6 +142,85 @@ func (in *NamespaceLabel) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceLabelEvent) DeepCopyInto(out *NamespaceLabelEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceLabelEvent.
+func (in *NamespaceLabelEvent) DeepCopy() *NamespaceLabelEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceLabelEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceLabelEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceLabelEventList) DeepCopyInto(out *NamespaceLabelEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceLabelEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceLabelEventList.
+func (in *NamespaceLabelEventList) DeepCopy() *NamespaceLabelEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceLabelEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceLabelEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceLabelEventSpec) DeepCopyInto(out *NamespaceLabelEventSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceLabelEventSpec.
+func (in *NamespaceLabelEventSpec) DeepCopy() *NamespaceLabelEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceLabelEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceLabelList) DeepCopyInto(out *NamespaceLabelList) {
 	*out = *in
@@ -94,11 +263,95 @@ func (in *NamespaceLabelSpec) DeepCopyInto(out *NamespaceLabelSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.LabelEntries != nil {
+		in, out := &in.LabelEntries, &out.LabelEntries
+		*out = make([]LabelEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoveLabels != nil {
+		in, out := &in.RemoveLabels, &out.RemoveLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AllowedLabelPatterns != nil {
+		in, out := &in.AllowedLabelPatterns, &out.AllowedLabelPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ProtectedLabelPatterns != nil {
 		in, out := &in.ProtectedLabelPatterns, &out.ProtectedLabelPatterns
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ProtectedValuePatterns != nil {
+		in, out := &in.ProtectedValuePatterns, &out.ProtectedValuePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovalProtectionPatterns != nil {
+		in, out := &in.RemovalProtectionPatterns, &out.RemovalProtectionPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PruneStaleLabels != nil {
+		in, out := &in.PruneStaleLabels, &out.PruneStaleLabels
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProtectedLabelRules != nil {
+		in, out := &in.ProtectedLabelRules, &out.ProtectedLabelRules
+		*out = make([]ProtectedLabelRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.TierProtectedLabelPatterns != nil {
+		in, out := &in.TierProtectedLabelPatterns, &out.TierProtectedLabelPatterns
+		*out = make([]TierProtectedLabelPatterns, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HashLabels != nil {
+		in, out := &in.HashLabels, &out.HashLabels
+		*out = make([]HashLabelSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.InheritKeys != nil {
+		in, out := &in.InheritKeys, &out.InheritKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApplyAfter != nil {
+		in, out := &in.ApplyAfter, &out.ApplyAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ConditionalOnNamespace != nil {
+		in, out := &in.ConditionalOnNamespace, &out.ConditionalOnNamespace
+		*out = new(NamespaceCondition)
+		**out = **in
+	}
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ListMergeKeys != nil {
+		in, out := &in.ListMergeKeys, &out.ListMergeKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceLabelSpec.
@@ -126,11 +379,112 @@ func (in *NamespaceLabelStatus) DeepCopyInto(out *NamespaceLabelStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ProtectedLabelReasons != nil {
+		in, out := &in.ProtectedLabelReasons, &out.ProtectedLabelReasons
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.LabelsApplied != nil {
 		in, out := &in.LabelsApplied, &out.LabelsApplied
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowedLabels != nil {
+		in, out := &in.AllowedLabels, &out.AllowedLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RemovalProtected != nil {
+		in, out := &in.RemovalProtected, &out.RemovalProtected
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WouldApply != nil {
+		in, out := &in.WouldApply, &out.WouldApply
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WouldRemove != nil {
+		in, out := &in.WouldRemove, &out.WouldRemove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = make([]LabelChange, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSources != nil {
+		in, out := &in.LabelSources, &out.LabelSources
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DisallowedLabels != nil {
+		in, out := &in.DisallowedLabels, &out.DisallowedLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelsRemoved != nil {
+		in, out := &in.LabelsRemoved, &out.LabelsRemoved
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreservedLabels != nil {
+		in, out := &in.PreservedLabels, &out.PreservedLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.OverwriteConflicts != nil {
+		in, out := &in.OverwriteConflicts, &out.OverwriteConflicts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClaimedBy != nil {
+		in, out := &in.ClaimedBy, &out.ClaimedBy
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SelectedNamespaces != nil {
+		in, out := &in.SelectedNamespaces, &out.SelectedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TTLStartTime != nil {
+		in, out := &in.TTLStartTime, &out.TTLStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LabelChanges != nil {
+		in, out := &in.LabelChanges, &out.LabelChanges
+		*out = new(LabelChanges)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastDriftDetected != nil {
+		in, out := &in.LastDriftDetected, &out.LastDriftDetected
+		*out = (*in).DeepCopy()
+	}
+	if in.NextReconcileAt != nil {
+		in, out := &in.NextReconcileAt, &out.NextReconcileAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Stats != nil {
+		in, out := &in.Stats, &out.Stats
+		*out = new(ReconcileStats)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceLabelStatus.
@@ -142,3 +496,53 @@ func (in *NamespaceLabelStatus) DeepCopy() *NamespaceLabelStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectedLabelRule) DeepCopyInto(out *ProtectedLabelRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectedLabelRule.
+func (in *ProtectedLabelRule) DeepCopy() *ProtectedLabelRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectedLabelRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileStats) DeepCopyInto(out *ReconcileStats) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileStats.
+func (in *ReconcileStats) DeepCopy() *ReconcileStats {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TierProtectedLabelPatterns) DeepCopyInto(out *TierProtectedLabelPatterns) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TierProtectedLabelPatterns.
+func (in *TierProtectedLabelPatterns) DeepCopy() *TierProtectedLabelPatterns {
+	if in == nil {
+		return nil
+	}
+	out := new(TierProtectedLabelPatterns)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,24 +17,38 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
 	"github.com/sbahar619/namespace-label-operator/internal/controller"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -45,6 +59,7 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 
 	utilruntime.Must(labelsv1alpha1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
@@ -56,6 +71,42 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var orderedAppliedAnnotation bool
+	var enableEventResources bool
+	var eventResourceTTL time.Duration
+	var globalWriteQPS float64
+	var globalWriteBurst int
+	var maxLabels int
+	var labelLimitMargin int
+	var failRequeueInterval time.Duration
+	var maxFailRequeueInterval time.Duration
+	var resyncInterval time.Duration
+	var statusUpdateRetries int
+	var maxConcurrentReconciles int
+	var aggregateWarningEvents bool
+	var ownerUIDAnnotation bool
+	var changelogEntries int
+	var preflightNamespaceUpdates bool
+	var protectionStatusLabel string
+	var labelValueCharset string
+	var reservedLabelPrefixes string
+	var maxSpecLabels int
+	var deniedValueSubstrings string
+	var rejectSelfProtectedLabels bool
+	var lastAppliedSpecAnnotation string
+	var mandatoryLabelPatterns string
+	var mandatoryLabelPolicy string
+	var defaultProtectedLabelPatterns string
+	var archiveConfigMap string
+	var selectorNamespace string
+	var inheritSourceNamespaces string
+	var conditionalNamespaces string
+	var multipleNamespaceLabels bool
+	var reconcileStalenessWindow time.Duration
+	var orphanSweepInterval time.Duration
+	var orphanGracePeriod time.Duration
+	var otlpEndpoint string
+	var otlpInsecure bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -65,6 +116,87 @@ func main() {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&orderedAppliedAnnotation, "ordered-applied-annotation", false,
+		"If set, the applied-labels annotation is stored as an ordered list of key/value entries, spec.labelEntries first in their declared order")
+	flag.BoolVar(&enableEventResources, "enable-event-resources", false,
+		"If set, NamespaceLabelEvent resources are created for applied, skipped and failed reconciles")
+	flag.DurationVar(&eventResourceTTL, "event-resource-ttl", 24*time.Hour,
+		"How long NamespaceLabelEvent resources are kept before being cleaned up")
+	flag.Float64Var(&globalWriteQPS, "global-write-qps", 0,
+		"Maximum mutating API calls per second across all reconciles. 0 disables the limit.")
+	flag.IntVar(&globalWriteBurst, "global-write-burst", 10,
+		"Burst size for --global-write-qps")
+	flag.IntVar(&maxLabels, "max-labels", 64,
+		"Namespace label count to warn about via the NearLabelLimit condition. 0 disables the warning.")
+	flag.IntVar(&labelLimitMargin, "label-limit-margin", 5,
+		"How many labels below --max-labels a namespace may reach before the NearLabelLimit warning is raised")
+	flag.DurationVar(&failRequeueInterval, "fail-requeue-interval", 5*time.Minute,
+		"How long to wait before retrying a NamespaceLabel that failed on a protected label conflict; "+
+			"doubled for every further consecutive conflict")
+	flag.DurationVar(&maxFailRequeueInterval, "max-fail-requeue-interval", time.Hour,
+		"Upper bound for the retry interval of a NamespaceLabel that keeps failing on protected label conflicts")
+	flag.DurationVar(&resyncInterval, "resync-interval", 0,
+		"How often a successfully applied NamespaceLabel is reconciled again; spec.reconcileInterval overrides it per CR. "+
+			"0 leaves resync to the cache sync period.")
+	flag.IntVar(&statusUpdateRetries, "status-update-retries", 5,
+		"How many times a NamespaceLabel status update is attempted when it hits a conflict")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"How many NamespaceLabels are reconciled in parallel. Raise it on clusters with many namespaces.")
+	flag.BoolVar(&aggregateWarningEvents, "aggregate-warning-events", false,
+		"If set, a single Warning event lists all protected labels skipped in a reconcile instead of one event per label")
+	flag.BoolVar(&preflightNamespaceUpdates, "preflight-namespace-updates", false,
+		"If set, every namespace update is first sent as a server-side dry run; an update admission would reject is "+
+			"reported in the "+controller.ConditionApplyWouldBeRejected+" condition instead of being attempted")
+	flag.BoolVar(&ownerUIDAnnotation, "owner-uid-annotation", false,
+		"If set, each namespace is annotated with "+controller.OwnerUIDAnnoKey+" carrying the UID of the NamespaceLabel managing its labels")
+	flag.IntVar(&changelogEntries, "changelog-entries", 0,
+		"How many of the latest label changes to keep in the "+controller.ChangelogAnnoKey+" namespace annotation. 0 disables it.")
+	flag.StringVar(&protectionStatusLabel, "protection-status-label", "",
+		"Label key set to '"+controller.ProtectionStatusLabelValue+"' on namespaces whose NamespaceLabel configures protection, "+
+			"e.g. labels.shahaf.com/protection. Empty disables the label.")
+	flag.StringVar(&labelValueCharset, "label-value-charset", "",
+		"Characters applied label values are restricted to: '"+string(webhookv1alpha1.ValueCharsetASCIIPrintable)+"'. "+
+			"Labels with other values are not applied. Empty disables the check; set it like the webhook's flag.")
+	flag.StringVar(&reservedLabelPrefixes, "reserved-label-prefixes", strings.Join(webhookv1alpha1.DefaultReservedLabelPrefixes, ","),
+		"Reserved label prefixes checked by reconcile-time spec validation; set it like the webhook's flag.")
+	flag.IntVar(&maxSpecLabels, "max-spec-labels", webhookv1alpha1.DefaultMaxLabels,
+		"spec.labels limit checked by reconcile-time spec validation; set it like the webhook's --max-labels.")
+	flag.StringVar(&deniedValueSubstrings, "denied-value-substrings", "",
+		"Denied label value substrings checked by reconcile-time spec validation; set it like the webhook's flag.")
+	flag.BoolVar(&rejectSelfProtectedLabels, "reject-self-protected-labels", false,
+		"If set, reconcile-time spec validation fails labels matching the CR's own fail-mode protection; set it like the webhook's flag.")
+	flag.StringVar(&lastAppliedSpecAnnotation, "last-applied-spec-annotation", controller.LastAppliedSpecAnnoKey,
+		"Annotation key on each NamespaceLabel that records, as JSON, the spec labels of its last successful apply")
+	flag.StringVar(&mandatoryLabelPatterns, "mandatory-label-patterns", "",
+		"Comma-separated glob or regex: patterns of labels every namespace must keep when its NamespaceLabel is deleted")
+	flag.StringVar(&mandatoryLabelPolicy, "mandatory-label-policy", string(controller.MandatoryLabelPolicyPreserve),
+		"What deleting a NamespaceLabel does to managed labels matching --mandatory-label-patterns: "+
+			"preserve leaves them on the namespace unmanaged, warn removes them with a Warning event")
+	flag.StringVar(&defaultProtectedLabelPatterns, "default-protected-label-patterns", "",
+		"Comma-separated glob or regex: patterns of label keys protected on every namespace, e.g. 'kubernetes.io/*,openshift.io/*'. "+
+			"Each NamespaceLabel's protection is added to them; its exceptions do not apply to them.")
+	flag.DurationVar(&reconcileStalenessWindow, "reconcile-staleness-window", 0,
+		"Fail /healthz when no reconcile has succeeded for this long while NamespaceLabels exist. "+
+			"Requires a shorter --resync-interval. 0 disables the check.")
+	flag.DurationVar(&orphanSweepInterval, "orphan-sweep-interval", 0,
+		"How often namespaces are scanned for labels left by NamespaceLabels deleted without finalization. 0 disables the sweep.")
+	flag.DurationVar(&orphanGracePeriod, "orphan-grace-period", time.Hour,
+		"How long a namespace must stay orphaned before --orphan-sweep-interval removes its tracked labels")
+	flag.StringVar(&selectorNamespace, "selector-namespace", "",
+		"Namespace whose NamespaceLabel may use spec.namespaceSelector to label other namespaces. Empty disables selectors.")
+	flag.StringVar(&inheritSourceNamespaces, "inherit-source-namespaces", "",
+		"Comma-separated namespaces a NamespaceLabel may copy labels from with spec.inheritFrom. Empty disables inheritance.")
+	flag.StringVar(&conditionalNamespaces, "conditional-namespaces", "",
+		"Comma-separated namespaces a NamespaceLabel may gate on with spec.conditionalOnNamespace, besides its own.")
+	flag.BoolVar(&multipleNamespaceLabels, "allow-multiple-namespace-labels", false,
+		"If set, the labels of several NamespaceLabels in a namespace are merged, a label they share going to the highest spec.priority. "+
+			"Must match the webhook's flag of the same name.")
+	flag.StringVar(&archiveConfigMap, "archive-configmap", "",
+		"Namespace/name of a ConfigMap that records the managed labels of each deleted namespace. Empty disables archiving.")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "",
+		"host:port of an OTLP/HTTP collector that receives reconcile traces. Empty disables tracing.")
+	flag.BoolVar(&otlpInsecure, "otlp-insecure", false,
+		"If set, traces are sent to --otlp-endpoint over plain HTTP instead of HTTPS")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -96,6 +228,11 @@ func main() {
 			SecureServing: secureMetrics,
 			TLSOpts:       tlsOpts,
 		},
+		// ConfigMaps are read straight from the API server so the cache never holds every ConfigMap of the cluster;
+		// the controller watches them by metadata only
+		Client: client.Options{
+			Cache: &client.CacheOptions{DisableFor: []client.Object{&corev1.ConfigMap{}}},
+		},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "88bf519b.shahaf.com",
@@ -116,28 +253,188 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (&controller.NamespaceLabelReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-	}).SetupWithManager(mgr); err != nil {
+	var writeBudget *controller.WriteBudget
+	if globalWriteQPS > 0 {
+		writeBudget = controller.NewWriteBudget(globalWriteQPS, globalWriteBurst)
+	}
+
+	if errs := validation.IsQualifiedName(lastAppliedSpecAnnotation); len(errs) > 0 {
+		setupLog.Error(fmt.Errorf("%s", strings.Join(errs, "; ")), "invalid --last-applied-spec-annotation")
+		os.Exit(1)
+	}
+
+	if protectionStatusLabel != "" {
+		if errs := validation.IsQualifiedName(protectionStatusLabel); len(errs) > 0 {
+			setupLog.Error(fmt.Errorf("%s", strings.Join(errs, "; ")), "invalid --protection-status-label")
+			os.Exit(1)
+		}
+	}
+
+	valueCharset, err := webhookv1alpha1.ParseValueCharset(labelValueCharset)
+	if err != nil {
+		setupLog.Error(err, "invalid --label-value-charset")
+		os.Exit(1)
+	}
+
+	if changelogEntries < 0 {
+		setupLog.Error(fmt.Errorf("expected 0 or more, got %d", changelogEntries), "invalid --changelog-entries")
+		os.Exit(1)
+	}
+
+	if maxConcurrentReconciles < 1 {
+		setupLog.Error(fmt.Errorf("expected at least 1, got %d", maxConcurrentReconciles), "invalid --max-concurrent-reconciles")
+		os.Exit(1)
+	}
+
+	if reconcileStalenessWindow > 0 && (resyncInterval <= 0 || resyncInterval >= reconcileStalenessWindow) {
+		setupLog.Error(fmt.Errorf("--resync-interval %s must be positive and shorter than the window %s",
+			resyncInterval, reconcileStalenessWindow), "invalid --reconcile-staleness-window")
+		os.Exit(1)
+	}
+
+	policy := controller.MandatoryLabelPolicy(mandatoryLabelPolicy)
+	if policy != controller.MandatoryLabelPolicyPreserve && policy != controller.MandatoryLabelPolicyWarn {
+		setupLog.Error(fmt.Errorf("expected preserve or warn, got %q", mandatoryLabelPolicy), "invalid --mandatory-label-policy")
+		os.Exit(1)
+	}
+	mandatoryPatterns := splitPatterns(mandatoryLabelPatterns)
+	defaultProtection := splitPatterns(defaultProtectedLabelPatterns)
+
+	var stateArchiver controller.StateArchiver
+	if archiveConfigMap != "" {
+		archiveNS, archiveName, ok := strings.Cut(archiveConfigMap, "/")
+		if !ok || archiveNS == "" || archiveName == "" {
+			setupLog.Error(fmt.Errorf("expected <namespace>/<name>, got %q", archiveConfigMap), "invalid --archive-configmap")
+			os.Exit(1)
+		}
+		stateArchiver = &controller.ConfigMapArchiver{Client: mgr.GetClient(), Namespace: archiveNS, Name: archiveName}
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+
+	var tracerProvider trace.TracerProvider
+	if otlpEndpoint != "" {
+		sdkProvider, err := newTracerProvider(ctx, otlpEndpoint, otlpInsecure)
+		if err != nil {
+			setupLog.Error(err, "unable to set up OTLP trace exporter")
+			os.Exit(1)
+		}
+		defer func() {
+			if err := sdkProvider.Shutdown(context.Background()); err != nil {
+				setupLog.Error(err, "unable to flush traces")
+			}
+		}()
+		tracerProvider = sdkProvider
+	}
+
+	// Reconcile-time validation runs the webhook's checks with the webhook's flags, mirrored on the controller
+	specValidator := &webhookv1alpha1.NamespaceLabelCustomValidator{
+		Client:                       mgr.GetClient(),
+		ReservedLabelPrefixes:        splitPatterns(reservedLabelPrefixes),
+		MaxLabels:                    maxSpecLabels,
+		DeniedValueSubstrings:        splitPatterns(deniedValueSubstrings),
+		ValueCharset:                 valueCharset,
+		RejectSelfProtectedLabels:    rejectSelfProtectedLabels,
+		AllowMultipleNamespaceLabels: multipleNamespaceLabels,
+		MandatoryLabelPatterns:       mandatoryPatterns,
+	}
+
+	reconciler := &controller.NamespaceLabelReconciler{
+		Client:                        mgr.GetClient(),
+		Scheme:                        mgr.GetScheme(),
+		OrderedAppliedAnnotation:      orderedAppliedAnnotation,
+		EventResources:                enableEventResources,
+		EventResourceTTL:              eventResourceTTL,
+		WriteBudget:                   writeBudget,
+		MaxLabels:                     maxLabels,
+		LabelLimitMargin:              labelLimitMargin,
+		FailRequeueInterval:           failRequeueInterval,
+		MaxFailRequeueInterval:        maxFailRequeueInterval,
+		ResyncInterval:                resyncInterval,
+		StatusUpdateRetries:           statusUpdateRetries,
+		MaxConcurrentReconciles:       maxConcurrentReconciles,
+		AggregateWarningEvents:        aggregateWarningEvents,
+		OwnerUIDAnnotation:            ownerUIDAnnotation,
+		ChangelogEntries:              changelogEntries,
+		PreflightNamespaceUpdates:     preflightNamespaceUpdates,
+		ProtectionStatusLabel:         protectionStatusLabel,
+		ValueCharset:                  valueCharset,
+		SpecValidator:                 specValidator,
+		LastAppliedSpecAnnotation:     lastAppliedSpecAnnotation,
+		MandatoryLabelPatterns:        mandatoryPatterns,
+		MandatoryLabelPolicy:          policy,
+		DefaultProtectedLabelPatterns: defaultProtection,
+		StateArchiver:                 stateArchiver,
+		SelectorNamespace:             selectorNamespace,
+		InheritSourceNamespaces:       splitPatterns(inheritSourceNamespaces),
+		ConditionalNamespaces:         splitPatterns(conditionalNamespaces),
+		MultipleNamespaceLabels:       multipleNamespaceLabels,
+		TracerProvider:                tracerProvider,
+	}
+	if err = reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NamespaceLabel")
 		os.Exit(1)
 	}
 
+	if orphanSweepInterval > 0 {
+		if err := mgr.Add(&controller.OrphanSweeper{
+			Client:      mgr.GetClient(),
+			Interval:    orphanSweepInterval,
+			GracePeriod: orphanGracePeriod,
+			WriteBudget: writeBudget,
+		}); err != nil {
+			setupLog.Error(err, "unable to add orphan sweeper")
+			os.Exit(1)
+		}
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
+	if reconcileStalenessWindow > 0 {
+		if err := mgr.AddHealthzCheck("reconcile", reconciler.ReconcileHealthCheck(reconcileStalenessWindow, mgr.Elected())); err != nil {
+			setupLog.Error(err, "unable to set up reconcile health check")
+			os.Exit(1)
+		}
+	}
 	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// splitPatterns splits a comma-separated pattern flag, dropping empty entries
+func splitPatterns(list string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(list, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// newTracerProvider batches spans to the OTLP/HTTP collector at endpoint
+func newTracerProvider(ctx context.Context, endpoint string, insecure bool) (*sdktrace.TracerProvider, error) {
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+	if insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "namespace-label-operator"))),
+	), nil
+}
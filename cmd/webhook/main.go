@@ -20,6 +20,7 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -56,6 +57,14 @@ func main() {
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var webhookPort int
+	var reservedLabelPrefixes string
+	var maxLabels int
+	var deniedValueSubstrings string
+	var labelValueCharset string
+	var rejectSelfProtectedLabels bool
+	var allowMultipleNamespaceLabels bool
+	var defaultProtectedLabelPatterns string
+	var mandatoryLabelPatterns string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -64,6 +73,25 @@ func main() {
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook server serves at.")
+	flag.StringVar(&reservedLabelPrefixes, "reserved-label-prefixes", strings.Join(webhookv1alpha1.DefaultReservedLabelPrefixes, ","),
+		"Comma-separated label prefixes a NamespaceLabel may only set in namespaces annotated with "+
+			webhookv1alpha1.AllowReservedLabelsAnnoKey+"=true. Empty disables the check.")
+	flag.IntVar(&maxLabels, "max-labels", webhookv1alpha1.DefaultMaxLabels,
+		"Maximum number of spec.labels entries a single NamespaceLabel may hold. 0 disables the limit.")
+	flag.StringVar(&deniedValueSubstrings, "denied-value-substrings", "",
+		"Comma-separated substrings, such as password,secret, that no label value may contain (case-insensitive). Empty disables the check.")
+	flag.StringVar(&labelValueCharset, "label-value-charset", "",
+		"Characters label values are restricted to: '"+string(webhookv1alpha1.ValueCharsetASCIIPrintable)+"'. Empty disables the check.")
+	flag.BoolVar(&rejectSelfProtectedLabels, "reject-self-protected-labels", false,
+		"If set, a NamespaceLabel whose labels match its own fail-mode protection is rejected instead of admitted with a warning")
+	flag.BoolVar(&allowMultipleNamespaceLabels, "allow-multiple-namespace-labels", false,
+		"If set, a namespace may hold several NamespaceLabels under any name. Must match the controller's flag of the same name.")
+	flag.StringVar(&defaultProtectedLabelPatterns, "default-protected-label-patterns", "",
+		"Comma-separated glob or regex: patterns of label keys protected on every namespace, previewed in admission warnings. "+
+			"Must match the controller's flag of the same name.")
+	flag.StringVar(&mandatoryLabelPatterns, "mandatory-label-patterns", "",
+		"Comma-separated glob or regex: patterns of labels every namespace must keep. A NamespaceLabel setting one under its own "+
+			"fail-mode protection is rejected. Must match the controller's flag of the same name.")
 
 	opts := zap.Options{
 		Development: true,
@@ -112,7 +140,37 @@ func main() {
 	}
 
 	// Setup webhook
-	if err := webhookv1alpha1.SetupNamespaceLabelWebhookWithManager(mgr); err != nil {
+	var reservedPrefixes []string
+	for _, prefix := range strings.Split(reservedLabelPrefixes, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			reservedPrefixes = append(reservedPrefixes, prefix)
+		}
+	}
+	var deniedSubstrings []string
+	for _, substring := range strings.Split(deniedValueSubstrings, ",") {
+		if substring = strings.TrimSpace(substring); substring != "" {
+			deniedSubstrings = append(deniedSubstrings, substring)
+		}
+	}
+	var defaultProtection []string
+	for _, pattern := range strings.Split(defaultProtectedLabelPatterns, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			defaultProtection = append(defaultProtection, pattern)
+		}
+	}
+	var mandatoryPatterns []string
+	for _, pattern := range strings.Split(mandatoryLabelPatterns, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			mandatoryPatterns = append(mandatoryPatterns, pattern)
+		}
+	}
+	valueCharset, err := webhookv1alpha1.ParseValueCharset(labelValueCharset)
+	if err != nil {
+		setupLog.Error(err, "invalid --label-value-charset")
+		os.Exit(1)
+	}
+	if err := webhookv1alpha1.SetupNamespaceLabelWebhookWithManager(mgr, reservedPrefixes, maxLabels, deniedSubstrings, valueCharset,
+		rejectSelfProtectedLabels, allowMultipleNamespaceLabels, defaultProtection, mandatoryPatterns); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "NamespaceLabel")
 		os.Exit(1)
 	}
@@ -87,6 +87,15 @@ else
     echo "   The webhook may not be configured for validation."
 fi
 
+# The defaulting webhook is served by the same endpoint and needs the same CA bundle
+MUTATING_CONFIG=$(kubectl get mutatingwebhookconfigurations -o name 2>/dev/null | grep -E "(namespacelabel|mutating)" | head -1)
+if [ -n "$MUTATING_CONFIG" ]; then
+    echo "🔧 Updating $MUTATING_CONFIG with CA bundle..."
+    kubectl patch "$MUTATING_CONFIG" \
+        --type='json' \
+        -p="[{'op': 'replace', 'path': '/webhooks/0/clientConfig/caBundle', 'value': '$CA_BUNDLE'}]"
+fi
+
 # Cleanup
 cd - > /dev/null
 rm -rf "$TEMP_DIR"
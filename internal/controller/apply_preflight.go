@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// applyRejectedError reports that a server-side dry run of the namespace update was rejected
+type applyRejectedError struct {
+	err error
+}
+
+func (e *applyRejectedError) Error() string {
+	return fmt.Sprintf("namespace update would be rejected: %v", e.err)
+}
+
+func (e *applyRejectedError) Unwrap() error {
+	return e.err
+}
+
+// preflightNamespacePatch server-side dry-runs the patch patchNamespace would send, so admission can refuse it
+// without the real update being attempted. A refusal is returned as an applyRejectedError; conflicts and other
+// errors are returned as they are.
+func (r *NamespaceLabelReconciler) preflightNamespacePatch(ctx context.Context, ns, base *corev1.Namespace) error {
+	err := r.Patch(ctx, ns.DeepCopy(), client.StrategicMergeFrom(base, client.MergeFromWithOptimisticLock{}), client.DryRunAll)
+	if apierrors.IsForbidden(err) || apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) {
+		return &applyRejectedError{err: err}
+	}
+	return err
+}
+
+// reportApplyRejected records a refused namespace update in status and leaves the CR to be retried on its
+// resync interval or its next change, instead of failing the reconcile into an error requeue loop
+func (r *NamespaceLabelReconciler) reportApplyRejected(ctx context.Context, current *labelsv1alpha1.NamespaceLabel,
+	targetNS string, rejected *applyRejectedError) (ctrl.Result, error) {
+	l := log.FromContext(ctx)
+	message := fmt.Sprintf("Update of namespace '%s' would be rejected: %v", targetNS, rejected.err)
+	l.Info("Namespace update would be rejected, not applying", "namespace", targetNS, "error", rejected.err.Error())
+
+	if !hasReadyCondition(current, ConditionApplyWouldBeRejected, message) {
+		r.recordEvent(current, corev1.EventTypeWarning, ConditionApplyWouldBeRejected, message)
+	}
+	updateStatus(current, false, ConditionApplyWouldBeRejected, message, nil, nil)
+	setCondition(current, ConditionApplyWouldBeRejected, metav1.ConditionTrue, "DryRunRejected", rejected.err.Error())
+	requeueAfter := r.scheduleRequeue(current, r.resyncInterval(current))
+	if err := r.updateCRStatus(ctx, current); err != nil {
+		l.Error(err, "failed to update status for rejected namespace update")
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
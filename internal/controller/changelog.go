@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+)
+
+// changelogEntry is the label changes of one namespace update in the changelog annotation
+type changelogEntry struct {
+	Time    string   `json:"time"`
+	Added   []string `json:"added,omitempty"`
+	Updated []string `json:"updated,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// appendChangelog appends changes, made at now, to the changelog annotation on ns in memory and drops the oldest
+// entries beyond limit, so they are persisted with the same update. A changelog that cannot be parsed starts over.
+func appendChangelog(ns *corev1.Namespace, changes []labelsv1alpha1.LabelChange, now time.Time, limit int) error {
+	var entries []changelogEntry
+	if raw, ok := ns.Annotations[ChangelogAnnoKey]; ok {
+		if err := json.Unmarshal([]byte(raw), &entries); err != nil {
+			entries = nil
+		}
+	}
+
+	entry := changelogEntry{Time: now.UTC().Format(time.RFC3339)}
+	for _, change := range changes {
+		switch change.Action {
+		case labelsv1alpha1.LabelChangeAdd:
+			entry.Added = append(entry.Added, change.Key)
+		case labelsv1alpha1.LabelChangeUpdate:
+			entry.Updated = append(entry.Updated, change.Key)
+		case labelsv1alpha1.LabelChangeRemove:
+			entry.Removed = append(entry.Removed, change.Key)
+		}
+	}
+	entries = append(entries, entry)
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("marshal changelog: %w", err)
+	}
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+	ns.Annotations[ChangelogAnnoKey] = string(b)
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in changelog.go
+
+var _ = Describe("Namespace changelog", Label("controller"), func() {
+	var (
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		fakeClock  *clocktesting.FakeClock
+		ctx        context.Context
+		req        reconcile.Request
+	)
+
+	BeforeEach(func() {
+		f := newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+		fakeClock = clocktesting.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+		reconciler.Clock = fakeClock
+		reconciler.ChangelogEntries = 2
+		req = reconcileRequest(StandardCRName, "test-ns")
+
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "test-ns", Finalizers: []string{FinalizerName}},
+			Spec:       labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"env": "dev"}},
+		})).To(Succeed())
+	})
+
+	changelog := func() []changelogEntry {
+		var ns corev1.Namespace
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "test-ns"}, &ns)).To(Succeed())
+		var entries []changelogEntry
+		Expect(json.Unmarshal([]byte(ns.Annotations[ChangelogAnnoKey]), &entries)).To(Succeed())
+		return entries
+	}
+
+	setLabels := func(labels map[string]string) {
+		var cr labelsv1alpha1.NamespaceLabel
+		Expect(fakeClient.Get(ctx, req.NamespacedName, &cr)).To(Succeed())
+		cr.Spec.Labels = labels
+		Expect(fakeClient.Update(ctx, &cr)).To(Succeed())
+	}
+
+	It("should keep only the latest entries over multiple reconciles", func() {
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changelog()).To(Equal([]changelogEntry{{Time: "2025-01-01T12:00:00Z", Added: []string{"env"}}}))
+
+		By("not logging a reconcile that changes nothing")
+		fakeClock.Step(time.Minute)
+		_, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changelog()).To(HaveLen(1))
+
+		By("appending further changes")
+		setLabels(map[string]string{"env": "prod", "team": "a"})
+		_, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changelog()).To(HaveLen(2))
+
+		By("trimming the oldest entry once the limit is reached")
+		fakeClock.Step(time.Minute)
+		setLabels(map[string]string{"env": "prod"})
+		_, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changelog()).To(Equal([]changelogEntry{
+			{Time: "2025-01-01T12:01:00Z", Added: []string{"team"}, Updated: []string{"env"}},
+			{Time: "2025-01-01T12:02:00Z", Removed: []string{"team"}},
+		}))
+	})
+
+	It("should start over from an unreadable changelog", func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns", Annotations: map[string]string{ChangelogAnnoKey: "not json"}}}
+		Expect(appendChangelog(ns, []labelsv1alpha1.LabelChange{{Key: "env", Action: labelsv1alpha1.LabelChangeAdd}},
+			fakeClock.Now(), 2)).To(Succeed())
+		Expect(ns.Annotations[ChangelogAnnoKey]).To(Equal(`[{"time":"2025-01-01T12:00:00Z","added":["env"]}]`))
+	})
+
+	It("should not write a changelog when disabled", func() {
+		reconciler.ChangelogEntries = 0
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		var ns corev1.Namespace
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "test-ns"}, &ns)).To(Succeed())
+		Expect(ns.Annotations).NotTo(HaveKey(ChangelogAnnoKey))
+	})
+})
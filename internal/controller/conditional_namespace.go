@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// unmetNamespaceCondition returns the reason and message explaining why spec.conditionalOnNamespace is not
+// satisfied, or empty strings when it is. A control namespace other than the CR's own must be in
+// ConditionalNamespaces, since reading it uses the operator's cluster-wide access; any other is never met.
+// A missing control namespace leaves the condition unmet rather than failing the reconcile.
+func (r *NamespaceLabelReconciler) unmetNamespaceCondition(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel) (string, string, error) {
+	cond := cr.Spec.ConditionalOnNamespace
+	if cond == nil {
+		return "", "", nil
+	}
+	if cond.Name != cr.Namespace && !slices.Contains(r.ConditionalNamespaces, cond.Name) {
+		return "NamespaceNotAllowed", fmt.Sprintf("Gating on namespace '%s' is not allowed on this operator", cond.Name), nil
+	}
+
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: cond.Name}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "ConditionUnmet", fmt.Sprintf("Namespace '%s' does not exist", cond.Name), nil
+		}
+		return "", "", fmt.Errorf("failed to get namespace '%s' for conditionalOnNamespace: %w", cond.Name, err)
+	}
+	value, ok := ns.Labels[cond.LabelKey]
+	switch {
+	case !ok:
+		return "ConditionUnmet", fmt.Sprintf("Namespace '%s' does not have label '%s'", cond.Name, cond.LabelKey), nil
+	case cond.LabelValue != "" && value != cond.LabelValue:
+		return "ConditionUnmet", fmt.Sprintf("Namespace '%s' has label '%s' set to '%s', waiting for '%s'",
+			cond.Name, cond.LabelKey, value, cond.LabelValue), nil
+	}
+	return "", "", nil
+}
+
+// addDependentRequests appends the NamespaceLabels that read the namespace's labels through
+// spec.conditionalOnNamespace or spec.inheritFrom, so they follow as soon as its labels change
+func (r *NamespaceLabelReconciler) addDependentRequests(ctx context.Context, namespace string, requests []reconcile.Request) []reconcile.Request {
+	var list labelsv1alpha1.NamespaceLabelList
+	if err := r.List(ctx, &list); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list NamespaceLabels for namespace change", "namespace", namespace)
+		return requests
+	}
+	for _, item := range list.Items {
+		conditional := item.Spec.ConditionalOnNamespace != nil && item.Spec.ConditionalOnNamespace.Name == namespace
+		if !conditional && item.Spec.InheritFrom != namespace {
+			continue
+		}
+		req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)}
+		if !slices.Contains(requests, req) {
+			requests = append(requests, req)
+		}
+	}
+	return requests
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"sort"
+	"time"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+)
+
+// configHashVersion is bumped whenever the operator changes how an unchanged configuration is applied,
+// so upgrades that alter behavior show up as a new status.configHash
+const configHashVersion = 1
+
+// effectiveConfig is everything that decides how a CR's labels are applied, with defaults resolved and
+// order-insensitive lists sorted so equal configurations hash equally
+type effectiveConfig struct {
+	Version int `json:"version"`
+
+	ProtectionMode     labelsv1alpha1.ProtectionMode       `json:"protectionMode"`
+	ProtectionRules    []labelsv1alpha1.ProtectedLabelRule `json:"protectionRules"`
+	ProtectionPatterns []string                            `json:"protectionPatterns"`
+	ValuePatterns      []string                            `json:"valuePatterns"`
+	ProtectCreation    bool                                `json:"protectCreation"`
+	PreserveProtected  bool                                `json:"preserveProtected"`
+	OverwritePolicy    labelsv1alpha1.OverwritePolicy      `json:"overwritePolicy"`
+	RemovalProtection  []string                            `json:"removalProtection"`
+	PruneStaleLabels   bool                                `json:"pruneStaleLabels"`
+	AllowedPatterns    []string                            `json:"allowedPatterns"`
+	KeyPrefix          string                              `json:"keyPrefix"`
+	ListMergeKeys      []string                            `json:"listMergeKeys"`
+	Sources            map[string]string                   `json:"sources"`
+
+	OrderedAppliedAnnotation bool          `json:"orderedAppliedAnnotation"`
+	OwnerUIDAnnotation       bool          `json:"ownerUIDAnnotation"`
+	ProtectionStatusLabel    string        `json:"protectionStatusLabel"`
+	ValueCharset             string        `json:"valueCharset"`
+	ArchiveOnDelete          bool          `json:"archiveOnDelete"`
+	ResyncInterval           time.Duration `json:"resyncInterval"`
+}
+
+// configHash hashes the CR's effective configuration against the namespace's labels, which select the
+// protection tier, together with the operator-wide settings that change what gets written
+func (r *NamespaceLabelReconciler) configHash(cr *labelsv1alpha1.NamespaceLabel, nsLabels, sources map[string]string) string {
+	mode := cr.Spec.ProtectionMode
+	if mode == "" {
+		mode = labelsv1alpha1.ProtectionModeSkip
+	}
+	overwrite := cr.Spec.OverwritePolicy
+	if overwrite == "" {
+		overwrite = labelsv1alpha1.OverwritePolicyAlways
+	}
+	// Rules are matched in order, so only their modes are resolved
+	rules := make([]labelsv1alpha1.ProtectedLabelRule, 0, len(cr.Spec.ProtectedLabelRules))
+	for _, rule := range cr.Spec.ProtectedLabelRules {
+		if rule.Mode == "" {
+			rule.Mode = mode
+		}
+		rules = append(rules, rule)
+	}
+
+	cfg := effectiveConfig{
+		Version:                  configHashVersion,
+		ProtectionMode:           mode,
+		ProtectionRules:          rules,
+		ProtectionPatterns:       sortedCopy(withDefaultProtection(r.DefaultProtectedLabelPatterns, cr.Spec, nsLabels)),
+		ValuePatterns:            sortedCopy(cr.Spec.ProtectedValuePatterns),
+		ProtectCreation:          cr.Spec.ProtectCreation,
+		PreserveProtected:        cr.Spec.PreserveProtectedValues,
+		OverwritePolicy:          overwrite,
+		RemovalProtection:        sortedCopy(cr.Spec.RemovalProtectionPatterns),
+		PruneStaleLabels:         pruneStaleLabels(cr),
+		AllowedPatterns:          sortedCopy(cr.Spec.AllowedLabelPatterns),
+		KeyPrefix:                cr.Spec.KeyPrefix,
+		ListMergeKeys:            sortedCopy(cr.Spec.ListMergeKeys),
+		Sources:                  sources,
+		OrderedAppliedAnnotation: r.OrderedAppliedAnnotation,
+		OwnerUIDAnnotation:       r.OwnerUIDAnnotation,
+		ProtectionStatusLabel:    r.ProtectionStatusLabel,
+		ValueCharset:             string(r.ValueCharset),
+		ArchiveOnDelete:          r.StateArchiver != nil,
+		ResyncInterval:           r.resyncInterval(cr),
+	}
+
+	// Map keys are marshaled in sorted order, so the encoding is deterministic
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:hashLength]
+}
+
+// sortedCopy returns a sorted copy of items, leaving the spec untouched. Empty lists are nil so an
+// omitted and an empty list hash the same.
+func sortedCopy(items []string) []string {
+	if len(items) == 0 {
+		return nil
+	}
+	out := slices.Clone(items)
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/utils/ptr"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+)
+
+// Tests for functions in config_hash.go
+
+var _ = Describe("configHash", Label("controller"), func() {
+	spec := labelsv1alpha1.NamespaceLabelSpec{
+		Labels:                 map[string]string{"env": "prod"},
+		ProtectedLabelPatterns: []string{"kubernetes.io/*", "istio.io/*"},
+		ProtectionTierLabel:    "tier",
+		TierProtectedLabelPatterns: []labelsv1alpha1.TierProtectedLabelPatterns{
+			{Tier: "gold", Patterns: []string{"billing/*"}},
+		},
+	}
+	sources := map[string]string{"env": labelSourceSpec}
+
+	hash := func(r *NamespaceLabelReconciler, spec labelsv1alpha1.NamespaceLabelSpec, nsLabels map[string]string) string {
+		return r.configHash(&labelsv1alpha1.NamespaceLabel{Spec: spec}, nsLabels, sources)
+	}
+
+	It("should be deterministic and ignore pattern order and defaults", func() {
+		r := &NamespaceLabelReconciler{}
+		base := hash(r, spec, nil)
+		Expect(base).To(HaveLen(hashLength))
+		Expect(hash(r, spec, nil)).To(Equal(base))
+
+		reordered := *spec.DeepCopy()
+		reordered.ProtectedLabelPatterns = []string{"istio.io/*", "kubernetes.io/*"}
+		reordered.ProtectionMode = labelsv1alpha1.ProtectionModeSkip
+		reordered.AllowedLabelPatterns = []string{}
+		reordered.PruneStaleLabels = ptr.To(true)
+		Expect(hash(r, reordered, nil)).To(Equal(base))
+	})
+
+	It("should change with the effective protection", func() {
+		r := &NamespaceLabelReconciler{}
+		base := hash(r, spec, nil)
+
+		By("selecting a protection tier through the namespace labels")
+		Expect(hash(r, spec, map[string]string{"tier": "gold"})).NotTo(Equal(base))
+		Expect(hash(r, spec, map[string]string{"tier": "silver"})).To(Equal(base))
+
+		By("changing the protection mode")
+		failing := *spec.DeepCopy()
+		failing.ProtectionMode = labelsv1alpha1.ProtectionModeFail
+		Expect(hash(r, failing, nil)).NotTo(Equal(base))
+
+		By("turning off stale label pruning")
+		additive := *spec.DeepCopy()
+		additive.PruneStaleLabels = ptr.To(false)
+		Expect(hash(r, additive, nil)).NotTo(Equal(base))
+	})
+
+	It("should change with operator-wide settings", func() {
+		base := hash(&NamespaceLabelReconciler{}, spec, nil)
+		Expect(hash(&NamespaceLabelReconciler{OwnerUIDAnnotation: true}, spec, nil)).NotTo(Equal(base))
+		Expect(hash(&NamespaceLabelReconciler{OrderedAppliedAnnotation: true}, spec, nil)).NotTo(Equal(base))
+		Expect(hash(&NamespaceLabelReconciler{ResyncInterval: time.Hour}, spec, nil)).NotTo(Equal(base))
+	})
+
+	It("should report a new hash in status when the global policy changes", func() {
+		f := newTestFixture()
+		f.createNamespace("test-ns", nil, nil)
+		f.createCR(StandardCRName, "test-ns", nil, []string{FinalizerName},
+			labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"env": "prod"}})
+
+		reconcileHash := func() string {
+			_, err := f.reconcile(StandardCRName, "test-ns")
+			Expect(err).NotTo(HaveOccurred())
+			return f.getCR(StandardCRName, "test-ns").Status.ConfigHash
+		}
+
+		first := reconcileHash()
+		Expect(first).NotTo(BeEmpty())
+		Expect(reconcileHash()).To(Equal(first))
+
+		f.reconciler.OwnerUIDAnnotation = true
+		Expect(reconcileHash()).NotTo(Equal(first))
+	})
+})
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"slices"
+	"sort"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	"github.com/sbahar619/namespace-label-operator/internal/protection"
+)
+
+// withDefaultProtection returns the operator's default protection patterns followed by the CR's active ones
+func withDefaultProtection(defaults []string, spec labelsv1alpha1.NamespaceLabelSpec, nsLabels map[string]string) []string {
+	return append(slices.Clip(defaults), protection.ActivePatterns(spec, nsLabels)...)
+}
+
+// enforceDefaultProtection re-checks the labels protection allowed against the default patterns alone. The CR's
+// "!" exceptions apply to the merged patterns, so without this they could exempt a key the defaults protect.
+// Keys caught here are handled with the CR's protectionMode and protectCreation like any other protected key.
+func enforceDefaultProtection(result *ProtectionResult, defaults []string, existing map[string]string,
+	spec labelsv1alpha1.NamespaceLabelSpec) {
+	if len(defaults) == 0 {
+		return
+	}
+	enforced := applyProtectionLogic(result.AllowedLabels, existing, defaults, nil, spec.ProtectionMode, spec.ProtectCreation, nil)
+	result.AllowedLabels = enforced.AllowedLabels
+	result.ProtectedSkipped = append(result.ProtectedSkipped, enforced.ProtectedSkipped...)
+	result.Warnings = append(result.Warnings, enforced.Warnings...)
+	if result.Reasons == nil {
+		result.Reasons = map[string]string{}
+	}
+	for key, pattern := range enforced.Reasons {
+		result.Reasons[key] = pattern
+	}
+	if enforced.ShouldFail {
+		result.ShouldFail = true
+		result.ConflictingKeys = append(result.ConflictingKeys, enforced.ConflictingKeys...)
+		sort.Strings(result.ConflictingKeys)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+)
+
+// Tests for functions in default_protection.go
+
+var _ = Describe("Default protection", Label("controller"), func() {
+	var f *testFixture
+
+	BeforeEach(func() {
+		f = newTestFixture()
+		f.reconciler.DefaultProtectedLabelPatterns = []string{"kubernetes.io/*", "openshift.io/*"}
+		f.createNamespace("test-ns", map[string]string{"kubernetes.io/os": "linux", "openshift.io/run-level": "0", "team": "a"}, nil)
+	})
+
+	// reconcileSpec reconciles a CR with spec against test-ns and returns the namespace's labels and the CR
+	reconcileSpec := func(spec labelsv1alpha1.NamespaceLabelSpec) (map[string]string, *labelsv1alpha1.NamespaceLabel) {
+		cr, ns, _ := f.reconcileSpec("test-ns", spec)
+		return ns.Labels, cr
+	}
+
+	It("should protect the default patterns for a CR without protection of its own", func() {
+		nsLabels, cr := reconcileSpec(labelsv1alpha1.NamespaceLabelSpec{
+			Labels: map[string]string{"kubernetes.io/os": "windows", "team": "b"},
+		})
+		Expect(nsLabels).To(HaveKeyWithValue("kubernetes.io/os", "linux"))
+		Expect(nsLabels).To(HaveKeyWithValue("team", "b"))
+		Expect(cr.Status.ProtectedLabelsSkipped).To(ConsistOf("kubernetes.io/os"))
+	})
+
+	It("should add the CR's patterns to the defaults", func() {
+		nsLabels, cr := reconcileSpec(labelsv1alpha1.NamespaceLabelSpec{
+			Labels:                 map[string]string{"openshift.io/run-level": "1", "team": "b"},
+			ProtectedLabelPatterns: []string{"team"},
+		})
+		Expect(nsLabels).To(HaveKeyWithValue("openshift.io/run-level", "0"))
+		Expect(nsLabels).To(HaveKeyWithValue("team", "a"))
+		Expect(cr.Status.ProtectedLabelsSkipped).To(ConsistOf("openshift.io/run-level", "team"))
+		Expect(cr.Status.ProtectedLabelReasons).To(Equal(map[string]string{"openshift.io/run-level": "openshift.io/*", "team": "team"}))
+	})
+
+	It("should not let the CR's exceptions weaken the defaults", func() {
+		nsLabels, cr := reconcileSpec(labelsv1alpha1.NamespaceLabelSpec{
+			Labels:                 map[string]string{"kubernetes.io/os": "windows"},
+			ProtectedLabelPatterns: []string{"!kubernetes.io/os"},
+			ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+		})
+		Expect(nsLabels).To(HaveKeyWithValue("kubernetes.io/os", "linux"))
+		Expect(cr.Status.Applied).To(BeFalse())
+	})
+
+	It("should handle keys caught by the defaults with the CR's mode", func() {
+		result := ProtectionResult{
+			AllowedLabels:    map[string]string{"kubernetes.io/os": "windows", "team": "b"},
+			ProtectedSkipped: []string{"owner"},
+		}
+		enforceDefaultProtection(&result, []string{"kubernetes.io/*"}, map[string]string{"kubernetes.io/os": "linux"},
+			labelsv1alpha1.NamespaceLabelSpec{ProtectionMode: labelsv1alpha1.ProtectionModeWarn})
+		Expect(result.ShouldFail).To(BeFalse())
+		Expect(result.AllowedLabels).To(Equal(map[string]string{"team": "b"}))
+		Expect(result.ProtectedSkipped).To(ConsistOf("owner", "kubernetes.io/os"))
+		Expect(result.Warnings).To(ConsistOf(ContainSubstring("kubernetes.io/os")))
+	})
+})
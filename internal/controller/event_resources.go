@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups=labels.shahaf.com,resources=namespacelabelevents,verbs=get;list;watch;create;delete
+
+// recordEventResource creates a NamespaceLabelEvent for a reconcile decision when event resources are enabled.
+// Failures are logged only, since the audit trail must never block label management.
+func (r *NamespaceLabelReconciler) recordEventResource(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel,
+	action labelsv1alpha1.NamespaceLabelEventAction, keys []string, msg string) {
+	if !r.EventResources {
+		return
+	}
+	l := log.FromContext(ctx)
+
+	sortedKeys := append([]string(nil), keys...)
+	sort.Strings(sortedKeys)
+
+	ev := &labelsv1alpha1.NamespaceLabelEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: cr.Name + "-",
+			Namespace:    cr.Namespace,
+			Labels:       map[string]string{eventSourceLabel: cr.Name},
+		},
+		Spec: labelsv1alpha1.NamespaceLabelEventSpec{
+			NamespaceLabel: cr.Name,
+			Action:         action,
+			Labels:         sortedKeys,
+			Message:        msg,
+			Timestamp:      metav1.NewTime(r.now()),
+		},
+	}
+
+	// Owner reference lets the events be garbage collected together with the CR
+	if err := controllerutil.SetControllerReference(cr, ev, r.Scheme); err != nil {
+		l.Error(err, "failed to set owner reference on NamespaceLabelEvent")
+		return
+	}
+	if err := r.Create(ctx, ev); err != nil {
+		l.Error(err, "failed to create NamespaceLabelEvent", "action", action)
+	}
+}
+
+// cleanupExpiredEventResources deletes NamespaceLabelEvents older than EventResourceTTL.
+// It returns the delay until the next remaining event expires, or zero if there is nothing to wait for.
+func (r *NamespaceLabelReconciler) cleanupExpiredEventResources(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel) time.Duration {
+	if !r.EventResources || r.EventResourceTTL <= 0 {
+		return 0
+	}
+	l := log.FromContext(ctx)
+
+	var events labelsv1alpha1.NamespaceLabelEventList
+	if err := r.List(ctx, &events, client.InNamespace(cr.Namespace), client.MatchingLabels{eventSourceLabel: cr.Name}); err != nil {
+		l.Error(err, "failed to list NamespaceLabelEvents for cleanup")
+		return 0
+	}
+
+	now := r.now()
+	var next time.Duration
+	for i := range events.Items {
+		ev := &events.Items[i]
+		remaining := ev.Spec.Timestamp.Add(r.EventResourceTTL).Sub(now)
+		if remaining <= 0 {
+			if err := r.Delete(ctx, ev); err != nil && !apierrors.IsNotFound(err) {
+				l.Error(err, "failed to delete expired NamespaceLabelEvent", "name", ev.Name)
+			}
+			continue
+		}
+		if next == 0 || remaining < next {
+			next = remaining
+		}
+	}
+	return next
+}
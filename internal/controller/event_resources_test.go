@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+)
+
+// Tests for functions in event_resources.go
+
+var _ = Describe("NamespaceLabelEvent resources", Label("controller"), func() {
+	var (
+		f          *testFixture
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		fakeClock  *clocktesting.FakeClock
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		f = newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+		fakeClock = clocktesting.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+		reconciler.Clock = fakeClock
+		reconciler.EventResources = true
+		reconciler.EventResourceTTL = time.Hour
+	})
+
+	listEvents := func() []labelsv1alpha1.NamespaceLabelEvent {
+		var events labelsv1alpha1.NamespaceLabelEventList
+		Expect(fakeClient.List(ctx, &events, client.InNamespace("test-ns"))).To(Succeed())
+		return events.Items
+	}
+
+	setup := func(nsLabels map[string]string, spec labelsv1alpha1.NamespaceLabelSpec) {
+		f.createNamespace("test-ns", nsLabels, nil)
+		f.createCR(StandardCRName, "test-ns", nil, []string{FinalizerName}, spec)
+	}
+
+	request := reconcileRequest(StandardCRName, "test-ns")
+
+	It("should record applied and skipped actions", func() {
+		setup(map[string]string{"kubernetes.io/managed-by": "other"}, labelsv1alpha1.NamespaceLabelSpec{
+			Labels: map[string]string{
+				"app":                      "web",
+				"kubernetes.io/managed-by": "me",
+			},
+			ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+		})
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		events := listEvents()
+		Expect(events).To(HaveLen(2))
+		actions := map[labelsv1alpha1.NamespaceLabelEventAction][]string{}
+		for _, ev := range events {
+			Expect(ev.Spec.NamespaceLabel).To(Equal("labels"))
+			Expect(ev.Labels).To(HaveKeyWithValue(eventSourceLabel, "labels"))
+			Expect(ev.OwnerReferences).To(HaveLen(1))
+			actions[ev.Spec.Action] = ev.Spec.Labels
+		}
+		Expect(actions).To(HaveKeyWithValue(labelsv1alpha1.EventActionApplied, []string{"app"}))
+		Expect(actions).To(HaveKeyWithValue(labelsv1alpha1.EventActionSkipped, []string{"kubernetes.io/managed-by"}))
+
+		By("reconciling again without changes")
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listEvents()).To(HaveLen(2))
+	})
+
+	It("should record failed actions in fail mode", func() {
+		setup(map[string]string{"kubernetes.io/managed-by": "other"}, labelsv1alpha1.NamespaceLabelSpec{
+			Labels:                 map[string]string{"kubernetes.io/managed-by": "me"},
+			ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+			ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+		})
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		events := listEvents()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Spec.Action).To(Equal(labelsv1alpha1.EventActionFailed))
+		Expect(events[0].Spec.Message).To(ContainSubstring("kubernetes.io/managed-by"))
+	})
+
+	It("should not record anything when disabled", func() {
+		reconciler.EventResources = false
+		setup(nil, labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"app": "web"}})
+
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(listEvents()).To(BeEmpty())
+	})
+
+	It("should clean up events once their TTL expires", func() {
+		setup(nil, labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"app": "web"}})
+
+		result, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listEvents()).To(HaveLen(1))
+		Expect(result.RequeueAfter).To(Equal(time.Hour))
+
+		By("reconciling before the TTL elapses")
+		fakeClock.Step(30 * time.Minute)
+		result, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listEvents()).To(HaveLen(1))
+		Expect(result.RequeueAfter).To(Equal(30 * time.Minute))
+
+		By("reconciling after the TTL elapses")
+		fakeClock.Step(31 * time.Minute)
+		result, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listEvents()).To(BeEmpty())
+		Expect(result.RequeueAfter).To(BeZero())
+	})
+})
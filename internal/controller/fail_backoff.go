@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// failBackoff counts the consecutive protection-fail conflicts of each NamespaceLabel so a CR that keeps
+// conflicting is retried less and less often instead of logging the same failure at a fixed interval
+type failBackoff struct {
+	mu       sync.Mutex
+	failures map[types.NamespacedName]int
+}
+
+// next records another consecutive failure of key and returns it, starting at 1
+func (b *failBackoff) next(key types.NamespacedName) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures == nil {
+		b.failures = map[types.NamespacedName]int{}
+	}
+	b.failures[key]++
+	return b.failures[key]
+}
+
+// reset forgets the failures of key once its conflict clears or the CR is gone
+func (b *failBackoff) reset(key types.NamespacedName) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.failures, key)
+}
+
+// failRequeueInterval records a protection-fail conflict of key and returns how long to wait before retrying:
+// FailRequeueInterval, doubled for every further consecutive conflict up to MaxFailRequeueInterval
+func (r *NamespaceLabelReconciler) failRequeueInterval(key types.NamespacedName) time.Duration {
+	base := r.FailRequeueInterval
+	if base <= 0 {
+		base = defaultFailRequeueInterval
+	}
+	limit := r.MaxFailRequeueInterval
+	if limit <= 0 {
+		limit = defaultMaxFailRequeueInterval
+	}
+	limit = max(limit, base)
+
+	interval := base
+	for failures := r.failBackoff.next(key); failures > 1 && interval < limit; failures-- {
+		interval *= 2
+	}
+	return min(interval, limit)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// Shared fixture for the controller specs that reconcile against a fake client
+
+// testFixture is a fake cluster and a reconciler working on it
+type testFixture struct {
+	ctx        context.Context
+	scheme     *runtime.Scheme
+	client     client.Client
+	reconciler *NamespaceLabelReconciler
+}
+
+// newTestScheme returns a scheme with every type the reconciler reads or writes
+func newTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	Expect(labelsv1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+	return scheme
+}
+
+// newClientBuilder returns a fake client builder for scheme serving NamespaceLabel status as a subresource,
+// for specs that add interceptors or objects before building
+func newClientBuilder(scheme *runtime.Scheme) *fake.ClientBuilder {
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&labelsv1alpha1.NamespaceLabel{})
+}
+
+// newTestFixture returns a fixture with an empty fake cluster and a reconciler using its default settings
+func newTestFixture() *testFixture {
+	scheme := newTestScheme()
+	fakeClient := newClientBuilder(scheme).Build()
+	return &testFixture{
+		ctx:        context.TODO(),
+		scheme:     scheme,
+		client:     fakeClient,
+		reconciler: &NamespaceLabelReconciler{Client: fakeClient, Scheme: scheme},
+	}
+}
+
+// setClient swaps the fixture's client, and the reconciler's, for c
+func (f *testFixture) setClient(c client.Client) {
+	f.client = c
+	f.reconciler.Client = c
+}
+
+// reconcileRequest returns the request for the NamespaceLabel name in namespace
+func reconcileRequest(name, namespace string) reconcile.Request {
+	return reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+func (f *testFixture) createNamespace(name string, labels map[string]string, annotations map[string]string) *corev1.Namespace {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+	Expect(f.client.Create(f.ctx, ns)).To(Succeed())
+	return ns
+}
+
+func (f *testFixture) createCR(name, namespace string, labels map[string]string, finalizers []string, spec labelsv1alpha1.NamespaceLabelSpec) *labelsv1alpha1.NamespaceLabel {
+	cr := &labelsv1alpha1.NamespaceLabel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  namespace,
+			Labels:     labels,
+			Finalizers: finalizers,
+		},
+		Spec: spec,
+	}
+	Expect(f.client.Create(f.ctx, cr)).To(Succeed())
+	return cr
+}
+
+// reconcile runs one reconcile of the NamespaceLabel name in namespace
+func (f *testFixture) reconcile(name, namespace string) (reconcile.Result, error) {
+	return f.reconciler.Reconcile(f.ctx, reconcileRequest(name, namespace))
+}
+
+// reconcileSpec creates the standard CR with spec in namespace, which must exist, and reconciles it once.
+// It returns the reconcile error and the CR and namespace as they are afterwards.
+func (f *testFixture) reconcileSpec(namespace string, spec labelsv1alpha1.NamespaceLabelSpec) (*labelsv1alpha1.NamespaceLabel, *corev1.Namespace, error) {
+	f.createCR(StandardCRName, namespace, nil, []string{FinalizerName}, spec)
+	_, err := f.reconcile(StandardCRName, namespace)
+	return f.getCR(StandardCRName, namespace), f.getNamespace(namespace), err
+}
+
+func (f *testFixture) getNamespace(name string) *corev1.Namespace {
+	var ns corev1.Namespace
+	Expect(f.client.Get(f.ctx, client.ObjectKey{Name: name}, &ns)).To(Succeed())
+	return &ns
+}
+
+func (f *testFixture) getCR(name, namespace string) *labelsv1alpha1.NamespaceLabel {
+	var cr labelsv1alpha1.NamespaceLabel
+	Expect(f.client.Get(f.ctx, types.NamespacedName{Name: name, Namespace: namespace}, &cr)).To(Succeed())
+	return &cr
+}
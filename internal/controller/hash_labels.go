@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// hashLength keeps hash label values well within the 63 character label value limit
+const hashLength = 32
+
+// configMapHash returns a stable content hash of a ConfigMap's data and binaryData
+func configMapHash(cm *corev1.ConfigMap) string {
+	h := sha256.New()
+
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h, "data\x00%s\x00%s\x00", k, cm.Data[k])
+	}
+
+	binKeys := make([]string, 0, len(cm.BinaryData))
+	for k := range cm.BinaryData {
+		binKeys = append(binKeys, k)
+	}
+	sort.Strings(binKeys)
+	for _, k := range binKeys {
+		fmt.Fprintf(h, "binaryData\x00%s\x00", k)
+		h.Write(cm.BinaryData[k])
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:hashLength]
+}
+
+// resolveHashLabels computes the hash label values for the CR's referenced ConfigMaps.
+// Missing ConfigMaps are reported as warnings and their labels are left out.
+func (r *NamespaceLabelReconciler) resolveHashLabels(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel) (map[string]string, []string, error) {
+	if len(cr.Spec.HashLabels) == 0 {
+		return nil, nil, nil
+	}
+
+	labels := make(map[string]string, len(cr.Spec.HashLabels))
+	var warnings []string
+	for _, hl := range cr.Spec.HashLabels {
+		var cm corev1.ConfigMap
+		if err := r.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: hl.ConfigMapName}, &cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				warnings = append(warnings, fmt.Sprintf("ConfigMap '%s' for hash label '%s' not found", hl.ConfigMapName, hl.Key))
+				continue
+			}
+			return nil, nil, fmt.Errorf("failed to get ConfigMap '%s' for hash label '%s': %w", hl.ConfigMapName, hl.Key, err)
+		}
+		labels[hl.Key] = configMapHash(&cm)
+	}
+	return labels, warnings, nil
+}
+
+// mapConfigMapToRequests enqueues the NamespaceLabels in the ConfigMap's namespace that hash it
+func (r *NamespaceLabelReconciler) mapConfigMapToRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list labelsv1alpha1.NamespaceLabelList
+	if err := r.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list NamespaceLabels for ConfigMap change")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		for _, hl := range item.Spec.HashLabels {
+			if hl.ConfigMapName == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+				break
+			}
+		}
+	}
+	return requests
+}
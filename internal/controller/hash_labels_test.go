@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in hash_labels.go
+
+var _ = Describe("Hash labels", Label("controller"), func() {
+	var (
+		f          *testFixture
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		f = newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+	})
+
+	request := reconcileRequest(StandardCRName, "test-ns")
+
+	setup := func(spec labelsv1alpha1.NamespaceLabelSpec) {
+		f.createNamespace("test-ns", nil, nil)
+		f.createCR(StandardCRName, "test-ns", nil, []string{FinalizerName}, spec)
+	}
+
+	namespaceLabels := func() map[string]string {
+		return f.getNamespace("test-ns").Labels
+	}
+
+	Describe("configMapHash", func() {
+		It("should be stable and sensitive to content", func() {
+			a := &corev1.ConfigMap{Data: map[string]string{"a": "1", "b": "2"}}
+			b := &corev1.ConfigMap{Data: map[string]string{"b": "2", "a": "1"}}
+			Expect(configMapHash(a)).To(Equal(configMapHash(b)))
+			Expect(configMapHash(a)).To(HaveLen(hashLength))
+
+			b.Data["b"] = "3"
+			Expect(configMapHash(a)).NotTo(Equal(configMapHash(b)))
+
+			c := &corev1.ConfigMap{BinaryData: map[string][]byte{"a": []byte("1")}}
+			d := &corev1.ConfigMap{Data: map[string]string{"a": "1"}}
+			Expect(configMapHash(c)).NotTo(Equal(configMapHash(d)))
+		})
+	})
+
+	It("should label the namespace with the ConfigMap hash and follow updates", func() {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "test-ns"},
+			Data:       map[string]string{"mode": "blue"},
+		}
+		Expect(fakeClient.Create(ctx, cm)).To(Succeed())
+		setup(labelsv1alpha1.NamespaceLabelSpec{
+			Labels:     map[string]string{"app": "web", "config-hash": "overridden"},
+			HashLabels: []labelsv1alpha1.HashLabelSpec{{Key: "config-hash", ConfigMapName: "settings"}},
+		})
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		first := configMapHash(cm)
+		Expect(namespaceLabels()).To(HaveKeyWithValue("config-hash", first))
+		Expect(namespaceLabels()).To(HaveKeyWithValue("app", "web"))
+
+		By("changing the ConfigMap content")
+		cm.Data["mode"] = "green"
+		Expect(fakeClient.Update(ctx, cm)).To(Succeed())
+
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaceLabels()).To(HaveKeyWithValue("config-hash", configMapHash(cm)))
+		Expect(namespaceLabels()["config-hash"]).NotTo(Equal(first))
+	})
+
+	It("should warn and omit the label when the ConfigMap is missing", func() {
+		setup(labelsv1alpha1.NamespaceLabelSpec{
+			HashLabels: []labelsv1alpha1.HashLabelSpec{{Key: "config-hash", ConfigMapName: "missing"}},
+		})
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaceLabels()).NotTo(HaveKey("config-hash"))
+
+		var cr labelsv1alpha1.NamespaceLabel
+		Expect(fakeClient.Get(ctx, request.NamespacedName, &cr)).To(Succeed())
+		cond := meta.FindStatusCondition(cr.Status.Conditions, ConditionHashLabelsResolved)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("ConfigMap 'missing'"))
+
+		By("creating the ConfigMap")
+		Expect(fakeClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "test-ns"},
+		})).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaceLabels()).To(HaveKey("config-hash"))
+		Expect(fakeClient.Get(ctx, request.NamespacedName, &cr)).To(Succeed())
+		Expect(meta.FindStatusCondition(cr.Status.Conditions, ConditionHashLabelsResolved)).To(BeNil())
+	})
+
+	Describe("mapConfigMapToRequests", func() {
+		It("should only enqueue NamespaceLabels referencing the ConfigMap", func() {
+			setup(labelsv1alpha1.NamespaceLabelSpec{
+				HashLabels: []labelsv1alpha1.HashLabelSpec{{Key: "config-hash", ConfigMapName: "settings"}},
+			})
+
+			requests := reconciler.mapConfigMapToRequests(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "test-ns"},
+			})
+			Expect(requests).To(ConsistOf(request))
+
+			Expect(reconciler.mapConfigMapToRequests(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "test-ns"},
+			})).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// labelSourceNamespacePrefix is followed by the source namespace name for inherited labels
+const labelSourceNamespacePrefix = "namespace/"
+
+// resolveInheritedLabels copies the spec.inheritKeys labels from the spec.inheritFrom namespace. Keys the source
+// does not carry are skipped. A source outside InheritSourceNamespaces or missing yields nothing, and keys under a
+// reserved prefix are dropped unless the CR's namespace opted in; each is reported with the
+// InheritedLabelsResolved reason and messages.
+func (r *NamespaceLabelReconciler) resolveInheritedLabels(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel) (map[string]string, string, []string, error) {
+	if cr.Spec.InheritFrom == "" || len(cr.Spec.InheritKeys) == 0 {
+		return nil, "", nil, nil
+	}
+	if !slices.Contains(r.InheritSourceNamespaces, cr.Spec.InheritFrom) {
+		return nil, "SourceNamespaceNotAllowed",
+			[]string{fmt.Sprintf("Inheriting labels from namespace '%s' is not allowed on this operator", cr.Spec.InheritFrom)}, nil
+	}
+
+	var source corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: cr.Spec.InheritFrom}, &source); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, "SourceNamespaceNotFound",
+				[]string{fmt.Sprintf("Source namespace '%s' for inherited labels not found", cr.Spec.InheritFrom)}, nil
+		}
+		return nil, "", nil, fmt.Errorf("failed to get source namespace '%s' for inherited labels: %w", cr.Spec.InheritFrom, err)
+	}
+
+	inherited := make(map[string]string, len(cr.Spec.InheritKeys))
+	var reserved []string
+	for _, key := range cr.Spec.InheritKeys {
+		value, ok := source.Labels[key]
+		if !ok {
+			continue
+		}
+		if r.SpecValidator.IsReservedLabelKey(key) {
+			reserved = append(reserved, key)
+			continue
+		}
+		inherited[key] = value
+	}
+	if len(reserved) == 0 {
+		return inherited, "", nil, nil
+	}
+
+	// Reserved keys need the same opt-in the webhook asks of spec labels
+	allowed, err := r.allowsReservedLabels(ctx, cr.Namespace)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if allowed {
+		for _, key := range reserved {
+			inherited[key] = source.Labels[key]
+		}
+		return inherited, "", nil, nil
+	}
+	sort.Strings(reserved)
+	warnings := make([]string, 0, len(reserved))
+	for _, key := range reserved {
+		warnings = append(warnings, fmt.Sprintf("Inherited label '%s' uses a reserved prefix; annotate namespace '%s' with %s=true to allow it",
+			key, cr.Namespace, webhookv1alpha1.AllowReservedLabelsAnnoKey))
+	}
+	return inherited, "ReservedLabelKeys", warnings, nil
+}
+
+// allowsReservedLabels reports whether the namespace opted in to reserved label prefixes with AllowReservedLabelsAnnoKey
+func (r *NamespaceLabelReconciler) allowsReservedLabels(ctx context.Context, namespace string) (bool, error) {
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check namespace '%s' for reserved label opt-in: %w", namespace, err)
+	}
+	return ns.Annotations[webhookv1alpha1.AllowReservedLabelsAnnoKey] == "true", nil
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"strings"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+)
+
+// prefixKeys returns labels with prefix prepended to every key
+func prefixKeys(labels map[string]string, prefix string) map[string]string {
+	if prefix == "" {
+		return labels
+	}
+	prefixed := make(map[string]string, len(labels))
+	for k, v := range labels {
+		prefixed[prefix+k] = v
+	}
+	return prefixed
+}
+
+// prefixList returns keys with prefix prepended to each
+func prefixList(keys []string, prefix string) []string {
+	if prefix == "" {
+		return keys
+	}
+	prefixed := make([]string, len(keys))
+	for i, k := range keys {
+		prefixed[i] = prefix + k
+	}
+	return prefixed
+}
+
+// reportedKeys returns the keys as they should appear in status. With spec.reportUnprefixedKeys
+// the configured key prefix is stripped so status lines up with the spec.
+func reportedKeys(cr *labelsv1alpha1.NamespaceLabel, keys []string) []string {
+	if !cr.Spec.ReportUnprefixedKeys || cr.Spec.KeyPrefix == "" || keys == nil {
+		return keys
+	}
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = reportedKey(cr, k)
+	}
+	return out
+}
+
+// reportedKey returns a single key as it should appear in status
+func reportedKey(cr *labelsv1alpha1.NamespaceLabel, key string) string {
+	if !cr.Spec.ReportUnprefixedKeys {
+		return key
+	}
+	return strings.TrimPrefix(key, cr.Spec.KeyPrefix)
+}
+
+// reportedResult returns a copy of the protection result with its keys as they should appear in status
+func reportedResult(cr *labelsv1alpha1.NamespaceLabel, result ProtectionResult) ProtectionResult {
+	if !cr.Spec.ReportUnprefixedKeys || cr.Spec.KeyPrefix == "" {
+		return result
+	}
+	allowed := make(map[string]string, len(result.AllowedLabels))
+	for k, v := range result.AllowedLabels {
+		allowed[reportedKey(cr, k)] = v
+	}
+	result.AllowedLabels = allowed
+	result.ProtectedSkipped = reportedKeys(cr, result.ProtectedSkipped)
+	result.ConflictingKeys = reportedKeys(cr, result.ConflictingKeys)
+	reasons := make(map[string]string, len(result.Reasons))
+	for k, pattern := range result.Reasons {
+		reasons[reportedKey(cr, k)] = pattern
+	}
+	result.Reasons = reasons
+	return result
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in key_prefix.go
+
+var _ = Describe("Key prefix", Label("controller"), func() {
+	var f *testFixture
+
+	BeforeEach(func() {
+		f = newTestFixture()
+	})
+
+	reconcileWith := func(spec labelsv1alpha1.NamespaceLabelSpec) (*corev1.Namespace, *labelsv1alpha1.NamespaceLabel) {
+		f.createNamespace("test-ns", map[string]string{"team.example.com/owner": "platform"}, nil)
+		cr, ns, err := f.reconcileSpec("test-ns", spec)
+		Expect(err).NotTo(HaveOccurred())
+		return ns, cr
+	}
+
+	It("should apply prefixed keys and report unprefixed keys in status", func() {
+		ns, cr := reconcileWith(labelsv1alpha1.NamespaceLabelSpec{
+			Labels:                 map[string]string{"env": "prod", "owner": "me"},
+			KeyPrefix:              "team.example.com/",
+			ReportUnprefixedKeys:   true,
+			ProtectedLabelPatterns: []string{"team.example.com/owner"},
+		})
+
+		Expect(ns.Labels).To(Equal(map[string]string{"team.example.com/env": "prod", "team.example.com/owner": "platform"}))
+		Expect(cr.Status.LabelsApplied).To(ConsistOf("env"))
+		Expect(cr.Status.AllowedLabels).To(Equal(map[string]string{"env": "prod"}))
+		Expect(cr.Status.ProtectedLabelsSkipped).To(ConsistOf("owner"))
+		Expect(cr.Status.ProtectedLabelReasons).To(Equal(map[string]string{"owner": "team.example.com/owner"}))
+	})
+
+	It("should report the prefixed keys by default", func() {
+		ns, cr := reconcileWith(labelsv1alpha1.NamespaceLabelSpec{
+			Labels:    map[string]string{"env": "prod"},
+			KeyPrefix: "team.example.com/",
+		})
+
+		Expect(ns.Labels).To(HaveKeyWithValue("team.example.com/env", "prod"))
+		Expect(cr.Status.LabelsApplied).To(ConsistOf("team.example.com/env"))
+		Expect(cr.Status.AllowedLabels).To(HaveKey("team.example.com/env"))
+	})
+})
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"sort"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	"github.com/sbahar619/namespace-label-operator/internal/protection"
+)
+
+// labelChangesStatus splits the changes a reconcile made into the added, updated and removed lists
+// reported in status, with keys as they should appear there. No changes report nothing.
+func labelChangesStatus(cr *labelsv1alpha1.NamespaceLabel, changes []labelsv1alpha1.LabelChange) *labelsv1alpha1.LabelChanges {
+	if len(changes) == 0 {
+		return nil
+	}
+	out := &labelsv1alpha1.LabelChanges{}
+	for _, change := range changes {
+		change.Key = reportedKey(cr, change.Key)
+		switch change.Action {
+		case labelsv1alpha1.LabelChangeAdd:
+			out.Added = append(out.Added, change)
+		case labelsv1alpha1.LabelChangeUpdate:
+			out.Updated = append(out.Updated, change)
+		case labelsv1alpha1.LabelChangeRemove:
+			out.Removed = append(out.Removed, change)
+		}
+	}
+	return out
+}
+
+// sortLabelChanges orders changes by key so status and logs are stable across reconciles
+func sortLabelChanges(changes []labelsv1alpha1.LabelChange) {
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+}
+
+// removedKeys returns, in the order of changes, the keys of every removal
+func removedKeys(changes []labelsv1alpha1.LabelChange) []string {
+	var keys []string
+	for _, change := range changes {
+		if change.Action == labelsv1alpha1.LabelChangeRemove {
+			keys = append(keys, change.Key)
+		}
+	}
+	return keys
+}
+
+// removedKeysMatching returns, in the order of changes, the keys of removals matching any of the matchers
+func removedKeysMatching(changes []labelsv1alpha1.LabelChange, matchers []protection.Matcher) []string {
+	var keys []string
+	for _, change := range changes {
+		if change.Action == labelsv1alpha1.LabelChangeRemove && protection.MatchesAny(change.Key, matchers) {
+			keys = append(keys, change.Key)
+		}
+	}
+	return keys
+}
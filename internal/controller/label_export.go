@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
+
+// exportDataKey is the ConfigMap data key holding the exported labels as a JSON object.
+// Label keys cannot be used as data keys directly, since a prefixed key contains a '/'.
+const exportDataKey = "labels.json"
+
+// exportLabels writes labels to the ConfigMap named by spec.exportConfigMap, creating it owned by cr so it is
+// garbage collected with the CR. A ConfigMap of that name not created by cr is left alone and reported as an error.
+// ConfigMaps exported under an earlier name are deleted.
+func (r *NamespaceLabelReconciler) exportLabels(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel, labels map[string]string) error {
+	if err := r.deleteExportedConfigMaps(ctx, cr, cr.Spec.ExportConfigMap); err != nil {
+		return err
+	}
+	if cr.Spec.ExportConfigMap == "" {
+		return nil
+	}
+
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: cr.Namespace, Name: cr.Spec.ExportConfigMap}}
+	// CreateOrUpdate writes through the embedded client, so its write is charged here
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		if cm.ResourceVersion != "" && !metav1.IsControlledBy(cm, cr) {
+			return fmt.Errorf("ConfigMap '%s/%s' exists and is not owned by this NamespaceLabel", cm.Namespace, cm.Name)
+		}
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels[eventSourceLabel] = cr.Name
+		cm.Data = map[string]string{exportDataKey: string(data)}
+		return controllerutil.SetControllerReference(cr, cm, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export labels to ConfigMap '%s/%s': %w", cr.Namespace, cr.Spec.ExportConfigMap, err)
+	}
+	if op != controllerutil.OperationResultNone {
+		r.WriteBudget.charge(r.now())
+	}
+	return nil
+}
+
+// deleteExportedConfigMaps deletes the ConfigMaps cr exported, except the one named keep
+func (r *NamespaceLabelReconciler) deleteExportedConfigMaps(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel, keep string) error {
+	var list corev1.ConfigMapList
+	if err := r.List(ctx, &list, client.InNamespace(cr.Namespace), client.MatchingLabels{eventSourceLabel: cr.Name}); err != nil {
+		return fmt.Errorf("failed to list exported ConfigMaps: %w", err)
+	}
+	for i := range list.Items {
+		cm := &list.Items[i]
+		if cm.Name == keep || !metav1.IsControlledBy(cm, cr) {
+			continue
+		}
+		if err := r.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete exported ConfigMap '%s/%s': %w", cm.Namespace, cm.Name, err)
+		}
+	}
+	return nil
+}
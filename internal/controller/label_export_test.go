@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in label_export.go
+
+var _ = Describe("Label export", Label("controller"), func() {
+	var (
+		f          *testFixture
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		ctx        context.Context
+		req        reconcile.Request
+	)
+
+	BeforeEach(func() {
+		f = newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+		req = reconcileRequest(StandardCRName, "test-ns")
+
+		f.createNamespace("test-ns", nil, nil)
+		f.createCR(StandardCRName, "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+			Labels:          map[string]string{"env": "dev", "example.com/team": "payments"},
+			ExportConfigMap: "namespace-labels",
+		})
+	})
+
+	getConfigMap := func(name string) (*corev1.ConfigMap, error) {
+		var cm corev1.ConfigMap
+		err := fakeClient.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: name}, &cm)
+		return &cm, err
+	}
+	getCR := func() *labelsv1alpha1.NamespaceLabel {
+		return f.getCR(StandardCRName, "test-ns")
+	}
+
+	It("should mirror the applied labels into a ConfigMap owned by the CR", func() {
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		cm, err := getConfigMap("namespace-labels")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cm.Data).To(HaveKeyWithValue(exportDataKey, `{"env":"dev","example.com/team":"payments"}`))
+		Expect(cm.Labels).To(HaveKeyWithValue(eventSourceLabel, StandardCRName))
+		Expect(metav1.IsControlledBy(cm, getCR())).To(BeTrue())
+
+		By("updating the ConfigMap when the labels change")
+		cr := getCR()
+		cr.Spec.Labels = map[string]string{"env": "prod"}
+		Expect(fakeClient.Update(ctx, cr)).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		cm, err = getConfigMap("namespace-labels")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cm.Data).To(HaveKeyWithValue(exportDataKey, `{"env":"prod"}`))
+	})
+
+	It("should delete the ConfigMap exported under an earlier name", func() {
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		cr := getCR()
+		cr.Spec.ExportConfigMap = "renamed-labels"
+		Expect(fakeClient.Update(ctx, cr)).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = getConfigMap("namespace-labels")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		_, err = getConfigMap("renamed-labels")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not take over a ConfigMap it did not create", func() {
+		Expect(fakeClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "namespace-labels", Namespace: "test-ns"},
+			Data:       map[string]string{"owner": "someone-else"},
+		})).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		cm, err := getConfigMap("namespace-labels")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cm.Data).To(Equal(map[string]string{"owner": "someone-else"}))
+		cond := meta.FindStatusCondition(getCR().Status.Conditions, ConditionLabelsExported)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("not owned by this NamespaceLabel"))
+	})
+
+	It("should delete the exported ConfigMap when the CR is deleted", func() {
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.Delete(ctx, getCR())).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = getConfigMap("namespace-labels")
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"fmt"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checkLabelLimit flags namespaces whose total label count is within the configured margin of MaxLabels.
+// The count covers labels from every source, not just this CR. The Warning event is only emitted
+// when the condition turns on, so repeated reconciles near the limit don't flood the event stream.
+func (r *NamespaceLabelReconciler) checkLabelLimit(cr *labelsv1alpha1.NamespaceLabel, ns *corev1.Namespace) {
+	if r.MaxLabels <= 0 {
+		meta.RemoveStatusCondition(&cr.Status.Conditions, ConditionNearLabelLimit)
+		return
+	}
+
+	count := len(ns.Labels)
+	if count < r.MaxLabels-r.LabelLimitMargin {
+		meta.RemoveStatusCondition(&cr.Status.Conditions, ConditionNearLabelLimit)
+		return
+	}
+
+	msg := fmt.Sprintf("Namespace '%s' has %d labels, within %d of the limit of %d", ns.Name, count, r.LabelLimitMargin, r.MaxLabels)
+	if !meta.IsStatusConditionTrue(cr.Status.Conditions, ConditionNearLabelLimit) {
+		r.recordEvent(cr, corev1.EventTypeWarning, ConditionNearLabelLimit, msg)
+	}
+	setCondition(cr, ConditionNearLabelLimit, metav1.ConditionTrue, ConditionNearLabelLimit, msg)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in label_limit.go
+
+var _ = Describe("Label limit warning", Label("controller"), func() {
+	var (
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		recorder   *record.FakeRecorder
+		ctx        context.Context
+	)
+
+	request := reconcileRequest("labels", "test-ns")
+
+	BeforeEach(func() {
+		f := newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+		recorder = record.NewFakeRecorder(10)
+		reconciler.Recorder = recorder
+		reconciler.MaxLabels = 6
+		reconciler.LabelLimitMargin = 2
+
+		// Two labels come from another source, the CR manages the rest
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-ns",
+			Labels: map[string]string{"external-a": "1", "external-b": "2"},
+		}})).To(Succeed())
+	})
+
+	reconcileWith := func(labels map[string]string) *labelsv1alpha1.NamespaceLabel {
+		var cr labelsv1alpha1.NamespaceLabel
+		err := fakeClient.Get(ctx, request.NamespacedName, &cr)
+		if err != nil {
+			cr = labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns", Finalizers: []string{FinalizerName}},
+				Spec:       labelsv1alpha1.NamespaceLabelSpec{Labels: labels},
+			}
+			Expect(fakeClient.Create(ctx, &cr)).To(Succeed())
+		} else {
+			cr.Spec.Labels = labels
+			Expect(fakeClient.Update(ctx, &cr)).To(Succeed())
+		}
+
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeClient.Get(ctx, request.NamespacedName, &cr)).To(Succeed())
+		return &cr
+	}
+
+	// nearLimitEvents drains the recorder and returns only the label limit warnings
+	nearLimitEvents := func() []string {
+		var events []string
+		for {
+			select {
+			case e := <-recorder.Events:
+				if strings.Contains(e, ConditionNearLabelLimit) {
+					events = append(events, e)
+				}
+			default:
+				return events
+			}
+		}
+	}
+
+	It("should stay quiet just below the margin", func() {
+		cr := reconcileWith(map[string]string{"a": "1"})
+		Expect(meta.FindStatusCondition(cr.Status.Conditions, ConditionNearLabelLimit)).To(BeNil())
+		Expect(nearLimitEvents()).To(BeEmpty())
+	})
+
+	It("should warn once when entering the margin and clear when leaving it", func() {
+		cr := reconcileWith(map[string]string{"a": "1", "b": "2"})
+		Expect(meta.IsStatusConditionTrue(cr.Status.Conditions, ConditionNearLabelLimit)).To(BeTrue())
+		events := nearLimitEvents()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0]).To(And(HavePrefix("Warning"), ContainSubstring("has 4 labels")))
+
+		By("reconciling again while still near the limit")
+		reconcileWith(map[string]string{"a": "1", "b": "2", "c": "3"})
+		Expect(nearLimitEvents()).To(BeEmpty())
+
+		By("dropping labels back below the margin")
+		cr = reconcileWith(map[string]string{"a": "1"})
+		Expect(meta.FindStatusCondition(cr.Status.Conditions, ConditionNearLabelLimit)).To(BeNil())
+	})
+
+	It("should be disabled without a limit", func() {
+		reconciler.MaxLabels = 0
+		cr := reconcileWith(map[string]string{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5"})
+		Expect(meta.FindStatusCondition(cr.Status.Conditions, ConditionNearLabelLimit)).To(BeNil())
+		Expect(nearLimitEvents()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
+)
+
+const (
+	// labelSourceSpec marks a label taken from spec.labels, including rendered templates
+	labelSourceSpec = "spec"
+	// labelSourceConfigMapPrefix is followed by the ConfigMap name for hash labels
+	labelSourceConfigMapPrefix = "configmap/"
+	// labelSourceTemplate marks a label rendered from spec.labelsTemplate
+	labelSourceTemplate = "template"
+)
+
+// labelSources maps every desired key, with the key prefix applied, to the source its value came from.
+// Spec labels override labelsTemplate ones and hash labels override both, matching mergeLabels; unresolved
+// hash labels are left out. Inherited labels keep their unprefixed key and only count where no other label claims it.
+func labelSources(spec labelsv1alpha1.NamespaceLabelSpec, templated, hashLabels, inherited map[string]string) map[string]string {
+	specLabels := webhookv1alpha1.SpecLabels(spec)
+	sources := make(map[string]string, len(templated)+len(specLabels)+len(hashLabels))
+	for k := range templated {
+		sources[k] = labelSourceTemplate
+	}
+	for k := range specLabels {
+		sources[k] = labelSourceSpec
+	}
+	for _, hl := range spec.HashLabels {
+		if _, resolved := hashLabels[hl.Key]; resolved {
+			sources[hl.Key] = labelSourceConfigMapPrefix + hl.ConfigMapName
+		}
+	}
+	sources = prefixKeys(sources, spec.KeyPrefix)
+	for k := range inherited {
+		if _, ok := sources[k]; !ok {
+			sources[k] = labelSourceNamespacePrefix + spec.InheritFrom
+		}
+	}
+	return sources
+}
+
+// reportedSources returns the sources of the allowed labels, keyed as they should appear in status
+func reportedSources(cr *labelsv1alpha1.NamespaceLabel, sources, allowed map[string]string) map[string]string {
+	if len(allowed) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(allowed))
+	for k := range allowed {
+		if source, ok := sources[k]; ok {
+			out[reportedKey(cr, k)] = source
+		}
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in label_sources.go
+
+var _ = Describe("Label sources", Label("controller"), func() {
+	It("should attribute hash labels to their ConfigMap over spec and template labels", func() {
+		spec := labelsv1alpha1.NamespaceLabelSpec{
+			Labels:    map[string]string{"app": "web", "config-hash": "overridden"},
+			KeyPrefix: "team.example.com/",
+			HashLabels: []labelsv1alpha1.HashLabelSpec{
+				{Key: "config-hash", ConfigMapName: "settings"},
+				{Key: "missing-hash", ConfigMapName: "missing"},
+			},
+		}
+		templated := map[string]string{"app": "api", "zone": "a"}
+		Expect(labelSources(spec, templated, map[string]string{"config-hash": "abc"}, nil)).To(Equal(map[string]string{
+			"team.example.com/app":         labelSourceSpec,
+			"team.example.com/config-hash": "configmap/settings",
+			"team.example.com/zone":        labelSourceTemplate,
+		}))
+	})
+
+	It("should report the source of every applied label in status", func() {
+		f := newTestFixture()
+		f.createNamespace("test-ns", map[string]string{"kubernetes.io/owner": "platform"}, nil)
+		Expect(f.client.Create(f.ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "test-ns"},
+			Data:       map[string]string{"mode": "blue"},
+		})).To(Succeed())
+		cr, _, err := f.reconcileSpec("test-ns", labelsv1alpha1.NamespaceLabelSpec{
+			Labels:                 map[string]string{"app": "web", "kubernetes.io/owner": "me"},
+			HashLabels:             []labelsv1alpha1.HashLabelSpec{{Key: "config-hash", ConfigMapName: "settings"}},
+			ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cr.Status.LabelSources).To(Equal(map[string]string{
+			"app":         labelSourceSpec,
+			"config-hash": "configmap/settings",
+		}))
+	})
+})
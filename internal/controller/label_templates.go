@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// labelTemplateData is what label value templates are evaluated against, e.g. {{ .Namespace.Labels.team }}
+type labelTemplateData struct {
+	Namespace *corev1.Namespace
+}
+
+// renderLabelTemplates evaluates templated label values against the target namespace.
+// Labels whose template fails or renders an invalid label value are left out and reported as warnings.
+func renderLabelTemplates(labels map[string]string, ns *corev1.Namespace) (map[string]string, []string) {
+	rendered := make(map[string]string, len(labels))
+	var warnings []string
+	data := labelTemplateData{Namespace: ns}
+	for key, value := range labels {
+		if !webhookv1alpha1.IsLabelTemplate(value) {
+			rendered[key] = value
+			continue
+		}
+
+		tmpl, err := webhookv1alpha1.ParseLabelTemplate(key, value)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Template for label '%s' is invalid: %v", key, err))
+			continue
+		}
+		var out strings.Builder
+		if err := tmpl.Execute(&out, data); err != nil {
+			warnings = append(warnings, fmt.Sprintf("Template for label '%s' failed: %v", key, err))
+			continue
+		}
+		if errs := validation.IsValidLabelValue(out.String()); len(errs) > 0 {
+			warnings = append(warnings, fmt.Sprintf("Template for label '%s' rendered invalid value '%s': %s",
+				key, out.String(), strings.Join(errs, "; ")))
+			continue
+		}
+		rendered[key] = out.String()
+	}
+	return rendered, warnings
+}
+
+// labelsTemplateData is what spec.labelsTemplate is evaluated against, e.g. {{ .NamespaceLabel.Name }}
+type labelsTemplateData struct {
+	Namespace      *corev1.Namespace
+	NamespaceLabel *labelsv1alpha1.NamespaceLabel
+}
+
+// renderLabelsTemplate evaluates spec.labelsTemplate against the target namespace and CR and parses the
+// output as a YAML map of labels; scalar values such as 3 or true become strings. Empty output yields no
+// labels. A template that fails to parse, execute or render a map yields no labels at all, while single
+// entries with an invalid key or value are left out. The rendered labels then get the validator's reserved
+// prefix, denied value and label count checks, which admission cannot run on them, and yield no labels if
+// they fail. Every problem is reported as a warning.
+func renderLabelsTemplate(cr *labelsv1alpha1.NamespaceLabel, ns *corev1.Namespace,
+	validator *webhookv1alpha1.NamespaceLabelCustomValidator) (map[string]string, []string) {
+	if cr.Spec.LabelsTemplate == "" {
+		return nil, nil
+	}
+	tmpl, err := webhookv1alpha1.ParseLabelTemplate("labelsTemplate", cr.Spec.LabelsTemplate)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("labelsTemplate is invalid: %v", err)}
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, labelsTemplateData{Namespace: ns, NamespaceLabel: cr}); err != nil {
+		return nil, []string{fmt.Sprintf("labelsTemplate failed: %v", err)}
+	}
+	if strings.TrimSpace(out.String()) == "" {
+		return nil, nil
+	}
+	var parsed map[string]string
+	if err := yaml.Unmarshal([]byte(out.String()), &parsed); err != nil {
+		return nil, []string{fmt.Sprintf("labelsTemplate did not render a map of string labels: %v", err)}
+	}
+
+	keys := make([]string, 0, len(parsed))
+	for key := range parsed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rendered := make(map[string]string, len(parsed))
+	var warnings []string
+	for _, key := range keys {
+		value := parsed[key]
+		if errs := validation.IsQualifiedName(cr.Spec.KeyPrefix + key); len(errs) > 0 {
+			warnings = append(warnings, fmt.Sprintf("labelsTemplate rendered invalid key '%s': %s", key, strings.Join(errs, "; ")))
+			continue
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			warnings = append(warnings, fmt.Sprintf("labelsTemplate rendered invalid value '%s' for label '%s': %s",
+				value, key, strings.Join(errs, "; ")))
+			continue
+		}
+		rendered[key] = value
+	}
+	if err := validator.ValidateRenderedLabels(cr.Spec, rendered, ns); err != nil {
+		return nil, append(warnings, fmt.Sprintf("labelsTemplate rendered labels that fail validation: %v", err))
+	}
+	return rendered, warnings
+}
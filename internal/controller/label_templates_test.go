@@ -0,0 +1,238 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in label_templates.go
+
+var _ = Describe("Label templates", Label("controller"), func() {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-ns",
+			Labels:            map[string]string{"team": "payments"},
+			CreationTimestamp: metav1.NewTime(time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)),
+		},
+	}
+
+	Describe("renderLabelTemplates", func() {
+		It("should render templates against the namespace and keep plain values", func() {
+			rendered, warnings := renderLabelTemplates(map[string]string{
+				"owner":   "{{ .Namespace.Labels.team }}",
+				"created": `{{ .Namespace.CreationTimestamp.Format "2006-01-02" }}`,
+				"name":    "ns-{{ .Namespace.Name }}",
+				"env":     "prod",
+			}, ns)
+
+			Expect(warnings).To(BeEmpty())
+			Expect(rendered).To(Equal(map[string]string{
+				"owner":   "payments",
+				"created": "2025-03-04",
+				"name":    "ns-test-ns",
+				"env":     "prod",
+			}))
+		})
+
+		It("should drop labels whose template fails or renders an invalid value", func() {
+			rendered, warnings := renderLabelTemplates(map[string]string{
+				"missing": "{{ .Namespace.Labels.absent }}",
+				"created": "{{ .Namespace.CreationTimestamp }}",
+				"broken":  "{{ .Namespace.Name",
+				"env":     "prod",
+			}, ns)
+
+			Expect(rendered).To(Equal(map[string]string{"env": "prod"}))
+			Expect(warnings).To(ConsistOf(
+				ContainSubstring("label 'missing' failed"),
+				ContainSubstring("label 'created' rendered invalid value"),
+				ContainSubstring("label 'broken' is invalid"),
+			))
+		})
+	})
+
+	Describe("renderLabelsTemplate", func() {
+		DescribeTable("should render a YAML map of labels against the namespace and CR",
+			func(template string, expected map[string]string, warnings []string) {
+				cr := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "test-ns"},
+					Spec:       labelsv1alpha1.NamespaceLabelSpec{LabelsTemplate: template},
+				}
+				rendered, got := renderLabelsTemplate(cr, ns, nil)
+				Expect(rendered).To(Equal(expected))
+				Expect(got).To(HaveLen(len(warnings)))
+				for i, substring := range warnings {
+					Expect(got[i]).To(ContainSubstring(substring))
+				}
+			},
+			Entry("unset", "", nil, nil),
+			Entry("multiple labels", "{{ range $k, $v := .Namespace.Labels }}mirror-{{ $k }}: {{ $v }}\n{{ end }}"+
+				"managed-by: {{ .NamespaceLabel.Name }}\nnamespace: {{ .Namespace.Name }}",
+				map[string]string{"mirror-team": "payments", "managed-by": "labels", "namespace": "test-ns"}, nil),
+			Entry("empty output", `{{ if eq .Namespace.Name "other" }}a: b{{ end }}`, nil, nil),
+			Entry("whitespace output", "  \n", nil, nil),
+			Entry("invalid key and value dropped", "ok: yes-please\n\"bad key\": x\nlong: "+strings.Repeat("v", 64),
+				map[string]string{"ok": "yes-please"},
+				[]string{"rendered invalid key 'bad key'", "rendered invalid value"}),
+			Entry("unparsable template", "{{ .Namespace.Name", nil, []string{"labelsTemplate is invalid"}),
+			Entry("failing template", "team: {{ .Namespace.Labels.absent }}", nil, []string{"labelsTemplate failed"}),
+			Entry("not a map", "- a\n- b", nil, []string{"did not render a map of string labels"}),
+			Entry("scalar values", "replicas: 3\nenabled: true", map[string]string{"replicas": "3", "enabled": "true"}, nil),
+			Entry("nested value", "owner:\n  team: payments", nil, []string{"did not render a map of string labels"}),
+		)
+
+		DescribeTable("should hold back rendered labels failing the spec validator's checks",
+			func(template string, optIn bool, expected map[string]string, warning string) {
+				validator := &webhookv1alpha1.NamespaceLabelCustomValidator{
+					ReservedLabelPrefixes: webhookv1alpha1.DefaultReservedLabelPrefixes,
+					DeniedValueSubstrings: []string{"password"},
+					MaxLabels:             2,
+				}
+				cr := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "test-ns"},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						Labels:         map[string]string{"env": "prod"},
+						LabelsTemplate: template,
+					},
+				}
+				target := ns.DeepCopy()
+				if optIn {
+					target.Annotations = map[string]string{webhookv1alpha1.AllowReservedLabelsAnnoKey: "true"}
+				}
+				rendered, got := renderLabelsTemplate(cr, target, validator)
+				Expect(rendered).To(Equal(expected))
+				if warning == "" {
+					Expect(got).To(BeEmpty())
+				} else {
+					Expect(got).To(ConsistOf(ContainSubstring(warning)))
+				}
+			},
+			Entry("passing labels", "owner: payments", false, map[string]string{"owner": "payments"}, ""),
+			Entry("reserved key", "app.kubernetes.io/owner: payments", false, nil,
+				"label keys app.kubernetes.io/owner use a reserved prefix"),
+			Entry("reserved key in an opted-in namespace", "app.kubernetes.io/owner: payments", true,
+				map[string]string{"app.kubernetes.io/owner": "payments"}, ""),
+			Entry("denied value", "owner: my-password", false, nil, "contains the denied substring 'password'"),
+			Entry("over the label limit with the spec labels", "owner: payments\nteam: checkout", false, nil,
+				"too many labels: 3 exceeds limit 2"),
+			Entry("a key the spec also sets counting once", "env: dev\nowner: payments", false,
+				map[string]string{"env": "dev", "owner": "payments"}, ""),
+		)
+	})
+
+	It("should apply labelsTemplate labels under spec labels and report failures in a condition", func() {
+		f := newTestFixture()
+		reconciler, fakeClient, ctx := f.reconciler, f.client, f.ctx
+
+		Expect(fakeClient.Create(ctx, ns.DeepCopy())).To(Succeed())
+		Expect(fakeClient.Create(ctx, &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "test-ns", Finalizers: []string{FinalizerName}},
+			Spec: labelsv1alpha1.NamespaceLabelSpec{
+				Labels:         map[string]string{"owner": "platform"},
+				LabelsTemplate: "owner: {{ .Namespace.Labels.team }}\ncost-center: cc-{{ .Namespace.Labels.team }}",
+			},
+		})).To(Succeed())
+
+		request := reconcileRequest(StandardCRName, "test-ns")
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		var updatedNS corev1.Namespace
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &updatedNS)).To(Succeed())
+		Expect(updatedNS.Labels).To(HaveKeyWithValue("owner", "platform"))
+		Expect(updatedNS.Labels).To(HaveKeyWithValue("cost-center", "cc-payments"))
+
+		var cr labelsv1alpha1.NamespaceLabel
+		Expect(fakeClient.Get(ctx, request.NamespacedName, &cr)).To(Succeed())
+		Expect(cr.Status.LabelSources).To(HaveKeyWithValue("cost-center", labelSourceTemplate))
+		Expect(cr.Status.LabelSources).To(HaveKeyWithValue("owner", labelSourceSpec))
+		Expect(meta.FindStatusCondition(cr.Status.Conditions, ConditionLabelTemplatesRendered)).To(BeNil())
+
+		By("reporting a template that no longer renders")
+		cr.Spec.LabelsTemplate = "cost-center: {{ .Namespace.Labels.region }}"
+		Expect(fakeClient.Update(ctx, &cr)).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &updatedNS)).To(Succeed())
+		Expect(updatedNS.Labels).NotTo(HaveKey("cost-center"))
+		Expect(fakeClient.Get(ctx, request.NamespacedName, &cr)).To(Succeed())
+		cond := meta.FindStatusCondition(cr.Status.Conditions, ConditionLabelTemplatesRendered)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("labelsTemplate failed"))
+
+		By("holding back rendered labels the spec validator rejects")
+		reconciler.SpecValidator = &webhookv1alpha1.NamespaceLabelCustomValidator{
+			Client: fakeClient, ReservedLabelPrefixes: webhookv1alpha1.DefaultReservedLabelPrefixes}
+		cr.Spec.LabelsTemplate = "kubernetes.io/owner: {{ .Namespace.Labels.team }}"
+		Expect(fakeClient.Update(ctx, &cr)).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &updatedNS)).To(Succeed())
+		Expect(updatedNS.Labels).NotTo(HaveKey("kubernetes.io/owner"))
+		Expect(fakeClient.Get(ctx, request.NamespacedName, &cr)).To(Succeed())
+		cond = meta.FindStatusCondition(cr.Status.Conditions, ConditionLabelTemplatesRendered)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("labelsTemplate rendered labels that fail validation"))
+	})
+
+	It("should apply rendered values and report render failures in a condition", func() {
+		f := newTestFixture()
+		reconciler, fakeClient, ctx := f.reconciler, f.client, f.ctx
+
+		Expect(fakeClient.Create(ctx, ns.DeepCopy())).To(Succeed())
+		Expect(fakeClient.Create(ctx, &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "test-ns", Finalizers: []string{FinalizerName}},
+			Spec: labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{
+				"owner":  "team-{{ .Namespace.Labels.team }}",
+				"region": "{{ .Namespace.Labels.region }}",
+			}},
+		})).To(Succeed())
+
+		request := reconcileRequest(StandardCRName, "test-ns")
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		var updatedNS corev1.Namespace
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &updatedNS)).To(Succeed())
+		Expect(updatedNS.Labels).To(HaveKeyWithValue("owner", "team-payments"))
+		Expect(updatedNS.Labels).NotTo(HaveKey("region"))
+
+		var cr labelsv1alpha1.NamespaceLabel
+		Expect(fakeClient.Get(ctx, request.NamespacedName, &cr)).To(Succeed())
+		cond := meta.FindStatusCondition(cr.Status.Conditions, ConditionLabelTemplatesRendered)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("label 'region'"))
+	})
+})
@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// labelTTLRemaining returns how long until the labels of cr expire and true, or false when spec.ttlSeconds is unset.
+// The TTL counts from status.ttlStartTime, the first apply of the current generation, so re-applying the labels
+// to correct drift does not extend it. A newer generation has not been applied yet and starts its TTL now; CRs
+// without a recorded start fall back to status.lastAppliedTime, or to creation when the labels never needed applying.
+func labelTTLRemaining(cr *labelsv1alpha1.NamespaceLabel, now time.Time) (time.Duration, bool) {
+	if cr.Spec.TTLSeconds <= 0 {
+		return 0, false
+	}
+	var start time.Time
+	switch {
+	case cr.Status.TTLStartTime != nil && cr.Status.TTLStartGeneration == cr.Generation:
+		start = cr.Status.TTLStartTime.Time
+	case cr.Status.TTLStartTime != nil:
+		start = now
+	case cr.Status.LastAppliedTime != nil:
+		start = cr.Status.LastAppliedTime.Time
+	default:
+		start = cr.CreationTimestamp.Time
+	}
+	expireAt := start.Add(time.Duration(cr.Spec.TTLSeconds) * time.Second)
+	if !now.Before(expireAt) {
+		return 0, true
+	}
+	return expireAt.Sub(now), true
+}
+
+// labelsExpired reports whether the labels of cr are past their TTL, or were already expired for this generation
+func (r *NamespaceLabelReconciler) labelsExpired(cr *labelsv1alpha1.NamespaceLabel) bool {
+	remaining, ok := labelTTLRemaining(cr, r.now())
+	if !ok {
+		return false
+	}
+	return remaining == 0 || meta.IsStatusConditionTrue(cr.Status.Conditions, ConditionExpired)
+}
+
+// startLabelTTL records the first apply of the current generation in status, which the TTL counts from.
+// Later reconciles of the same generation keep it; without spec.ttlSeconds it is cleared.
+func (r *NamespaceLabelReconciler) startLabelTTL(cr *labelsv1alpha1.NamespaceLabel) {
+	if cr.Spec.TTLSeconds <= 0 {
+		cr.Status.TTLStartTime = nil
+		cr.Status.TTLStartGeneration = 0
+		return
+	}
+	if cr.Status.TTLStartTime != nil && cr.Status.TTLStartGeneration == cr.Generation {
+		return
+	}
+	start := r.now()
+	// CRs reconciled before the start was recorded keep counting from their last apply
+	if cr.Status.TTLStartTime == nil && cr.Status.LastAppliedTime != nil {
+		start = cr.Status.LastAppliedTime.Time
+	}
+	cr.Status.TTLStartTime = &metav1.Time{Time: start}
+	cr.Status.TTLStartGeneration = cr.Generation
+}
+
+// ttlRequeue returns how long until the labels of cr expire, so the reconcile that applied them is requeued to
+// remove them on time
+func (r *NamespaceLabelReconciler) ttlRequeue(cr *labelsv1alpha1.NamespaceLabel) time.Duration {
+	remaining, ok := labelTTLRemaining(cr, r.now())
+	if !ok {
+		return 0
+	}
+	return max(remaining, time.Second)
+}
+
+// expireLabels removes the labels of an expired CR exactly as deleting it would, and reports Expired in its status.
+// The CR is not requeued; a spec change re-applies its labels and restarts the TTL.
+func (r *NamespaceLabelReconciler) expireLabels(ctx context.Context, current *labelsv1alpha1.NamespaceLabel) (ctrl.Result, error) {
+	l := log.FromContext(ctx)
+	released := &labelsv1alpha1.NamespaceLabel{ObjectMeta: metav1.ObjectMeta{Name: current.Name, Namespace: current.Namespace}}
+	result, err := r.processNamespaceLabels(ctx, released, current.Namespace, false)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// Fail-mode protection kept some labels; retry the removal rather than reporting them expired
+	if meta.IsStatusConditionTrue(released.Status.Conditions, ConditionProtectionConflict) {
+		return result, nil
+	}
+
+	message := fmt.Sprintf("Labels expired %ds after they were applied and were removed from namespace '%s'",
+		current.Spec.TTLSeconds, current.Namespace)
+	if !meta.IsStatusConditionTrue(current.Status.Conditions, ConditionExpired) {
+		l.Info("Labels expired, removed them from namespace", "namespace", current.Namespace, "ttlSeconds", current.Spec.TTLSeconds)
+		r.recordEvent(current, corev1.EventTypeNormal, "LabelsExpired", message)
+	}
+	setCondition(current, ConditionExpired, metav1.ConditionTrue, "TTLElapsed", message)
+	updateStatus(current, false, "Expired", message, nil, nil)
+	current.Status.AllowedLabels = nil
+	current.Status.LabelSources = nil
+	if err := r.updateCRStatus(ctx, current); err != nil {
+		l.Error(err, "failed to update status for expired labels")
+	}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+)
+
+// Tests for functions in label_ttl.go
+
+var _ = Describe("Label TTL", Label("controller"), func() {
+	var (
+		f          *testFixture
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		fakeClock  *clocktesting.FakeClock
+		ctx        context.Context
+		req        reconcile.Request
+	)
+
+	BeforeEach(func() {
+		f = newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+		fakeClock = clocktesting.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+		reconciler.Clock = fakeClock
+		req = reconcileRequest(StandardCRName, "test-ns")
+
+		f.createNamespace("test-ns", nil, nil)
+		Expect(fakeClient.Create(ctx, &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "test-ns", Finalizers: []string{FinalizerName},
+				Generation: 1, CreationTimestamp: metav1.NewTime(fakeClock.Now())},
+			Spec: labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"env": "dev"}, TTLSeconds: 3600},
+		})).To(Succeed())
+	})
+
+	namespaceLabels := func() map[string]string {
+		return f.getNamespace("test-ns").Labels
+	}
+	getCR := func() *labelsv1alpha1.NamespaceLabel {
+		return f.getCR(StandardCRName, "test-ns")
+	}
+
+	It("should remove the labels once the TTL elapses and keep the CR", func() {
+		By("applying the labels and requeueing at expiry")
+		result, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Hour))
+		Expect(namespaceLabels()).To(HaveKeyWithValue("env", "dev"))
+
+		By("counting down from the first apply while nothing changes")
+		fakeClock.Step(40 * time.Minute)
+		result, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(20 * time.Minute))
+
+		By("removing the labels once the TTL has elapsed")
+		fakeClock.Step(20 * time.Minute)
+		result, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(namespaceLabels()).NotTo(HaveKey("env"))
+
+		cr := getCR()
+		Expect(cr.Finalizers).To(ContainElement(FinalizerName))
+		Expect(meta.IsStatusConditionTrue(cr.Status.Conditions, ConditionExpired)).To(BeTrue())
+		Expect(meta.FindStatusCondition(cr.Status.Conditions, "Ready").Reason).To(Equal("Expired"))
+		Expect(cr.Status.NextReconcileAt).To(BeNil())
+
+		By("staying expired on later reconciles")
+		result, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(namespaceLabels()).NotTo(HaveKey("env"))
+	})
+
+	It("should keep counting from the first apply when drift is corrected before expiry", func() {
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("re-applying a label changed out-of-band without restarting the TTL")
+		fakeClock.Step(30 * time.Minute)
+		ns := f.getNamespace("test-ns")
+		ns.Labels["env"] = "drifted"
+		Expect(fakeClient.Update(ctx, ns)).To(Succeed())
+		result, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaceLabels()).To(HaveKeyWithValue("env", "dev"))
+		Expect(result.RequeueAfter).To(Equal(30 * time.Minute))
+		Expect(getCR().Status.TTLStartTime.Time).To(BeTemporally("==", fakeClock.Now().Add(-30*time.Minute)))
+
+		By("removing the labels one TTL after the first apply")
+		fakeClock.Step(30 * time.Minute)
+		result, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(namespaceLabels()).NotTo(HaveKey("env"))
+	})
+
+	It("should re-apply the labels and restart the TTL when the spec changes", func() {
+		_, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		fakeClock.Step(2 * time.Hour)
+		_, err = reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaceLabels()).NotTo(HaveKey("env"))
+
+		cr := getCR()
+		cr.Spec.Labels = map[string]string{"env": "prod"}
+		cr.Generation = 2
+		Expect(fakeClient.Update(ctx, cr)).To(Succeed())
+
+		result, err := reconciler.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Hour))
+		Expect(namespaceLabels()).To(HaveKeyWithValue("env", "prod"))
+		Expect(meta.FindStatusCondition(getCR().Status.Conditions, ConditionExpired)).To(BeNil())
+	})
+})
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Metrics are labeled by the namespace of the NamespaceLabel so they can be split per team, except where noted
+var (
+	protectedSkippedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "namespacelabel_protected_skipped_total",
+			Help: "Number of labels skipped or rejected because they conflict with a protected label",
+		},
+		[]string{"namespace"},
+	)
+
+	reconcileFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "namespacelabel_reconcile_failures_total",
+			Help: "Number of NamespaceLabel reconciles that returned an error",
+		},
+		[]string{"namespace"},
+	)
+
+	// managedLabelsPerNamespace is deliberately unlabeled: it gives the fleet-wide distribution of managed
+	// label counts without a series per namespace
+	managedLabelsPerNamespace = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "namespacelabel_managed_labels_per_namespace",
+			Help:    "Number of labels the operator manages on a namespace, observed on every successful reconcile",
+			Buckets: []float64{0, 1, 2, 4, 8, 16, 32, 64},
+		},
+	)
+
+	// appliedLabels is labeled by the namespace the labels are on, which for the selector NamespaceLabel is each
+	// selected namespace rather than its own. It is the total over every NamespaceLabel labeling the namespace,
+	// kept by appliedLabelCounts.
+	appliedLabels = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "namespacelabel_applied_labels",
+			Help: "Number of labels the operator currently manages on the namespace",
+		},
+		[]string{"namespace"},
+	)
+
+	appliedLabelCounts = labelCounts{counts: map[string]map[types.NamespacedName]int{}}
+)
+
+// labelCounts holds the applied label count of each NamespaceLabel by target namespace and NamespaceLabel, so
+// several NamespaceLabels labeling a namespace add up instead of overwriting each other's count
+type labelCounts struct {
+	mu     sync.Mutex
+	counts map[string]map[types.NamespacedName]int
+}
+
+// setAppliedLabels records the number of labels the NamespaceLabel owner applies to each namespace in counts,
+// dropping it from any namespace it no longer labels, and sets appliedLabels to the total of every namespace
+// it touched. A namespace's series is deleted once no NamespaceLabel labels it.
+func setAppliedLabels(owner types.NamespacedName, counts map[string]int) {
+	appliedLabelCounts.mu.Lock()
+	defer appliedLabelCounts.mu.Unlock()
+
+	for namespace, byOwner := range appliedLabelCounts.counts {
+		if _, ok := byOwner[owner]; !ok {
+			continue
+		}
+		if _, ok := counts[namespace]; ok {
+			continue
+		}
+		delete(byOwner, owner)
+		if len(byOwner) == 0 {
+			delete(appliedLabelCounts.counts, namespace)
+			appliedLabels.DeleteLabelValues(namespace)
+			continue
+		}
+		appliedLabels.WithLabelValues(namespace).Set(float64(sumCounts(byOwner)))
+	}
+	for namespace, count := range counts {
+		byOwner, ok := appliedLabelCounts.counts[namespace]
+		if !ok {
+			byOwner = map[types.NamespacedName]int{}
+			appliedLabelCounts.counts[namespace] = byOwner
+		}
+		byOwner[owner] = count
+		appliedLabels.WithLabelValues(namespace).Set(float64(sumCounts(byOwner)))
+	}
+}
+
+// forgetAppliedLabels drops the NamespaceLabel owner from the total of every namespace it labeled
+func forgetAppliedLabels(owner types.NamespacedName) {
+	setAppliedLabels(owner, nil)
+}
+
+func sumCounts(byOwner map[types.NamespacedName]int) int {
+	total := 0
+	for _, count := range byOwner {
+		total += count
+	}
+	return total
+}
+
+func init() {
+	metrics.Registry.MustRegister(protectedSkippedTotal, reconcileFailuresTotal, appliedLabels, managedLabelsPerNamespace)
+}
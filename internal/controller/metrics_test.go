@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for metrics.go
+
+var _ = Describe("Metrics", Label("controller"), func() {
+	var (
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		f := newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+	})
+
+	setup := func(namespace string, existing map[string]string, spec labelsv1alpha1.NamespaceLabelSpec) reconcile.Request {
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: existing}})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: namespace, Finalizers: []string{FinalizerName}},
+			Spec:       spec,
+		})).To(Succeed())
+		return reconcileRequest(StandardCRName, namespace)
+	}
+
+	It("should track applied labels and skipped protected labels per namespace", func() {
+		request := setup("metrics-skip", map[string]string{"kubernetes.io/owner": "platform"}, labelsv1alpha1.NamespaceLabelSpec{
+			Labels:                 map[string]string{"app": "web", "team": "a", "kubernetes.io/owner": "me"},
+			ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+		})
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testutil.ToFloat64(appliedLabels.WithLabelValues("metrics-skip"))).To(Equal(2.0))
+		Expect(testutil.ToFloat64(protectedSkippedTotal.WithLabelValues("metrics-skip"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(reconcileFailuresTotal.WithLabelValues("metrics-skip"))).To(BeZero())
+
+		By("deleting the CR")
+		var cr labelsv1alpha1.NamespaceLabel
+		Expect(fakeClient.Get(ctx, request.NamespacedName, &cr)).To(Succeed())
+		Expect(fakeClient.Delete(ctx, &cr)).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(appliedLabels.DeleteLabelValues("metrics-skip")).To(BeFalse(), "the gauge is dropped with the CR")
+	})
+
+	It("should report the total of every NamespaceLabel sharing a namespace", func() {
+		f := newTestFixture()
+		f.reconciler.MultipleNamespaceLabels = true
+		f.createNamespace("metrics-shared", nil, nil)
+		standard := f.createCR(StandardCRName, "metrics-shared", nil, []string{FinalizerName},
+			labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"app": "web", "team": "a"}})
+		payments := f.createCR("payments", "metrics-shared", nil, []string{FinalizerName},
+			labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"cost-center": "42"}})
+		for _, name := range []string{StandardCRName, "payments"} {
+			_, err := f.reconcile(name, "metrics-shared")
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(testutil.ToFloat64(appliedLabels.WithLabelValues("metrics-shared"))).To(Equal(3.0))
+
+		By("keeping the series for the NamespaceLabel left after one is deleted")
+		Expect(f.client.Delete(f.ctx, payments)).To(Succeed())
+		_, err := f.reconcile("payments", "metrics-shared")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testutil.ToFloat64(appliedLabels.WithLabelValues("metrics-shared"))).To(Equal(2.0))
+
+		By("dropping the series with the last NamespaceLabel")
+		Expect(f.client.Delete(f.ctx, standard)).To(Succeed())
+		_, err = f.reconcile(StandardCRName, "metrics-shared")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(appliedLabels.DeleteLabelValues("metrics-shared")).To(BeFalse())
+	})
+
+	It("should count the selector NamespaceLabel's labels in each selected namespace's series", func() {
+		f := newTestFixture()
+		f.reconciler.SelectorNamespace = "metrics-platform"
+		f.createNamespace("metrics-platform", nil, nil)
+		f.createNamespace("metrics-tenant-a", map[string]string{"tenant": "true"}, nil)
+		f.createNamespace("metrics-tenant-b", map[string]string{"tenant": "true"}, nil)
+		f.createCR(StandardCRName, "metrics-tenant-a", nil, []string{FinalizerName},
+			labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"app": "web"}})
+		selector := f.createCR(StandardCRName, "metrics-platform", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+			Labels:            map[string]string{"cost-center": "shared", "region": "eu"},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+		})
+		for _, namespace := range []string{"metrics-tenant-a", "metrics-platform"} {
+			_, err := f.reconcile(StandardCRName, namespace)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(testutil.ToFloat64(appliedLabels.WithLabelValues("metrics-tenant-a"))).To(Equal(3.0))
+		Expect(testutil.ToFloat64(appliedLabels.WithLabelValues("metrics-tenant-b"))).To(Equal(2.0))
+		Expect(appliedLabels.DeleteLabelValues("metrics-platform")).To(BeFalse(), "the selector namespace carries none of the labels")
+
+		By("dropping a namespace that stops matching")
+		tenant := f.getNamespace("metrics-tenant-b")
+		delete(tenant.Labels, "tenant")
+		Expect(f.client.Update(f.ctx, tenant)).To(Succeed())
+		_, err := f.reconcile(StandardCRName, "metrics-platform")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(appliedLabels.DeleteLabelValues("metrics-tenant-b")).To(BeFalse())
+
+		By("keeping the namespace's own NamespaceLabel once the selector NamespaceLabel is deleted")
+		Expect(f.client.Delete(f.ctx, selector)).To(Succeed())
+		_, err = f.reconcile(StandardCRName, "metrics-platform")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testutil.ToFloat64(appliedLabels.WithLabelValues("metrics-tenant-a"))).To(Equal(1.0))
+	})
+
+	It("should observe the managed label count of every successful reconcile in a histogram", func() {
+		sampleCount := func() (uint64, float64) {
+			var m dto.Metric
+			Expect(managedLabelsPerNamespace.Write(&m)).To(Succeed())
+			return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+		}
+		countBefore, sumBefore := sampleCount()
+
+		request := setup("metrics-histogram", nil, labelsv1alpha1.NamespaceLabelSpec{
+			Labels: map[string]string{"app": "web", "team": "a", "tier": "gold"},
+		})
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		count, sum := sampleCount()
+		Expect(count - countBefore).To(Equal(uint64(1)))
+		Expect(sum - sumBefore).To(Equal(3.0))
+	})
+
+	It("should count protection failures as conflicts but not as reconcile failures", func() {
+		request := setup("metrics-fail", map[string]string{"kubernetes.io/owner": "platform"}, labelsv1alpha1.NamespaceLabelSpec{
+			Labels:                 map[string]string{"kubernetes.io/owner": "me"},
+			ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+			ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+		})
+
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testutil.ToFloat64(protectedSkippedTotal.WithLabelValues("metrics-fail"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(reconcileFailuresTotal.WithLabelValues("metrics-fail"))).To(BeZero())
+	})
+})
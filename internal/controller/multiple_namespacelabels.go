@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// trackingKey returns the tracking annotation key recording what the NamespaceLabel named name applied.
+// The standard CR keeps base, so a namespace looks the same whether or not MultipleNamespaceLabels is set;
+// any other CR gets base suffixed with its name.
+func (r *NamespaceLabelReconciler) trackingKey(base, name string) string {
+	if !r.MultipleNamespaceLabels || name == "" || name == StandardCRName {
+		return base
+	}
+	return base + "." + name
+}
+
+// outranks reports whether a takes precedence over b on a label both set: the higher spec.priority wins,
+// then the alphabetically first name
+func outranks(a, b *labelsv1alpha1.NamespaceLabel) bool {
+	if a.Spec.Priority != b.Spec.Priority {
+		return a.Spec.Priority > b.Spec.Priority
+	}
+	return a.Name < b.Name
+}
+
+// resolveClaims returns the spec label keys, prefixed with their keyPrefix, that NamespaceLabels outranking
+// current set in its namespace, each mapped to the name of the highest-ranked one. Siblings being deleted or
+// labeling other namespaces through a selector claim nothing.
+func (r *NamespaceLabelReconciler) resolveClaims(ctx context.Context, current *labelsv1alpha1.NamespaceLabel) (map[string]string, error) {
+	if !r.MultipleNamespaceLabels {
+		return nil, nil
+	}
+
+	var list labelsv1alpha1.NamespaceLabelList
+	if err := r.List(ctx, &list, client.InNamespace(current.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list NamespaceLabels in namespace '%s': %w", current.Namespace, err)
+	}
+	var siblings []*labelsv1alpha1.NamespaceLabel
+	for i := range list.Items {
+		item := &list.Items[i]
+		if item.Name == current.Name || item.DeletionTimestamp != nil || item.Spec.NamespaceSelector != nil {
+			continue
+		}
+		if outranks(item, current) {
+			siblings = append(siblings, item)
+		}
+	}
+	sort.Slice(siblings, func(i, j int) bool { return outranks(siblings[i], siblings[j]) })
+
+	var claims map[string]string
+	for _, sibling := range siblings {
+		for k := range prefixKeys(webhookv1alpha1.SpecLabels(sibling.Spec), sibling.Spec.KeyPrefix) {
+			if _, ok := claims[k]; ok {
+				continue
+			}
+			if claims == nil {
+				claims = map[string]string{}
+			}
+			claims[k] = sibling.Name
+		}
+	}
+	return claims, nil
+}
+
+// dropClaimedLabels removes the keys claimed by higher-ranked NamespaceLabels from desired and prevApplied, so
+// they are neither applied nor removed but handed over as they are. It returns the claimed keys desired asked
+// for, mapped to the name of their claimer.
+func dropClaimedLabels(desired, prevApplied, claims map[string]string) map[string]string {
+	var claimed map[string]string
+	for k, name := range claims {
+		delete(prevApplied, k)
+		if _, ok := desired[k]; !ok {
+			continue
+		}
+		delete(desired, k)
+		if claimed == nil {
+			claimed = map[string]string{}
+		}
+		claimed[k] = name
+	}
+	return claimed
+}
+
+// reportedClaims returns claimed with its keys as they should appear in status
+func reportedClaims(cr *labelsv1alpha1.NamespaceLabel, claimed map[string]string) map[string]string {
+	if len(claimed) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(claimed))
+	for k, name := range claimed {
+		out[reportedKey(cr, k)] = name
+	}
+	return out
+}
+
+// namespaceLabelRequests returns a request for every NamespaceLabel in namespace other than exclude
+func (r *NamespaceLabelReconciler) namespaceLabelRequests(ctx context.Context, namespace, exclude string) []reconcile.Request {
+	var list labelsv1alpha1.NamespaceLabelList
+	if err := r.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list NamespaceLabels", "namespace", namespace)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if item.Name == exclude {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+	}
+	return requests
+}
+
+// mapSiblingsToRequests enqueues the other NamespaceLabels of the changed one's namespace, since its labels,
+// priority or deletion decide which of them applies a label they share
+func (r *NamespaceLabelReconciler) mapSiblingsToRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.namespaceLabelRequests(ctx, obj.GetNamespace(), obj.GetName())
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in multiple_namespacelabels.go
+
+var _ = Describe("Multiple NamespaceLabels", Label("controller"), func() {
+	var (
+		f          *testFixture
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		f = newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+		reconciler.MultipleNamespaceLabels = true
+
+		f.createNamespace("test-ns", nil, nil)
+	})
+
+	createCR := func(name string, priority int32, labels map[string]string) {
+		f.createCR(name, "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{Labels: labels, Priority: priority})
+	}
+	reconcileCR := func(name string) {
+		_, err := f.reconcile(name, "test-ns")
+		Expect(err).NotTo(HaveOccurred())
+	}
+	getCR := func(name string) *labelsv1alpha1.NamespaceLabel {
+		return f.getCR(name, "test-ns")
+	}
+	getNamespace := func() *corev1.Namespace {
+		return f.getNamespace("test-ns")
+	}
+
+	It("should merge the labels and leave a shared key to the higher priority", func() {
+		createCR(StandardCRName, 0, map[string]string{"env": "dev", "team": "platform"})
+		createCR("payments", 10, map[string]string{"env": "prod", "cost-center": "42"})
+		reconcileCR(StandardCRName)
+		reconcileCR("payments")
+
+		ns := getNamespace()
+		Expect(ns.Labels).To(HaveKeyWithValue("env", "prod"))
+		Expect(ns.Labels).To(HaveKeyWithValue("team", "platform"))
+		Expect(ns.Labels).To(HaveKeyWithValue("cost-center", "42"))
+		Expect(readTrackingAnnotation(ns, appliedAnnoKey)).To(Equal(map[string]string{"team": "platform"}))
+		Expect(readTrackingAnnotation(ns, appliedAnnoKey+".payments")).To(Equal(map[string]string{"env": "prod", "cost-center": "42"}))
+
+		Expect(getCR(StandardCRName).Status.ClaimedBy).To(Equal(map[string]string{"env": "payments"}))
+		Expect(getCR("payments").Status.ClaimedBy).To(BeNil())
+	})
+
+	It("should break a priority tie by the alphabetically first name", func() {
+		createCR("beta", 0, map[string]string{"env": "beta"})
+		createCR("alpha", 0, map[string]string{"env": "alpha"})
+		reconcileCR("beta")
+		reconcileCR("alpha")
+
+		Expect(getNamespace().Labels).To(HaveKeyWithValue("env", "alpha"))
+		Expect(getCR("beta").Status.ClaimedBy).To(Equal(map[string]string{"env": "alpha"}))
+	})
+
+	It("should hand over an applied label without removing it", func() {
+		createCR(StandardCRName, 0, map[string]string{"env": "dev"})
+		reconcileCR(StandardCRName)
+		Expect(getNamespace().Labels).To(HaveKeyWithValue("env", "dev"))
+
+		createCR("payments", 10, map[string]string{"env": "dev"})
+		reconcileCR(StandardCRName)
+		ns := getNamespace()
+		Expect(ns.Labels).To(HaveKeyWithValue("env", "dev"))
+		Expect(readTrackingAnnotation(ns, appliedAnnoKey)).To(BeEmpty())
+
+		reconcileCR("payments")
+		Expect(readTrackingAnnotation(getNamespace(), appliedAnnoKey+".payments")).To(Equal(map[string]string{"env": "dev"}))
+	})
+
+	It("should remove only the deleted NamespaceLabel's labels", func() {
+		createCR(StandardCRName, 0, map[string]string{"env": "dev"})
+		createCR("payments", 0, map[string]string{"cost-center": "42"})
+		reconcileCR(StandardCRName)
+		reconcileCR("payments")
+
+		Expect(fakeClient.Delete(ctx, getCR("payments"))).To(Succeed())
+		reconcileCR("payments")
+
+		ns := getNamespace()
+		Expect(ns.Labels).To(HaveKeyWithValue("env", "dev"))
+		Expect(ns.Labels).NotTo(HaveKey("cost-center"))
+		Expect(ns.Annotations).NotTo(HaveKey(appliedAnnoKey + ".payments"))
+		Expect(readTrackingAnnotation(ns, appliedAnnoKey)).To(Equal(map[string]string{"env": "dev"}))
+	})
+
+	It("should keep the standard tracking key when several are not allowed", func() {
+		reconciler.MultipleNamespaceLabels = false
+		Expect(reconciler.trackingKey(appliedAnnoKey, "payments")).To(Equal(appliedAnnoKey))
+
+		reconciler.MultipleNamespaceLabels = true
+		Expect(reconciler.trackingKey(appliedAnnoKey, StandardCRName)).To(Equal(appliedAnnoKey))
+		Expect(reconciler.trackingKey(appliedAnnotationsAnnoKey, "payments")).To(Equal(appliedAnnotationsAnnoKey + ".payments"))
+	})
+})
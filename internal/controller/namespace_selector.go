@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	"github.com/sbahar619/namespace-label-operator/internal/protection"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// selectedNamespaceResult is the outcome of syncing one namespace against the selector NamespaceLabel
+type selectedNamespaceResult struct {
+	// selected is set when the namespace matches the selector and carries the CR's labels
+	selected   bool
+	protection ProtectionResult
+}
+
+// processSelectedNamespaces applies the CR's labels to every namespace matching spec.namespaceSelector and
+// removes them from namespaces that no longer match. Only the CR in SelectorNamespace may use a selector,
+// since it writes to namespaces other than its own.
+func (r *NamespaceLabelReconciler) processSelectedNamespaces(ctx context.Context, current *labelsv1alpha1.NamespaceLabel) (ctrl.Result, error) {
+	l := log.FromContext(ctx)
+
+	if r.SelectorNamespace == "" || current.Namespace != r.SelectorNamespace {
+		message := fmt.Sprintf("spec.namespaceSelector is only allowed for the NamespaceLabel in namespace '%s'", r.SelectorNamespace)
+		if r.SelectorNamespace == "" {
+			message = "spec.namespaceSelector is disabled on this operator"
+		}
+		updateStatus(current, false, "NamespaceSelectorNotAllowed", message, nil, nil)
+		if err := r.updateCRStatus(ctx, current); err != nil {
+			l.Error(err, "failed to update status for disallowed namespace selector")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(current.Spec.NamespaceSelector)
+	if err != nil {
+		updateStatus(current, false, "InvalidNamespaceSelector", fmt.Sprintf("Invalid namespace selector: %v", err), nil, nil)
+		if err := r.updateCRStatus(ctx, current); err != nil {
+			l.Error(err, "failed to update status for invalid namespace selector")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	results, err := r.syncSelectedNamespaces(ctx, current, selector)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var selected, failed, skipped, conflicting []string
+	allowed := map[string]string{}
+	counts := map[string]int{}
+	for name, res := range results {
+		if res.protection.ShouldFail {
+			failed = append(failed, name)
+			for _, k := range res.protection.ConflictingKeys {
+				if !slices.Contains(conflicting, k) {
+					conflicting = append(conflicting, k)
+				}
+			}
+			continue
+		}
+		if !res.selected {
+			continue
+		}
+		selected = append(selected, name)
+		counts[name] = len(res.protection.AllowedLabels)
+		for k, v := range res.protection.AllowedLabels {
+			allowed[k] = v
+		}
+		for _, k := range res.protection.ProtectedSkipped {
+			if !slices.Contains(skipped, k) {
+				skipped = append(skipped, k)
+			}
+		}
+	}
+	sort.Strings(selected)
+	sort.Strings(failed)
+	sort.Strings(skipped)
+	sort.Strings(conflicting)
+	current.Status.SelectedNamespaces = selected
+	setProtectionConflict(current, conflicting)
+
+	if len(failed) > 0 {
+		message := fmt.Sprintf("Protected label conflicts in namespaces: %s", strings.Join(failed, ", "))
+		updateStatus(current, false, "ProtectedLabelConflict", message, skipped, nil)
+		requeueAfter := r.scheduleRequeue(current, r.failRequeueInterval(client.ObjectKeyFromObject(current)))
+		if err := r.updateCRStatus(ctx, current); err != nil {
+			l.Error(err, "failed to update status for protection conflict")
+		}
+		// The conflict is the CR's to resolve, so it is reported in status rather than returned as an error
+		l.Info("Protected label conflict in selected namespaces, requeueing", "namespaces", failed, "requeueAfter", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	r.failBackoff.reset(client.ObjectKeyFromObject(current))
+	l.Info("NamespaceLabel applied to selected namespaces", "namespaces", selected)
+	message := fmt.Sprintf("Applied %d labels to %d namespaces", len(allowed), len(selected))
+	updateStatus(current, true, "Synced", message, skipped, mapKeys(allowed))
+	current.Status.AllowedLabels = allowed
+	// Each selected namespace's series counts the labels applied to it, not the selector namespace's
+	setAppliedLabels(client.ObjectKeyFromObject(current), counts)
+	requeueAfter := r.scheduleRequeue(current, r.resyncInterval(current))
+	if err := r.updateCRStatus(ctx, current); err != nil {
+		l.Error(err, "failed to update CR status")
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// syncSelectedNamespaces brings every namespace in line with the selector: matching namespaces get the CR's
+// labels and the rest lose any labels the selector applied earlier. Pass labels.Nothing() to release them all.
+func (r *NamespaceLabelReconciler) syncSelectedNamespaces(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel,
+	selector labels.Selector) (map[string]selectedNamespaceResult, error) {
+	var list corev1.NamespaceList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	results := map[string]selectedNamespaceResult{}
+	for _, item := range list.Items {
+		if isOptedOut(&item) {
+			continue
+		}
+		_, tracked := item.Annotations[selectorAppliedAnnoKey]
+		if !tracked && !selector.Matches(labels.Set(item.Labels)) {
+			continue
+		}
+		res, err := r.syncSelectedNamespace(ctx, cr, item.Name, selector)
+		if err != nil {
+			return nil, err
+		}
+		results[item.Name] = res
+	}
+	return results, nil
+}
+
+// syncSelectedNamespace applies or releases the selector labels on one namespace, re-reading it on conflict.
+// Labels are tracked in selectorAppliedAnnoKey so they never mix with those of the namespace's own CR.
+func (r *NamespaceLabelReconciler) syncSelectedNamespace(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel,
+	name string, selector labels.Selector) (selectedNamespaceResult, error) {
+	var res selectedNamespaceResult
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var ns corev1.Namespace
+		if err := r.Get(ctx, types.NamespacedName{Name: name}, &ns); err != nil {
+			return err
+		}
+		base := ns.DeepCopy()
+		prevApplied := readTrackingAnnotation(&ns, selectorAppliedAnnoKey)
+		dropPhantomEntries(prevApplied, ns.Labels)
+
+		res = selectedNamespaceResult{selected: selector.Matches(labels.Set(ns.Labels))}
+		desired := map[string]string{}
+		if res.selected {
+			templated, _ := renderLabelsTemplate(cr, &ns, r.SpecValidator)
+			specLabels, _ := renderLabelTemplates(webhookv1alpha1.SpecLabels(cr.Spec), &ns)
+			specLabels = mergeLabels(templated, specLabels)
+			desired, _ = filterAllowedLabels(prefixKeys(specLabels, cr.Spec.KeyPrefix), cr.Spec.AllowedLabelPatterns)
+			desired, _ = filterValueCharset(desired, r.ValueCharset)
+		}
+		res.protection = applyProtectionLogic(desired, ns.Labels, withDefaultProtection(r.DefaultProtectedLabelPatterns, cr.Spec, ns.Labels),
+			cr.Spec.ProtectedLabelRules, cr.Spec.ProtectionMode, cr.Spec.ProtectCreation, cr.Spec.ProtectedValuePatterns)
+		enforceDefaultProtection(&res.protection, r.DefaultProtectedLabelPatterns, ns.Labels, cr.Spec)
+		enforceSystemNamespaceProtection(&res.protection, ns.Name, desired, ns.Labels)
+		if res.protection.ShouldFail {
+			return nil
+		}
+
+		// A namespace that stops matching is always released, whether or not the selector prunes stale labels
+		prune := !res.selected || pruneStaleLabels(cr)
+		changes, _ := r.applyLabelsToNamespace(&ns, res.protection.AllowedLabels, prevApplied,
+			protection.CompilePatterns(cr.Spec.RemovalProtectionPatterns), prune)
+		tracked := res.protection.AllowedLabels
+		if !prune {
+			tracked = mergeLabels(keptStaleLabels(ns.Labels, tracked, prevApplied), tracked)
+		}
+		trackingChanged, err := setTrackingAnnotation(&ns, selectorAppliedAnnoKey, tracked, labelOrder(cr.Spec), r.OrderedAppliedAnnotation)
+		if err != nil {
+			return err
+		}
+		if len(changes) > 0 || trackingChanged {
+			return r.patchNamespace(ctx, &ns, base)
+		}
+		return nil
+	})
+	return res, err
+}
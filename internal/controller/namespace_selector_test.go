@@ -0,0 +1,262 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in namespace_selector.go
+
+var _ = Describe("Namespace selector", Label("controller"), func() {
+	var (
+		f          *testFixture
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		f = newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+		reconciler.SelectorNamespace = "platform"
+	})
+
+	requestFor := func(namespace string) reconcile.Request {
+		return reconcileRequest(StandardCRName, namespace)
+	}
+
+	createNamespace := func(name string, labels map[string]string) {
+		f.createNamespace(name, labels, nil)
+	}
+
+	createCR := func(namespace string, spec labelsv1alpha1.NamespaceLabelSpec) *labelsv1alpha1.NamespaceLabel {
+		return f.createCR(StandardCRName, namespace, nil, []string{FinalizerName}, spec)
+	}
+
+	namespaceLabels := func(name string) map[string]string {
+		return f.getNamespace(name).Labels
+	}
+
+	selectorSpec := labelsv1alpha1.NamespaceLabelSpec{
+		Labels:            map[string]string{"cost-center": "shared"},
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+	}
+
+	It("should skip matching namespaces that opted out of management", func() {
+		createNamespace("platform", nil)
+		createNamespace("team-a", map[string]string{"tenant": "true"})
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:        "team-b",
+			Labels:      map[string]string{"tenant": "true"},
+			Annotations: map[string]string{IgnoreAnnoKey: "true"},
+		}})).To(Succeed())
+		cr := createCR("platform", selectorSpec)
+
+		_, err := reconciler.Reconcile(ctx, requestFor("platform"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaceLabels("team-a")).To(HaveKeyWithValue("cost-center", "shared"))
+		Expect(namespaceLabels("team-b")).NotTo(HaveKey("cost-center"))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+		Expect(cr.Status.SelectedNamespaces).To(Equal([]string{"team-a"}))
+	})
+
+	It("should label matching namespaces and release those that stop matching", func() {
+		createNamespace("platform", nil)
+		createNamespace("team-a", map[string]string{"tenant": "true"})
+		createNamespace("team-b", map[string]string{"tenant": "true"})
+		createNamespace("kube-system", nil)
+		cr := createCR("platform", selectorSpec)
+
+		_, err := reconciler.Reconcile(ctx, requestFor("platform"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaceLabels("team-a")).To(HaveKeyWithValue("cost-center", "shared"))
+		Expect(namespaceLabels("team-b")).To(HaveKeyWithValue("cost-center", "shared"))
+		Expect(namespaceLabels("kube-system")).NotTo(HaveKey("cost-center"))
+		Expect(namespaceLabels("platform")).NotTo(HaveKey("cost-center"))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+		Expect(cr.Status.Applied).To(BeTrue())
+		Expect(cr.Status.SelectedNamespaces).To(Equal([]string{"team-a", "team-b"}))
+
+		By("removing the tenant label from team-b")
+		var ns corev1.Namespace
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "team-b"}, &ns)).To(Succeed())
+		delete(ns.Labels, "tenant")
+		Expect(fakeClient.Update(ctx, &ns)).To(Succeed())
+
+		_, err = reconciler.Reconcile(ctx, requestFor("platform"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaceLabels("team-a")).To(HaveKeyWithValue("cost-center", "shared"))
+		Expect(namespaceLabels("team-b")).NotTo(HaveKey("cost-center"))
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "team-b"}, &ns)).To(Succeed())
+		Expect(ns.Annotations).NotTo(HaveKey(selectorAppliedAnnoKey))
+
+		By("deleting the selector NamespaceLabel")
+		Expect(fakeClient.Delete(ctx, cr)).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, requestFor("platform"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaceLabels("team-a")).NotTo(HaveKey("cost-center"))
+	})
+
+	It("should track selector labels apart from the namespace's own NamespaceLabel", func() {
+		createNamespace("platform", nil)
+		createNamespace("team-a", map[string]string{"tenant": "true"})
+		createCR("platform", selectorSpec)
+		createCR("team-a", labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"team": "a"}})
+
+		_, err := reconciler.Reconcile(ctx, requestFor("platform"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = reconciler.Reconcile(ctx, requestFor("team-a"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = reconciler.Reconcile(ctx, requestFor("platform"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(namespaceLabels("team-a")).To(HaveKeyWithValue("cost-center", "shared"))
+		Expect(namespaceLabels("team-a")).To(HaveKeyWithValue("team", "a"))
+	})
+
+	It("should back off fail-mode conflicts through the returned requeue, without an error", func() {
+		reconciler.FailRequeueInterval = time.Minute
+		reconciler.MaxFailRequeueInterval = 3 * time.Minute
+		createNamespace("platform", nil)
+		createNamespace("team-a", map[string]string{"tenant": "true", "cost-center": "team-a"})
+		cr := createCR("platform", labelsv1alpha1.NamespaceLabelSpec{
+			Labels:                 selectorSpec.Labels,
+			NamespaceSelector:      selectorSpec.NamespaceSelector,
+			ProtectedLabelPatterns: []string{"cost-center"},
+			ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+		})
+
+		for _, expected := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute} {
+			result, err := reconciler.Reconcile(ctx, requestFor("platform"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(expected))
+		}
+		Expect(namespaceLabels("team-a")).To(HaveKeyWithValue("cost-center", "team-a"))
+
+		By("starting over once the conflict clears")
+		var ns corev1.Namespace
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "team-a"}, &ns)).To(Succeed())
+		delete(ns.Labels, "cost-center")
+		Expect(fakeClient.Update(ctx, &ns)).To(Succeed())
+		result, err := reconciler.Reconcile(ctx, requestFor("platform"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "team-a"}, &ns)).To(Succeed())
+		ns.Labels["cost-center"] = "team-a"
+		Expect(fakeClient.Update(ctx, &ns)).To(Succeed())
+		result, err = reconciler.Reconcile(ctx, requestFor("platform"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+		Expect(meta.FindStatusCondition(cr.Status.Conditions, "Ready").Reason).To(Equal("ProtectedLabelConflict"))
+	})
+
+	It("should report the requeue of a fail-mode conflict as the next reconcile", func() {
+		start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		reconciler.Clock = clocktesting.NewFakeClock(start)
+		reconciler.FailRequeueInterval = time.Minute
+		createNamespace("platform", nil)
+		createNamespace("team-a", map[string]string{"tenant": "true", "cost-center": "team-a"})
+		cr := createCR("platform", labelsv1alpha1.NamespaceLabelSpec{
+			Labels:                 selectorSpec.Labels,
+			NamespaceSelector:      selectorSpec.NamespaceSelector,
+			ProtectedLabelPatterns: []string{"cost-center"},
+			ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+		})
+
+		result, err := reconciler.Reconcile(ctx, requestFor("platform"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+		Expect(cr.Status.NextReconcileAt.Time).To(BeTemporally("==", start.Add(result.RequeueAfter)))
+	})
+
+	It("should refuse a selector outside the selector namespace", func() {
+		createNamespace("team-a", map[string]string{"tenant": "true"})
+		createNamespace("team-b", map[string]string{"tenant": "true"})
+		cr := createCR("team-a", selectorSpec)
+
+		_, err := reconciler.Reconcile(ctx, requestFor("team-a"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(namespaceLabels("team-b")).NotTo(HaveKey("cost-center"))
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+		cond := meta.FindStatusCondition(cr.Status.Conditions, "Ready")
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("NamespaceSelectorNotAllowed"))
+	})
+
+	It("should enqueue the selector NamespaceLabel for any namespace change", func() {
+		createNamespace("platform", nil)
+		createCR("platform", selectorSpec)
+
+		Expect(reconciler.mapNamespaceToRequests(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}})).
+			To(ConsistOf(requestFor("platform")))
+		Expect(reconciler.mapNamespaceToRequests(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform"}})).
+			To(ConsistOf(requestFor("platform")))
+	})
+
+	It("should converge when namespace CRs reconcile concurrently with the selector", func() {
+		reconciler.WriteBudget = NewWriteBudget(1000, 1000)
+		createNamespace("platform", nil)
+		createCR("platform", selectorSpec)
+		teams := []string{"team-a", "team-b", "team-c", "team-d"}
+		for _, team := range teams {
+			createNamespace(team, map[string]string{"tenant": "true"})
+			createCR(team, labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"team": team}})
+		}
+
+		// Conflicts between workers writing the same namespace are retried, as the workqueue would
+		var wg sync.WaitGroup
+		for _, namespace := range append([]string{"platform"}, teams...) {
+			wg.Add(1)
+			go func(namespace string) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				Eventually(func() error {
+					_, err := reconciler.Reconcile(ctx, requestFor(namespace))
+					return err
+				}).Should(Succeed())
+			}(namespace)
+		}
+		wg.Wait()
+
+		for _, team := range teams {
+			Expect(namespaceLabels(team)).To(HaveKeyWithValue("cost-center", "shared"))
+			Expect(namespaceLabels(team)).To(HaveKeyWithValue("team", team))
+		}
+	})
+})
@@ -2,17 +2,32 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	"github.com/sbahar619/namespace-label-operator/internal/protection"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // RBAC: access our CRD + update Namespaces.
@@ -20,155 +35,908 @@ import (
 // +kubebuilder:rbac:groups=labels.shahaf.com,resources=namespacelabels/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=labels.shahaf.com,resources=namespacelabels/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
 func (r *NamespaceLabelReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// Create the controller without unnecessary namespace watch
-	return ctrl.NewControllerManagedBy(mgr).
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("namespacelabel-controller")
+	}
+
+	// Namespaces are watched so out-of-band label edits are corrected, filtered to label/annotation changes.
+	// The CRD is watched only so toggling the kill switch re-reconciles every CR,
+	// and ConfigMaps so hash labels follow content changes. ConfigMaps are watched by metadata only, which still
+	// sees every data change through the resourceVersion, and hash labels read their data with a live Get.
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&labelsv1alpha1.NamespaceLabel{}).
-		Complete(r)
+		Watches(&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.mapNamespaceToRequests),
+			builder.WithPredicates(predicate.Or(predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.mapConfigMapToRequests), builder.OnlyMetadata).
+		Watches(&apiextensionsv1.CustomResourceDefinition{},
+			handler.EnqueueRequestsFromMapFunc(r.mapKillSwitchToRequests),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetName() == crdName
+			}))).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles})
+
+	// NamespaceLabels sharing a namespace decide between them which applies a label they both set
+	if r.MultipleNamespaceLabels {
+		b = b.Watches(&labelsv1alpha1.NamespaceLabel{}, handler.EnqueueRequestsFromMapFunc(r.mapSiblingsToRequests),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	}
+
+	// Namespace deletions only feed the archive and never enqueue a reconcile
+	if r.StateArchiver != nil {
+		b = b.Watches(&corev1.Namespace{}, handler.Funcs{DeleteFunc: r.archiveDeletedNamespace})
+	}
+	return b.Complete(r)
+}
+
+// mapNamespaceToRequests enqueues the namespace's NamespaceLabel, if one exists, so drift gets re-applied.
+// Under MultipleNamespaceLabels every NamespaceLabel of the namespace is enqueued.
+// The selector NamespaceLabel is enqueued too, since any namespace may start or stop matching its selector,
+// as are the NamespaceLabels whose spec.conditionalOnNamespace or spec.inheritFrom names the namespace.
+func (r *NamespaceLabelReconciler) mapNamespaceToRequests(ctx context.Context, obj client.Object) []reconcile.Request {
+	namespaces := []string{obj.GetName()}
+	if r.SelectorNamespace != "" && r.SelectorNamespace != obj.GetName() {
+		namespaces = append(namespaces, r.SelectorNamespace)
+	}
+
+	var requests []reconcile.Request
+	for _, namespace := range namespaces {
+		if r.MultipleNamespaceLabels {
+			requests = append(requests, r.namespaceLabelRequests(ctx, namespace, "")...)
+			continue
+		}
+		key := types.NamespacedName{Namespace: namespace, Name: StandardCRName}
+		var cr labelsv1alpha1.NamespaceLabel
+		if err := r.Get(ctx, key, &cr); err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.FromContext(ctx).Error(err, "failed to get NamespaceLabel for namespace change", "namespace", obj.GetName())
+			}
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: key})
+	}
+	return r.addDependentRequests(ctx, obj.GetName(), requests)
+}
+
+// mapKillSwitchToRequests enqueues every NamespaceLabel when the CRD carrying the kill switch changes
+func (r *NamespaceLabelReconciler) mapKillSwitchToRequests(ctx context.Context, _ client.Object) []reconcile.Request {
+	var list labelsv1alpha1.NamespaceLabelList
+	if err := r.List(ctx, &list); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list NamespaceLabels for kill switch change")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, item := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+	}
+	return requests
 }
 
-func (r *NamespaceLabelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+// isKillSwitchActive reports whether the kill switch annotation is set on the NamespaceLabel CRD
+func (r *NamespaceLabelReconciler) isKillSwitchActive(ctx context.Context) (bool, error) {
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := r.Get(ctx, types.NamespacedName{Name: crdName}, &crd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check kill switch: %w", err)
+	}
+	return crd.Annotations[KillSwitchAnnoKey] == "true", nil
+}
+
+func (r *NamespaceLabelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	ctx, span := r.startReconcileSpan(ctx, req)
 	l := log.FromContext(ctx)
+	defer func() {
+		if err != nil {
+			reconcileFailuresTotal.WithLabelValues(req.Namespace).Inc()
+		} else {
+			// Every outcome that is not an error counts for the health check, including the kill switch, opt-outs,
+			// expired TTLs and CRs still waiting to apply, so a healthy but idle controller is never restarted
+			r.recordSuccessfulReconcile()
+		}
+		endSpan(span, err)
+	}()
 
 	var current labelsv1alpha1.NamespaceLabel
-	err := r.Get(ctx, req.NamespacedName, &current)
+	err = r.Get(ctx, req.NamespacedName, &current)
 	exists := err == nil
 	if err != nil && !apierrors.IsNotFound(err) {
 		return ctrl.Result{}, err
 	}
+	if !exists {
+		// A deleted CR still finds its own tracking annotations by name
+		current.Name, current.Namespace = req.Name, req.Namespace
+	}
+	// Only a path that requeues schedules the next reconcile, so status written by any other reports none
+	current.Status.NextReconcileAt = nil
+
+	// Requeue instead of exceeding the operator-wide API write rate
+	if delay := r.WriteBudget.acquire(req.NamespacedName, r.now()); delay > 0 {
+		l.V(1).Info("Write budget exhausted, requeueing", "namespace", req.Namespace, "after", delay)
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
+	// Kill switch halts every label operation, including deletion cleanup, while keeping applied labels in place
+	killSwitch, err := r.isKillSwitchActive(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if killSwitch {
+		l.Info("Kill switch is active, skipping reconciliation", "namespace", req.Namespace)
+		if exists && current.DeletionTimestamp == nil {
+			setCondition(&current, ConditionKillSwitchActive, metav1.ConditionTrue, "KillSwitchActive",
+				fmt.Sprintf("Label operations are halted by the '%s' annotation on CRD '%s'", KillSwitchAnnoKey, crdName))
+			if err := r.updateCRStatus(ctx, &current); err != nil {
+				l.Error(err, "failed to update status for kill switch")
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+	meta.RemoveStatusCondition(&current.Status.Conditions, ConditionKillSwitchActive)
+
+	if !exists || current.DeletionTimestamp != nil {
+		r.failBackoff.reset(req.NamespacedName)
+	}
 
 	// Handle deletion
 	if exists && current.DeletionTimestamp != nil {
 		return r.finalize(ctx, &current)
 	}
 
-	// Add finalizer if it doesn't exist and CR exists
+	// The defaulting webhook adds the finalizer at creation; this is the safety net for CRs admitted without
+	// it, and also collapses duplicate or malformed finalizers into exactly one
 	if exists {
-		if !controllerutil.ContainsFinalizer(&current, FinalizerName) {
-			controllerutil.AddFinalizer(&current, FinalizerName)
+		if normalizeFinalizers(&current) {
 			if err := r.Update(ctx, &current); err != nil {
 				return ctrl.Result{}, err
 			}
-			return ctrl.Result{}, nil // Stop reconciliation after adding finalizer
+			return ctrl.Result{}, nil // Stop reconciliation after fixing finalizers
+		}
+	}
+
+	// Re-check the spec in case it was admitted while the webhook was unavailable
+	if exists {
+		if err := r.checkSpecValidation(ctx, &current); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// A namespace opted out with IgnoreAnnoKey is never mutated, whatever its NamespaceLabel asks for
+	if exists && current.Spec.NamespaceSelector == nil {
+		optedOut, err := r.isNamespaceOptedOut(ctx, req.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if optedOut {
+			return r.reportOptedOut(ctx, &current)
+		}
+		meta.RemoveStatusCondition(&current.Status.Conditions, ConditionNamespaceOptedOut)
+	}
+
+	// Staged onboarding: hold off applying labels until spec.applyAfter has elapsed since creation
+	if exists {
+		if wait := applyDelayRemaining(&current, r.now()); wait > 0 {
+			applyAt := r.now().Add(wait)
+			l.Info("Delaying label apply", "namespace", req.Namespace, "applyAt", applyAt)
+			setCondition(&current, ConditionPendingDelayedApply, metav1.ConditionTrue, "DelayedApply",
+				fmt.Sprintf("Labels will be applied at %s", applyAt.UTC().Format(time.RFC3339)))
+			r.scheduleRequeue(&current, wait)
+			if err := r.updateCRStatus(ctx, &current); err != nil {
+				l.Error(err, "failed to update status for delayed apply")
+			}
+			return ctrl.Result{RequeueAfter: wait}, nil
 		}
+		meta.RemoveStatusCondition(&current.Status.Conditions, ConditionPendingDelayedApply)
 	}
 
-	// Target namespace is always the same as the CR's namespace for multi-tenant security
-	targetNS := req.Namespace
+	// Cross-namespace gating: hold off until the control namespace carries the required label
+	if exists {
+		reason, unmet, err := r.unmetNamespaceCondition(ctx, &current)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if unmet != "" {
+			l.Info("Waiting for conditional namespace", "namespace", req.Namespace, "reason", unmet)
+			setCondition(&current, ConditionNamespaceNotReady, metav1.ConditionTrue, reason, unmet)
+			if err := r.updateCRStatus(ctx, &current); err != nil {
+				l.Error(err, "failed to update status for unmet namespace condition")
+			}
+			return ctrl.Result{}, nil
+		}
+		meta.RemoveStatusCondition(&current.Status.Conditions, ConditionNamespaceNotReady)
+	}
 
-	ns, err := r.getTargetNamespace(ctx, targetNS)
+	// Labels are removed once spec.ttlSeconds has passed since they were applied; the CR itself is kept
+	if exists && current.Spec.NamespaceSelector == nil {
+		if cond := meta.FindStatusCondition(current.Status.Conditions, ConditionExpired); cond != nil && cond.ObservedGeneration != current.Generation {
+			// A spec change re-applies the labels and restarts the TTL
+			meta.RemoveStatusCondition(&current.Status.Conditions, ConditionExpired)
+			current.Status.TTLStartTime = &metav1.Time{Time: r.now()}
+			current.Status.TTLStartGeneration = current.Generation
+		}
+		if r.labelsExpired(&current) {
+			return r.expireLabels(ctx, &current)
+		}
+	}
+
+	// The selector NamespaceLabel labels every matching namespace instead of its own
+	if exists && current.Spec.NamespaceSelector != nil {
+		return r.processSelectedNamespaces(ctx, &current)
+	}
+	if exists && r.SelectorNamespace != "" && req.Namespace == r.SelectorNamespace {
+		// Release the namespaces of a selector that was dropped from the spec
+		if _, err := r.syncSelectedNamespaces(ctx, &current, labels.Nothing()); err != nil {
+			return ctrl.Result{}, err
+		}
+		current.Status.SelectedNamespaces = nil
+	}
+
+	// Without a selector the target namespace is always the CR's own namespace for multi-tenant security
+	return r.processNamespaceLabels(ctx, &current, req.Namespace, exists)
+}
+
+// processNamespaceLabels applies the CR's desired labels to the target namespace and reports the outcome.
+// When the CR no longer exists the desired set is empty, so previously applied labels are cleaned up.
+func (r *NamespaceLabelReconciler) processNamespaceLabels(ctx context.Context, current *labelsv1alpha1.NamespaceLabel, targetNS string, exists bool) (ctrl.Result, error) {
+	ctx, span := startSpan(ctx, "processNamespaceLabels")
+	defer span.End()
+	l := log.FromContext(ctx)
+
+	var resolved resolvedLabels
+	var err error
+	resolved.hash, resolved.hashWarnings, err = r.resolveHashLabels(ctx, current)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	resolved.inherited, resolved.inheritReason, resolved.inheritWarnings, err = r.resolveInheritedLabels(ctx, current)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	if exists {
+		resolved.claims, err = r.resolveClaims(ctx, current)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	appliedKey := r.trackingKey(appliedAnnoKey, current.Name)
+	annotationsKey := r.trackingKey(appliedAnnotationsAnnoKey, current.Name)
 
-	// Process namespace labels with protection logic
-	desired := current.Spec.Labels
-	prevApplied := readAppliedAnnotation(ns)
+	// The namespace mutation is retried on conflict. Each attempt re-reads the namespace and re-plans,
+	// so protection and diffing see any concurrent change instead of overwriting it.
+	var (
+		ns                 *corev1.Namespace
+		plan               labelPlan
+		changes            []labelsv1alpha1.LabelChange
+		retained           []string
+		stale              []string
+		removed            []labelsv1alpha1.LabelChange
+		removeRetained     []string
+		annotationsChanged bool
+		trackingChanged    bool
+		appliedChanged     bool
+		optedOut           bool
+	)
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		ns, err = r.getTargetNamespace(ctx, targetNS)
+		if err != nil {
+			return err
+		}
+		// The opt-out was checked before this read; one added since then must still win over our write
+		if optedOut = exists && isOptedOut(ns); optedOut {
+			return nil
+		}
+		base := ns.DeepCopy()
+		plan = planLabels(ctx, current, ns, resolved, r.ProtectionStatusLabel, r.ValueCharset, r.DefaultProtectedLabelPatterns, appliedKey,
+			r.SpecValidator)
+		if len(plan.phantoms) > 0 {
+			// A namespace recreated with copied annotations can claim labels it no longer has
+			l.Info("Applied annotation references labels missing from namespace", "namespace", targetNS, "labels", plan.phantoms)
+		}
 
-	allProtectionPatterns := current.Spec.ProtectedLabelPatterns
-	protectionMode := current.Spec.ProtectionMode
+		// Protection failures and dry runs are reported below without touching the namespace
+		if plan.protection.ShouldFail || (exists && current.Spec.DryRun) {
+			return nil
+		}
 
-	// Apply protection logic
-	if ns.Labels == nil {
-		ns.Labels = map[string]string{}
+		changes, retained = r.applyLabelsToNamespace(ns, plan.effective, plan.prevApplied, plan.removalProtection, pruneStaleLabels(current))
+		stale = removedKeys(changes)
+		// Listed keys are deleted whoever set them unless protected; they were never ours, so they stay out of the
+		// applied annotation
+		removed, removeRetained = removeListedLabels(ns.Labels, current.Spec.RemoveLabels, plan.effective, plan.removalProtection,
+			r.removalGuard(current.Spec, ns))
+		changes = append(changes, removed...)
+		sortLabelChanges(changes)
+		if len(changes) > 0 && r.ChangelogEntries > 0 {
+			if err := appendChangelog(ns, changes, r.now(), r.ChangelogEntries); err != nil {
+				return err
+			}
+		}
+
+		// The applied annotation is written in the same update as the labels it records, never apart from them.
+		// Retained labels stay tracked so they are released once removal protection no longer covers them.
+		tracked := plan.tracked
+		for _, k := range retained {
+			tracked[k] = ns.Labels[k]
+		}
+		// Without pruning the applied annotation is the union, so deleting the CR still removes dropped labels
+		if !pruneStaleLabels(current) {
+			for k, v := range keptStaleLabels(ns.Labels, plan.effective, plan.prevApplied) {
+				tracked[k] = v
+			}
+		}
+		// Only the standard CR keeps an empty applied annotation; the others drop theirs once nothing is applied
+		if appliedKey == appliedAnnoKey {
+			appliedChanged, err = setAppliedAnnotation(ns, tracked, labelOrder(current.Spec), r.OrderedAppliedAnnotation)
+		} else {
+			appliedChanged, err = setTrackingAnnotation(ns, appliedKey, tracked, labelOrder(current.Spec), r.OrderedAppliedAnnotation)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Annotations ride along in the same namespace update, including their tracking annotation
+		annotationsChanged = r.applyAnnotationsToNamespace(ns, current.Spec.Annotations, readTrackingAnnotation(ns, annotationsKey))
+		trackingChanged, err = setTrackingAnnotation(ns, annotationsKey, current.Spec.Annotations, nil, r.OrderedAppliedAnnotation)
+		if err != nil {
+			return err
+		}
+		// The owner UID names the standard CR, whichever others share the namespace
+		if appliedKey == appliedAnnoKey {
+			var ownerUID string
+			if exists && r.OwnerUIDAnnotation {
+				ownerUID = string(current.UID)
+			}
+			trackingChanged = setOwnerUIDAnnotation(ns, ownerUID) || trackingChanged
+		}
+
+		if len(changes) > 0 || annotationsChanged || trackingChanged || appliedChanged {
+			if r.PreflightNamespaceUpdates {
+				if err := r.preflightNamespacePatch(ctx, ns, base); err != nil {
+					return err
+				}
+			}
+			return r.patchNamespace(ctx, ns, base)
+		}
+		return nil
+	})
+	var rejected *applyRejectedError
+	if exists && errors.As(err, &rejected) {
+		return r.reportApplyRejected(ctx, current, targetNS, rejected)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
 	}
+	if optedOut {
+		return r.reportOptedOut(ctx, current)
+	}
+	meta.RemoveStatusCondition(&current.Status.Conditions, ConditionApplyWouldBeRejected)
 
-	protectionResult := applyProtectionLogic(
-		desired,
-		ns.Labels,
-		allProtectionPatterns,
-		protectionMode,
-	)
+	changed := len(changes) > 0
+	desired := plan.desired
+	protectionResult := plan.protection
+	templateWarnings := plan.templateWarnings
+
+	// Fail-mode conflicts are rejected labels too
+	conflicts := len(protectionResult.ProtectedSkipped) + len(protectionResult.ConflictingKeys)
+	if conflicts > 0 {
+		protectedSkippedTotal.WithLabelValues(targetNS).Add(float64(conflicts))
+	}
+	reported := reportedResult(current, protectionResult)
+	if len(plan.disallowed) > 0 {
+		l.Info("Dropping labels not matching allowedLabelPatterns", "namespace", targetNS, "labels", plan.disallowed)
+	}
+	current.Status.DisallowedLabels = reportedKeys(current, plan.disallowed)
+	if len(plan.outsideCharset) > 0 {
+		l.Info("Dropping labels with values outside the value charset", "namespace", targetNS, "labels", plan.outsideCharset)
+		setCondition(current, ConditionLabelValuesInCharset, metav1.ConditionFalse, "ValuesOutsideCharset",
+			fmt.Sprintf("Values outside the %s charset are not applied: %s", r.ValueCharset,
+				summarizeKeys(reportedKeys(current, plan.outsideCharset))))
+	} else {
+		meta.RemoveStatusCondition(&current.Status.Conditions, ConditionLabelValuesInCharset)
+	}
+	if protectionResult.EmptyKeysDropped > 0 {
+		l.Info("Dropping labels with an empty key", "namespace", targetNS, "count", protectionResult.EmptyKeysDropped)
+		setCondition(current, ConditionLabelKeysValid, metav1.ConditionFalse, "EmptyKeyDropped",
+			fmt.Sprintf("%d labels with an empty key are not applied", protectionResult.EmptyKeysDropped))
+	} else {
+		meta.RemoveStatusCondition(&current.Status.Conditions, ConditionLabelKeysValid)
+	}
+	if len(plan.unmergeable) > 0 {
+		l.Info("Holding list-merge labels whose merged value would be invalid", "namespace", targetNS, "labels", plan.unmergeable)
+		setCondition(current, ConditionListLabelsMerged, metav1.ConditionFalse, "InvalidMergedValue",
+			fmt.Sprintf("Merging would produce an invalid label value, keeping the namespace value of: %s",
+				summarizeKeys(reportedKeys(current, plan.unmergeable))))
+	} else {
+		meta.RemoveStatusCondition(&current.Status.Conditions, ConditionListLabelsMerged)
+	}
+	if len(plan.overwriteConflicts) > 0 {
+		l.Info("Leaving labels the operator does not own", "namespace", targetNS, "labels", plan.overwriteConflicts)
+	}
+	current.Status.OverwriteConflicts = reportedKeys(current, plan.overwriteConflicts)
+	if len(plan.claimed) > 0 {
+		l.Info("Leaving labels to higher-priority NamespaceLabels", "namespace", targetNS, "claimedBy", plan.claimed)
+	}
+	current.Status.ClaimedBy = reportedClaims(current, plan.claimed)
+	current.Status.LabelSources = reportedSources(current, plan.sources, protectionResult.AllowedLabels)
+	current.Status.ConfigHash = r.configHash(current, ns.Labels, plan.sources)
+	// Drift is measured before anything is re-applied, so it reflects changes made since the last reconcile
+	current.Status.IntegrityOK = len(plan.drifted) == 0
+	current.Status.PreservedLabels = plan.preserved
+	setProtectionConflict(current, reported.ConflictingKeys)
 
 	// If protection mode is "fail" and we hit protected labels, fail the reconciliation
 	if protectionResult.ShouldFail {
 		message := fmt.Sprintf("Protected label conflicts: %s", strings.Join(protectionResult.Warnings, "; "))
-		updateStatus(&current, false, "ProtectedLabelConflict", message, protectionResult.ProtectedSkipped, nil)
-		if err := r.Status().Update(ctx, &current); err != nil {
+		if exists && !hasReadyCondition(current, "ProtectedLabelConflict", message) {
+			r.recordEventResource(ctx, current, labelsv1alpha1.EventActionFailed, protectionResult.ConflictingKeys, message)
+			r.recordEvent(current, corev1.EventTypeWarning, "ProtectedLabelConflict", message)
+		}
+		updateStatus(current, false, "ProtectedLabelConflict", message, reported.ProtectedSkipped, nil)
+		current.Status.ProtectedLabelReasons = protectedLabelReasons(reported)
+		current.Status.AllowedLabels = nil
+		current.Status.LabelSources = nil
+		current.Status.Stats = reconcileStats(current, plan, ns.Labels, nil)
+		requeueAfter := r.scheduleRequeue(current, r.failRequeueInterval(client.ObjectKeyFromObject(current)))
+		if err := r.updateCRStatus(ctx, current); err != nil {
 			l.Error(err, "failed to update status for protection conflict")
 		}
-		return ctrl.Result{RequeueAfter: time.Minute * 5}, fmt.Errorf("protected label conflict: %s", strings.Join(protectionResult.Warnings, "; "))
+		// The conflict is the CR's to resolve and status reports it, so this is not a reconcile error: an error
+		// would make controller-runtime drop RequeueAfter and retry on its own rate limiter instead
+		l.Info("Protected label conflict, requeueing", "namespace", targetNS, "requeueAfter", requeueAfter,
+			"labels", protectionResult.ConflictingKeys)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
 
-	changed := r.applyLabelsToNamespace(ns, protectionResult.AllowedLabels, prevApplied)
+	r.failBackoff.reset(client.ObjectKeyFromObject(current))
 
-	if changed {
-		if err := r.Update(ctx, ns); err != nil {
-			return ctrl.Result{}, err
+	// Dry run only reports the plan; the namespace and applied annotation are left untouched
+	if exists && current.Spec.DryRun {
+		return r.reportDryRun(ctx, current, targetNS, ns, reported, plan.effective, plan.prevApplied, plan.removalProtection)
+	}
+
+	var mutated []string
+	if changed || annotationsChanged || trackingChanged {
+		// Another admission webhook may have rewritten our values; compare against what was actually stored
+		mutated, err = r.findMutatedLabels(ctx, targetNS, plan.effective)
+		if err != nil {
+			l.Error(err, "failed to verify stored label values")
 		}
 	}
 
-	if err := writeAppliedAnnotation(ctx, r.Client, ns, protectionResult.AllowedLabels); err != nil {
-		// Log error but don't fail reconciliation since labels were applied successfully
-		l.Error(err, "failed to write applied annotation")
+	if !exists {
+		forgetAppliedLabels(client.ObjectKeyFromObject(current))
+		return ctrl.Result{}, nil
 	}
 
-	if exists {
-		labelCount := len(desired)
-		appliedCount := len(protectionResult.AllowedLabels)
-		skippedCount := len(protectionResult.ProtectedSkipped)
-
-		var message string
-		if skippedCount > 0 {
-			message = fmt.Sprintf("Applied %d labels to namespace '%s', skipped %d protected labels (%v)",
-				appliedCount, targetNS, skippedCount, protectionResult.ProtectedSkipped)
-		} else {
-			message = fmt.Sprintf("Applied %d labels to namespace '%s'",
-				appliedCount, targetNS)
+	if len(resolved.hashWarnings) > 0 {
+		setCondition(current, ConditionHashLabelsResolved, metav1.ConditionFalse, "ConfigMapNotFound", strings.Join(resolved.hashWarnings, "; "))
+	} else {
+		meta.RemoveStatusCondition(&current.Status.Conditions, ConditionHashLabelsResolved)
+	}
+	if len(resolved.inheritWarnings) > 0 {
+		setCondition(current, ConditionInheritedLabelsResolved, metav1.ConditionFalse, resolved.inheritReason,
+			strings.Join(resolved.inheritWarnings, "; "))
+	} else {
+		meta.RemoveStatusCondition(&current.Status.Conditions, ConditionInheritedLabelsResolved)
+	}
+	if len(templateWarnings) > 0 {
+		setCondition(current, ConditionLabelTemplatesRendered, metav1.ConditionFalse, "TemplateFailed", strings.Join(templateWarnings, "; "))
+	} else {
+		meta.RemoveStatusCondition(&current.Status.Conditions, ConditionLabelTemplatesRendered)
+	}
+	r.checkLabelLimit(current, ns)
+	if len(plan.drifted) > 0 {
+		l.Info("Namespace labels drifted from the applied annotation", "namespace", targetNS, "labels", plan.drifted)
+		current.Status.LastDriftDetected = &metav1.Time{Time: r.now()}
+	}
+	removalProtected := append(slices.Clip(retained), removeRetained...)
+	sort.Strings(removalProtected)
+	current.Status.RemovalProtected = reportedKeys(current, slices.Compact(removalProtected))
+	// removeLabels keys are reported as listed, since keyPrefix never applies to them
+	if len(current.Spec.RemoveLabels) == 0 {
+		current.Status.LabelsRemoved = nil
+	} else if len(removed) > 0 {
+		current.Status.LabelsRemoved = make([]string, 0, len(removed))
+		for _, change := range removed {
+			current.Status.LabelsRemoved = append(current.Status.LabelsRemoved, change.Key)
 		}
+	}
+	if len(mutated) > 0 {
+		l.Info("Label values were mutated after update", "namespace", targetNS, "labels", mutated)
+		setCondition(current, ConditionValueMutatedExternally, metav1.ConditionTrue, "ValueMutatedExternally",
+			fmt.Sprintf("Stored values differ from the requested values for labels: %s", strings.Join(mutated, ", ")))
+	} else {
+		meta.RemoveStatusCondition(&current.Status.Conditions, ConditionValueMutatedExternally)
+	}
 
-		appliedKeys := make([]string, 0, len(protectionResult.AllowedLabels))
-		for k := range protectionResult.AllowedLabels {
-			appliedKeys = append(appliedKeys, k)
+	if changed {
+		appliedKeys := mapKeys(protectionResult.AllowedLabels)
+		r.recordEventResource(ctx, current, labelsv1alpha1.EventActionApplied, appliedKeys,
+			fmt.Sprintf("Applied %d labels to namespace '%s'", len(protectionResult.AllowedLabels), targetNS))
+		r.recordEvent(current, corev1.EventTypeNormal, "LabelsApplied",
+			fmt.Sprintf("Applied %d labels to namespace '%s': %s", len(appliedKeys), targetNS, strings.Join(appliedKeys, ", ")))
+	}
+	if len(stale) > 0 {
+		r.recordEvent(current, corev1.EventTypeNormal, "StaleLabelsRemoved",
+			fmt.Sprintf("Removed %d stale labels from namespace '%s': %s", len(stale), targetNS, strings.Join(reportedKeys(current, stale), ", ")))
+	}
+	if !sameKeys(current.Status.ProtectedLabelsSkipped, reported.ProtectedSkipped) && len(protectionResult.ProtectedSkipped) > 0 {
+		r.recordEventResource(ctx, current, labelsv1alpha1.EventActionSkipped, protectionResult.ProtectedSkipped,
+			fmt.Sprintf("Skipped %d protected labels", len(protectionResult.ProtectedSkipped)))
+		if r.AggregateWarningEvents {
+			skipped := slices.Clone(protectionResult.ProtectedSkipped)
+			sort.Strings(skipped)
+			r.recordEvent(current, corev1.EventTypeWarning, "ProtectedLabelsSkipped",
+				fmt.Sprintf("Skipped %d protected labels: %s", len(skipped), strings.Join(skipped, ", ")))
+		} else {
+			for _, key := range protectionResult.ProtectedSkipped {
+				r.recordEvent(current, corev1.EventTypeWarning, "ProtectedLabelSkipped",
+					fmt.Sprintf("Skipped protected label '%s': namespace has '%s', spec requests '%s'", key, ns.Labels[key], desired[key]))
+			}
 		}
+	}
 
-		l.Info("NamespaceLabel successfully processed",
-			"namespace", current.Namespace, "labelsApplied", appliedCount, "labelsRequested", labelCount, "protectedSkipped", skippedCount)
+	if err := r.recordLastAppliedSpec(ctx, current); err != nil {
+		l.Error(err, "failed to record last applied spec")
+	}
+	if err := r.exportLabels(ctx, current, protectionResult.AllowedLabels); err != nil {
+		l.Error(err, "failed to export applied labels")
+		setCondition(current, ConditionLabelsExported, metav1.ConditionFalse, "ExportFailed", err.Error())
+	} else {
+		meta.RemoveStatusCondition(&current.Status.Conditions, ConditionLabelsExported)
+	}
 
-		updateStatus(&current, true, "Synced", message, protectionResult.ProtectedSkipped, appliedKeys)
-		if err := r.Status().Update(ctx, &current); err != nil {
-			l.Error(err, "failed to update CR status")
-		}
+	r.startLabelTTL(current)
+	requeueAfter := r.scheduleRequeue(current, soonestRequeue(r.cleanupExpiredEventResources(ctx, current), r.resyncInterval(current),
+		r.ttlRequeue(current)))
+	r.updateSuccessStatus(ctx, current, targetNS, reported, changes, reconcileStats(current, plan, ns.Labels, changes))
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// labelPlan is the outcome of evaluating the CR against one read of the target namespace
+type labelPlan struct {
+	// desired is every label the spec asks for, before protection
+	desired     map[string]string
+	prevApplied map[string]string
+	protection  ProtectionResult
+	// protectionPatterns is the default patterns followed by the CR's active ones
+	protectionPatterns []string
+	// effective is what gets written, with list-merge keys unioned with the namespace's items
+	effective map[string]string
+	// tracked is what the applied annotation records as ours
+	tracked           map[string]string
+	removalProtection []protection.Matcher
+	templateWarnings  []string
+	phantoms          []string
+	// drifted is the applied keys changed or removed on the namespace since the last apply
+	drifted []string
+	// disallowed is the spec keys matching none of allowedLabelPatterns
+	disallowed []string
+	// outsideCharset is the keys whose values use characters outside the operator's value charset
+	outsideCharset []string
+	// unmergeable is the list-merge keys held at their namespace value because the merged value is invalid
+	unmergeable []string
+	// sources maps each desired key to where its value came from
+	sources map[string]string
+	// overwriteConflicts is the keys left alone under overwritePolicy ifOwned
+	overwriteConflicts []string
+	// claimed maps the desired keys left to higher-ranked NamespaceLabels to their names
+	claimed map[string]string
+	// preserved is the namespace values kept by protection under preserveProtectedValues
+	preserved map[string]string
+}
+
+// resolvedLabels are the labels read from other objects for one reconcile, with the problems found resolving them
+type resolvedLabels struct {
+	hash            map[string]string
+	hashWarnings    []string
+	inherited       map[string]string
+	inheritReason   string
+	inheritWarnings []string
+	// claims maps the label keys of higher-ranked NamespaceLabels in the namespace to their names
+	claims map[string]string
+}
+
+// planLabels evaluates templates, protection and list merging for the CR against the namespace as read.
+// ns.Labels is initialized if nil so the plan can be applied to it directly. A non-empty protectionStatusLabel
+// is added, unprefixed and exempt from the allowlist, while the CR protects the namespace. Labels whose values
+// fall outside valueCharset are dropped. defaultProtection is protected on top of the CR's own patterns.
+// appliedKey is the annotation tracking what the CR applied before. validator checks the rendered labelsTemplate labels.
+func planLabels(ctx context.Context, current *labelsv1alpha1.NamespaceLabel, ns *corev1.Namespace, resolved resolvedLabels, protectionStatusLabel string,
+	valueCharset webhookv1alpha1.ValueCharset, defaultProtection []string, appliedKey string,
+	validator *webhookv1alpha1.NamespaceLabelCustomValidator) labelPlan {
+	var plan labelPlan
+
+	templated, templateWarnings := renderLabelsTemplate(current, ns, validator)
+	specLabels, valueWarnings := renderLabelTemplates(webhookv1alpha1.SpecLabels(current.Spec), ns)
+	templateWarnings = append(templateWarnings, valueWarnings...)
+	plan.templateWarnings = templateWarnings
+	// Rendered labelsTemplate keys lose to spec labels, and inherited keys are copied as-is and lose to any
+	// label the spec sets under the same key
+	specLabels = mergeLabels(templated, specLabels)
+	plan.desired = mergeLabels(resolved.inherited, prefixKeys(mergeLabels(specLabels, resolved.hash), current.Spec.KeyPrefix))
+	plan.sources = labelSources(current.Spec, templated, resolved.hash, resolved.inherited)
+	// The allowlist drops keys before protection is considered
+	plan.desired, plan.disallowed = filterAllowedLabels(plan.desired, current.Spec.AllowedLabelPatterns)
+	plan.desired, plan.outsideCharset = filterValueCharset(plan.desired, valueCharset)
+	if statusLabels := protectionStatusLabels(protectionStatusLabel, current.Spec, ns.Labels); len(statusLabels) > 0 {
+		plan.desired = mergeLabels(plan.desired, statusLabels)
+		plan.sources[protectionStatusLabel] = labelSourceOperator
+	}
+	plan.prevApplied = readTrackingAnnotation(ns, appliedKey)
+	// Keys a higher-ranked NamespaceLabel sets are its to apply, so they pass to it without being removed
+	plan.claimed = dropClaimedLabels(plan.desired, plan.prevApplied, resolved.claims)
+	listKeys := prefixList(current.Spec.ListMergeKeys, current.Spec.KeyPrefix)
+	plan.drifted = driftedLabels(plan.prevApplied, ns.Labels, listKeys)
+
+	// Forget applied entries whose label is gone so they are re-applied like any new label
+	plan.phantoms = dropPhantomEntries(plan.prevApplied, ns.Labels)
+
+	// Under ifOwned, labels someone else put on the namespace are left alone rather than overwritten
+	if current.Spec.OverwritePolicy == labelsv1alpha1.OverwritePolicyIfOwned {
+		plan.desired, plan.overwriteConflicts = filterUnownedLabels(plan.desired, ns.Labels, plan.prevApplied)
 	}
 
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+
+	// The namespace's tier label may widen the protected set
+	plan.protectionPatterns = withDefaultProtection(defaultProtection, current.Spec, ns.Labels)
+	_, span := startSpan(ctx, "applyProtectionLogic")
+	plan.protection = applyProtectionLogic(
+		plan.desired,
+		ns.Labels,
+		plan.protectionPatterns,
+		current.Spec.ProtectedLabelRules,
+		current.Spec.ProtectionMode,
+		current.Spec.ProtectCreation,
+		current.Spec.ProtectedValuePatterns,
+	)
+	// Default patterns and system namespaces keep their protection however the CR configures its own
+	enforceDefaultProtection(&plan.protection, defaultProtection, ns.Labels, current.Spec)
+	enforceSystemNamespaceProtection(&plan.protection, ns.Name, plan.desired, ns.Labels)
+	span.End()
+	plan.protection.Warnings = append(plan.protection.Warnings, resolved.hashWarnings...)
+	plan.protection.Warnings = append(plan.protection.Warnings, resolved.inheritWarnings...)
+	plan.protection.Warnings = append(plan.protection.Warnings, templateWarnings...)
+	if current.Spec.PreserveProtectedValues {
+		plan.preserved = preserveProtectedValues(&plan.protection, ns.Labels, current.Status.PreservedLabels)
+	}
+
+	plan.removalProtection = protection.CompilePatterns(current.Spec.RemovalProtectionPatterns)
+
+	// List-merge keys union our items with the ones already on the namespace instead of overwriting
+	plan.effective, plan.tracked, plan.unmergeable = mergeListLabels(ns.Labels, plan.protection.AllowedLabels, plan.prevApplied, listKeys)
+	return plan
+}
+
+// reportDryRun records in status what a real reconcile would change on the namespace
+func (r *NamespaceLabelReconciler) reportDryRun(ctx context.Context, current *labelsv1alpha1.NamespaceLabel, targetNS string,
+	ns *corev1.Namespace, protectionResult ProtectionResult, effective, prevApplied map[string]string, removalProtection []protection.Matcher) (ctrl.Result, error) {
+	l := log.FromContext(ctx)
+
+	wouldApply, wouldRemove := planLabelChanges(ns.Labels, effective, prevApplied, current.Spec.RemoveLabels, removalProtection,
+		r.removalGuard(current.Spec, ns), pruneStaleLabels(current))
+	message := fmt.Sprintf("Dry run: would apply %d labels and remove %d labels on namespace '%s'", len(wouldApply), len(wouldRemove), targetNS)
+	l.Info("NamespaceLabel dry run", "namespace", targetNS, "wouldApply", wouldApply, "wouldRemove", wouldRemove)
+
+	updateStatus(current, false, "DryRun", message, protectionResult.ProtectedSkipped, nil)
+	current.Status.ProtectedLabelReasons = protectedLabelReasons(protectionResult)
+	current.Status.AllowedLabels = protectionResult.AllowedLabels
+	current.Status.WouldApply = reportedKeys(current, wouldApply)
+	current.Status.WouldRemove = reportedKeys(current, wouldRemove)
+	current.Status.PendingChanges = pendingChanges(readLastAppliedSpec(current, r.lastAppliedSpecKey()), webhookv1alpha1.SpecLabels(current.Spec))
+	if err := r.updateCRStatus(ctx, current); err != nil {
+		l.Error(err, "failed to update status for dry run")
+	}
 	return ctrl.Result{}, nil
 }
 
+// updateSuccessStatus reports a successful apply in the CR status.
+// LastAppliedTime and LabelChanges only move when the reconcile actually changed namespace labels.
+func (r *NamespaceLabelReconciler) updateSuccessStatus(ctx context.Context, current *labelsv1alpha1.NamespaceLabel, targetNS string,
+	protectionResult ProtectionResult, changes []labelsv1alpha1.LabelChange, stats *labelsv1alpha1.ReconcileStats) {
+	l := log.FromContext(ctx)
+
+	labelCount := len(webhookv1alpha1.SpecLabels(current.Spec)) + len(current.Spec.HashLabels)
+	appliedCount := len(protectionResult.AllowedLabels)
+	skippedCount := len(protectionResult.ProtectedSkipped)
+
+	var message string
+	if skippedCount > 0 {
+		message = fmt.Sprintf("Applied %d labels to namespace '%s', skipped %d protected labels (%s)",
+			appliedCount, targetNS, skippedCount, summarizeKeys(protectionResult.ProtectedSkipped))
+	} else {
+		message = fmt.Sprintf("Applied %d labels to namespace '%s'",
+			appliedCount, targetNS)
+	}
+
+	appliedKeys := make([]string, 0, len(protectionResult.AllowedLabels))
+	for k := range protectionResult.AllowedLabels {
+		appliedKeys = append(appliedKeys, k)
+	}
+
+	if len(protectionResult.Warnings) > 0 {
+		l.Info("NamespaceLabel processed with warnings", "namespace", current.Namespace, "warnings", protectionResult.Warnings)
+	}
+
+	l.Info("NamespaceLabel successfully processed",
+		"namespace", current.Namespace, "labelsApplied", appliedCount, "labelsRequested", labelCount, "protectedSkipped", skippedCount)
+
+	updateStatus(current, true, "Synced", message, protectionResult.ProtectedSkipped, appliedKeys)
+	current.Status.ProtectedLabelReasons = protectedLabelReasons(protectionResult)
+	setAppliedLabels(client.ObjectKeyFromObject(current), map[string]int{targetNS: appliedCount})
+	managedLabelsPerNamespace.Observe(float64(appliedCount))
+	current.Status.AllowedLabels = protectionResult.AllowedLabels
+	current.Status.WouldApply = nil
+	current.Status.WouldRemove = nil
+	current.Status.PendingChanges = nil
+	current.Status.Stats = stats
+	if len(changes) > 0 {
+		current.Status.LastAppliedTime = &metav1.Time{Time: r.now()}
+		current.Status.LabelChanges = labelChangesStatus(current, changes)
+	}
+	if err := r.updateCRStatus(ctx, current); err != nil {
+		l.Error(err, "failed to update CR status")
+	}
+}
+
 // finalize cleans up namespace labels and removes the finalizer
 func (r *NamespaceLabelReconciler) finalize(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel) (ctrl.Result, error) {
+	ctx, span := startSpan(ctx, "finalize")
+	defer span.End()
 	l := log.FromContext(ctx)
 
+	// A slot the CR still holds in the write budget would never be claimed once it is gone
+	r.WriteBudget.release(client.ObjectKeyFromObject(cr))
+
+	// The selector NamespaceLabel releases every namespace it labeled
+	if r.SelectorNamespace != "" && cr.Namespace == r.SelectorNamespace {
+		if _, err := r.syncSelectedNamespaces(ctx, cr, labels.Nothing()); err != nil {
+			l.Error(err, "failed to remove labels from selected namespaces")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+	}
+
+	// The owner reference would also have the exported ConfigMap garbage collected, but not before the CR is gone
+	if err := r.deleteExportedConfigMaps(ctx, cr, ""); err != nil {
+		l.Error(err, "failed to delete exported ConfigMap")
+	}
+
 	ns, err := r.getTargetNamespace(ctx, cr.Namespace)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// Namespace is gone - just remove finalizer
-			controllerutil.RemoveFinalizer(cr, FinalizerName)
+			forgetAppliedLabels(client.ObjectKeyFromObject(cr))
+			removeFinalizer(cr)
 			return ctrl.Result{}, r.Update(ctx, cr)
 		}
 		return ctrl.Result{}, err
 	}
 
-	prevApplied := readAppliedAnnotation(ns)
-	changed := r.applyLabelsToNamespace(ns, map[string]string{}, prevApplied)
-	if changed {
-		if err := r.Update(ctx, ns); err != nil {
-			l.Error(err, "failed to remove applied labels")
-			return ctrl.Result{RequeueAfter: time.Minute}, nil
+	// An opted-out namespace is left exactly as it is, including labels applied before it opted out
+	if isOptedOut(ns) {
+		l.Info("Namespace is opted out of management, leaving labels in place", "namespace", cr.Namespace)
+		forgetAppliedLabels(client.ObjectKeyFromObject(cr))
+		removeFinalizer(cr)
+		return ctrl.Result{}, r.Update(ctx, cr)
+	}
+
+	// A terminating namespace takes its labels with it, so there is nothing to clean up and the finalizer is
+	// released at once. Its applied annotation is left for the StateArchiver to archive when it is deleted.
+	if isNamespaceTerminating(ns) {
+		l.Info("Namespace is being deleted, leaving labels in place", "namespace", cr.Namespace)
+		forgetAppliedLabels(client.ObjectKeyFromObject(cr))
+		removeFinalizer(cr)
+		return ctrl.Result{}, r.Update(ctx, cr)
+	}
+
+	// Every removal, including clearing the applied annotation, goes out as one patch that nulls exactly
+	// the managed keys, so labels and annotations written by others are never part of the request
+	base := ns.DeepCopy()
+	appliedKey := r.trackingKey(appliedAnnoKey, cr.Name)
+	annotationsKey := r.trackingKey(appliedAnnotationsAnnoKey, cr.Name)
+	prevApplied := readTrackingAnnotation(ns, appliedKey)
+	// List-merge keys keep the items other writers added
+	remaining, _, _ := mergeListLabels(ns.Labels, nil, prevApplied, cr.Spec.ListMergeKeys)
+	// Mandatory labels are kept like removal-protected ones unless the policy only warns about removing them
+	removalProtection := protection.CompilePatterns(cr.Spec.RemovalProtectionPatterns)
+	mandatory := protection.CompilePatterns(r.MandatoryLabelPatterns)
+	if r.MandatoryLabelPolicy != MandatoryLabelPolicyWarn {
+		removalProtection = append(removalProtection, mandatory...)
+	}
+	changes, retained := r.applyLabelsToNamespace(ns, remaining, prevApplied, removalProtection, true)
+	if len(retained) > 0 {
+		l.Info("Leaving protected labels on namespace unmanaged", "namespace", cr.Namespace, "labels", retained)
+	}
+	changed := r.applyAnnotationsToNamespace(ns, map[string]string{}, readTrackingAnnotation(ns, annotationsKey)) || len(changes) > 0
+	trackingChanged, err := setTrackingAnnotation(ns, annotationsKey, nil, nil, r.OrderedAppliedAnnotation)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if appliedKey == appliedAnnoKey {
+		changed = setOwnerUIDAnnotation(ns, "") || changed
+		cleared, err := marshalApplied(map[string]string{}, nil, r.OrderedAppliedAnnotation)
+		if err != nil {
+			return ctrl.Result{}, err
 		}
+		if cur, ok := ns.Annotations[appliedAnnoKey]; !ok || cur != string(cleared) {
+			if ns.Annotations == nil {
+				ns.Annotations = map[string]string{}
+			}
+			ns.Annotations[appliedAnnoKey] = string(cleared)
+			trackingChanged = true
+		}
+	} else if _, ok := ns.Annotations[appliedKey]; ok {
+		delete(ns.Annotations, appliedKey)
+		trackingChanged = true
 	}
 
-	if err := writeAppliedAnnotation(ctx, r.Client, ns, map[string]string{}); err != nil {
-		l.Error(err, "failed to clear applied annotation")
-		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	if changed || trackingChanged {
+		if err := r.patchNamespace(ctx, ns, base); err != nil {
+			l.Error(err, "failed to remove applied labels and annotations")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+	}
+	if stranded := removedKeysMatching(changes, mandatory); len(stranded) > 0 {
+		message := fmt.Sprintf("Removed mandatory labels from namespace '%s': %s", cr.Namespace, strings.Join(stranded, ", "))
+		l.Info("Removed mandatory labels on deletion", "namespace", cr.Namespace, "labels", stranded)
+		r.recordEvent(cr, corev1.EventTypeWarning, "MandatoryLabelsRemoved", message)
 	}
 
-	controllerutil.RemoveFinalizer(cr, FinalizerName)
+	forgetAppliedLabels(client.ObjectKeyFromObject(cr))
+	removeFinalizer(cr)
 	return ctrl.Result{}, r.Update(ctx, cr)
 }
 
+// isNamespaceOptedOut reports whether the named namespace carries IgnoreAnnoKey. A missing namespace is not
+// opted out, so the regular path can report it.
+func (r *NamespaceLabelReconciler) isNamespaceOptedOut(ctx context.Context, name string) (bool, error) {
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, &ns); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return isOptedOut(&ns), nil
+}
+
+// reportOptedOut reports in status that the CR's namespace is opted out and leaves it untouched
+func (r *NamespaceLabelReconciler) reportOptedOut(ctx context.Context, current *labelsv1alpha1.NamespaceLabel) (ctrl.Result, error) {
+	l := log.FromContext(ctx)
+	l.Info("Namespace is opted out of management, skipping", "namespace", current.Namespace)
+	message := fmt.Sprintf("Namespace '%s' is opted out of label management by the '%s' annotation", current.Namespace, IgnoreAnnoKey)
+	setCondition(current, ConditionNamespaceOptedOut, metav1.ConditionTrue, "NamespaceOptedOut", message)
+	updateStatus(current, false, "NamespaceOptedOut", message, nil, nil)
+	current.Status.AllowedLabels = nil
+	if err := r.updateCRStatus(ctx, current); err != nil {
+		l.Error(err, "failed to update status for opted out namespace")
+	}
+	return ctrl.Result{}, nil
+}
+
+// isNamespaceTerminating reports whether the namespace is being deleted
+func isNamespaceTerminating(ns *corev1.Namespace) bool {
+	return !ns.DeletionTimestamp.IsZero() || ns.Status.Phase == corev1.NamespaceTerminating
+}
+
+// isOptedOut reports whether the namespace opted out of management with IgnoreAnnoKey
+func isOptedOut(ns *corev1.Namespace) bool {
+	return ns.Annotations[IgnoreAnnoKey] == "true"
+}
+
 // getTargetNamespace retrieves the namespace that should be modified
-func (r *NamespaceLabelReconciler) getTargetNamespace(ctx context.Context, targetNS string) (*corev1.Namespace, error) {
+func (r *NamespaceLabelReconciler) getTargetNamespace(ctx context.Context, targetNS string) (_ *corev1.Namespace, err error) {
+	ctx, span := startSpan(ctx, "getTargetNamespace")
+	defer func() { endSpan(span, err) }()
+
 	if targetNS == "" {
 		return nil, fmt.Errorf("empty namespace name")
 	}
@@ -180,13 +948,78 @@ func (r *NamespaceLabelReconciler) getTargetNamespace(ctx context.Context, targe
 	return &ns, nil
 }
 
-// applyLabelsToNamespace applies desired labels and removes stale ones
-func (r *NamespaceLabelReconciler) applyLabelsToNamespace(ns *corev1.Namespace, desired, prevApplied map[string]string) bool {
+// patchNamespace writes the label and annotation changes made to ns since base as a strategic merge patch,
+// so the request carries only the changed keys. The patch keeps the resourceVersion of base, and a conflict is
+// returned for the caller to re-read and re-plan; any other patch failure falls back to a full update.
+func (r *NamespaceLabelReconciler) patchNamespace(ctx context.Context, ns, base *corev1.Namespace) (err error) {
+	ctx, span := startSpan(ctx, "patchNamespace")
+	defer func() { endSpan(span, err) }()
+
+	patched := ns.DeepCopy()
+	err = r.Patch(ctx, patched, client.StrategicMergeFrom(base, client.MergeFromWithOptimisticLock{}))
+	if err == nil || apierrors.IsConflict(err) || apierrors.IsNotFound(err) {
+		if err == nil {
+			*ns = *patched
+		}
+		return err
+	}
+	log.FromContext(ctx).Info("Namespace patch failed, falling back to update", "namespace", ns.Name, "error", err.Error())
+	return r.Update(ctx, ns)
+}
+
+// findMutatedLabels re-reads the namespace and returns the sorted keys whose stored value differs from the intended one
+func (r *NamespaceLabelReconciler) findMutatedLabels(ctx context.Context, targetNS string, intended map[string]string) ([]string, error) {
+	stored, err := r.getTargetNamespace(ctx, targetNS)
+	if err != nil {
+		return nil, err
+	}
+
+	var mutated []string
+	for key, want := range intended {
+		if got, ok := stored.Labels[key]; !ok || got != want {
+			mutated = append(mutated, key)
+		}
+	}
+	sort.Strings(mutated)
+	return mutated, nil
+}
+
+// applyAnnotationsToNamespace applies desired annotations and removes stale ones.
+// The operator's own tracking annotations are never touched here.
+func (r *NamespaceLabelReconciler) applyAnnotationsToNamespace(ns *corev1.Namespace, desired, prevApplied map[string]string) bool {
+	if ns.Annotations == nil {
+		ns.Annotations = make(map[string]string)
+	}
+
+	wanted := make(map[string]string, len(desired))
+	for k, v := range desired {
+		// Covers the per-CR tracking keys of MultipleNamespaceLabels too
+		if strings.HasPrefix(k, appliedAnnoKey) {
+			continue
+		}
+		wanted[k] = v
+	}
+
+	removed, _ := removeStaleLabels(ns.Annotations, wanted, prevApplied, nil)
+	applied := applyDesiredLabels(ns.Annotations, wanted)
+	return len(removed) > 0 || len(applied) > 0
+}
+
+// applyLabelsToNamespace applies desired labels and, when prune is set, removes stale ones, except those under
+// removal protection.
+// It returns the label changes sorted by key and the stale keys that were retained.
+func (r *NamespaceLabelReconciler) applyLabelsToNamespace(ns *corev1.Namespace, desired, prevApplied map[string]string,
+	removalProtection []protection.Matcher, prune bool) ([]labelsv1alpha1.LabelChange, []string) {
 	if ns.Labels == nil {
 		ns.Labels = make(map[string]string)
 	}
 
-	changed := removeStaleLabels(ns.Labels, desired, prevApplied)
-	changed = applyDesiredLabels(ns.Labels, desired) || changed
-	return changed
+	var changes []labelsv1alpha1.LabelChange
+	var retained []string
+	if prune {
+		changes, retained = removeStaleLabels(ns.Labels, desired, prevApplied, removalProtection)
+	}
+	changes = append(changes, applyDesiredLabels(ns.Labels, desired)...)
+	sortLabelChanges(changes)
+	return changes, retained
 }
@@ -18,26 +18,38 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
 
 // Tests for functions in namespacelabel_controller.go
 
 var _ = Describe("NamespaceLabelReconciler", Label("controller"), func() {
 	var (
+		f          *testFixture
 		reconciler *NamespaceLabelReconciler
 		fakeClient client.Client
 		scheme     *runtime.Scheme
@@ -45,54 +57,10 @@ var _ = Describe("NamespaceLabelReconciler", Label("controller"), func() {
 	)
 
 	BeforeEach(func() {
-		scheme = runtime.NewScheme()
-		Expect(labelsv1alpha1.AddToScheme(scheme)).To(Succeed())
-		Expect(corev1.AddToScheme(scheme)).To(Succeed())
-
-		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
-		reconciler = &NamespaceLabelReconciler{
-			Client: fakeClient,
-			Scheme: scheme,
-		}
-		ctx = context.TODO()
+		f = newTestFixture()
+		reconciler, fakeClient, scheme, ctx = f.reconciler, f.client, f.scheme, f.ctx
 	})
 
-	// Helper functions to reduce code duplication
-	createNamespace := func(name string, labels map[string]string, annotations map[string]string) *corev1.Namespace {
-		ns := &corev1.Namespace{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        name,
-				Labels:      labels,
-				Annotations: annotations,
-			},
-		}
-		Expect(fakeClient.Create(ctx, ns)).To(Succeed())
-		return ns
-	}
-
-	createCR := func(name, namespace string, labels map[string]string, finalizers []string, spec labelsv1alpha1.NamespaceLabelSpec) *labelsv1alpha1.NamespaceLabel {
-		cr := &labelsv1alpha1.NamespaceLabel{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:       name,
-				Namespace:  namespace,
-				Labels:     labels,
-				Finalizers: finalizers,
-			},
-			Spec: spec,
-		}
-		Expect(fakeClient.Create(ctx, cr)).To(Succeed())
-		return cr
-	}
-
-	reconcileRequest := func(name, namespace string) reconcile.Request {
-		return reconcile.Request{
-			NamespacedName: types.NamespacedName{
-				Name:      name,
-				Namespace: namespace,
-			},
-		}
-	}
-
 	expectFinalizerRemoved := func(cr *labelsv1alpha1.NamespaceLabel) {
 		var updatedCR labelsv1alpha1.NamespaceLabel
 		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
@@ -101,7 +69,7 @@ var _ = Describe("NamespaceLabelReconciler", Label("controller"), func() {
 
 	Describe("Reconcile", func() {
 		It("should handle non-existent CR gracefully", func() {
-			createNamespace("test-ns", nil, nil)
+			f.createNamespace("test-ns", nil, nil)
 
 			result, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
 
@@ -110,8 +78,8 @@ var _ = Describe("NamespaceLabelReconciler", Label("controller"), func() {
 		})
 
 		It("should add finalizer to CR without finalizer", func() {
-			createNamespace("test-ns", nil, nil)
-			cr := createCR("labels", "test-ns", nil, nil, labelsv1alpha1.NamespaceLabelSpec{
+			f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, nil, labelsv1alpha1.NamespaceLabelSpec{
 				Labels: map[string]string{"app": "test"},
 			})
 
@@ -126,9 +94,26 @@ var _ = Describe("NamespaceLabelReconciler", Label("controller"), func() {
 			Expect(updatedCR.Finalizers).To(ContainElement(FinalizerName))
 		})
 
+		It("should normalize duplicate finalizers", func() {
+			f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName, FinalizerName, "labels.shahaf.com/Finalizer"},
+				labelsv1alpha1.NamespaceLabelSpec{
+					Labels: map[string]string{"app": "test"},
+				})
+
+			result, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Finalizers).To(Equal([]string{FinalizerName}))
+		})
+
 		It("should apply labels to namespace successfully", func() {
-			ns := createNamespace("test-ns", nil, nil)
-			createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+			ns := f.createNamespace("test-ns", nil, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
 				Labels: map[string]string{
 					"app": "test",
 					"env": "prod",
@@ -148,11 +133,70 @@ var _ = Describe("NamespaceLabelReconciler", Label("controller"), func() {
 			Expect(updatedNS.Annotations).To(HaveKey(appliedAnnoKey))
 		})
 
+		It("should drop labels with empty keys without failing reconciliation", func() {
+			ns := f.createNamespace("test-ns", nil, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{
+					"":    "orphan",
+					"app": "test",
+				},
+			})
+
+			result, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("app", "test"))
+			Expect(updatedNS.Labels).NotTo(HaveKey(""))
+			Expect(readAppliedAnnotation(&updatedNS)).NotTo(HaveKey(""))
+
+			By("reporting the dropped key in status")
+			cr := f.getCR("labels", "test-ns")
+			Expect(cr.Status.Applied).To(BeTrue())
+			cond := meta.FindStatusCondition(cr.Status.Conditions, ConditionLabelKeysValid)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("EmptyKeyDropped"))
+			Expect(cond.Message).To(Equal("1 labels with an empty key are not applied"))
+
+			By("clearing the condition once the empty key is gone")
+			delete(cr.Spec.Labels, "")
+			Expect(fakeClient.Update(ctx, cr)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(meta.FindStatusCondition(f.getCR("labels", "test-ns").Status.Conditions, ConditionLabelKeysValid)).To(BeNil())
+		})
+
+		It("should report the allowed labels snapshot after protection", func() {
+			existing := map[string]string{"kubernetes.io/managed-by": "existing-operator"}
+			f.createNamespace("test-ns", existing, nil)
+			spec := labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{
+					"app":                      "test",
+					"kubernetes.io/managed-by": "my-operator",
+				},
+				ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+			}
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, spec)
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := applyProtectionLogic(spec.Labels, existing, spec.ProtectedLabelPatterns, spec.ProtectedLabelRules, spec.ProtectionMode, spec.ProtectCreation, spec.ProtectedValuePatterns)
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.AllowedLabels).To(Equal(expected.AllowedLabels))
+			Expect(updatedCR.Status.AllowedLabels).To(Equal(map[string]string{"app": "test"}))
+		})
+
 		It("should handle label protection in fail mode", func() {
-			ns := createNamespace("test-ns", map[string]string{
+			ns := f.createNamespace("test-ns", map[string]string{
 				"kubernetes.io/managed-by": "existing-operator",
 			}, nil)
-			createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
 				Labels: map[string]string{
 					"app":                      "test",
 					"kubernetes.io/managed-by": "my-operator", // This should be protected
@@ -163,22 +207,64 @@ var _ = Describe("NamespaceLabelReconciler", Label("controller"), func() {
 
 			result, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
 
-			Expect(err).To(HaveOccurred())
-			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
 
 			// Verify protected label was not changed
 			var updatedNS corev1.Namespace
 			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
 			Expect(updatedNS.Labels).To(HaveKeyWithValue("kubernetes.io/managed-by", "existing-operator"))
+
+			var cr labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, reconcileRequest("labels", "test-ns").NamespacedName, &cr)).To(Succeed())
+			Expect(cr.Status.ObservedGeneration).To(Equal(cr.Generation))
+
+			By("configuring a shorter fail requeue interval, doubled for the second consecutive conflict")
+			reconciler.FailRequeueInterval = 30 * time.Second
+			result, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
+		})
+
+		It("should back off fail-mode requeues until the conflict clears", func() {
+			reconciler.FailRequeueInterval = time.Minute
+			reconciler.MaxFailRequeueInterval = 5 * time.Minute
+			f.createNamespace("test-ns", map[string]string{"kubernetes.io/managed-by": "existing-operator"}, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"kubernetes.io/managed-by": "my-operator"},
+				ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+			})
+
+			for _, expected := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute} {
+				result, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(expected))
+			}
+
+			By("resetting once the conflict clears")
+			var cr labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, reconcileRequest("labels", "test-ns").NamespacedName, &cr)).To(Succeed())
+			cr.Spec.ProtectionMode = labelsv1alpha1.ProtectionModeSkip
+			Expect(fakeClient.Update(ctx, &cr)).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, reconcileRequest("labels", "test-ns").NamespacedName, &cr)).To(Succeed())
+			cr.Spec.ProtectionMode = labelsv1alpha1.ProtectionModeFail
+			Expect(fakeClient.Update(ctx, &cr)).To(Succeed())
+			result, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(time.Minute))
 		})
 
 		It("should handle label updates when spec changes", func() {
-			ns := createNamespace("test-ns", map[string]string{
+			ns := f.createNamespace("test-ns", map[string]string{
 				"old-label": "old-value",
 			}, map[string]string{
 				appliedAnnoKey: `{"old-label":"old-value"}`,
 			})
-			createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
 				Labels: map[string]string{
 					"new-label": "new-value", // Changed from old-label to new-label
 				},
@@ -200,81 +286,2115 @@ var _ = Describe("NamespaceLabelReconciler", Label("controller"), func() {
 			Expect(appliedLabels).To(HaveKeyWithValue("new-label", "new-value"))
 			Expect(appliedLabels).NotTo(HaveKey("old-label"))
 		})
+
+		It("should record the observed generation", func() {
+			f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"app": "test"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			Expect(cr.Status.ObservedGeneration).To(Equal(cr.Generation))
+
+			By("editing the spec")
+			cr.Generation++
+			cr.Spec.Labels["app"] = "updated"
+			Expect(fakeClient.Update(ctx, cr)).To(Succeed())
+			Expect(cr.Status.ObservedGeneration).To(BeNumerically("<", cr.Generation))
+
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			Expect(cr.Status.ObservedGeneration).To(Equal(cr.Generation))
+		})
+
+		It("should only move lastAppliedTime when labels change", func() {
+			start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+			fakeClock := clocktesting.NewFakeClock(start)
+			reconciler.Clock = fakeClock
+			f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"app": "test"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			Expect(cr.Status.LastAppliedTime).NotTo(BeNil())
+			Expect(cr.Status.LastAppliedTime.Time).To(BeTemporally("==", start))
+
+			By("reconciling without changes")
+			fakeClock.Step(time.Minute)
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			Expect(cr.Status.LastAppliedTime.Time).To(BeTemporally("==", start))
+
+			By("changing the spec")
+			cr.Spec.Labels["app"] = "updated"
+			Expect(fakeClient.Update(ctx, cr)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			Expect(cr.Status.LastAppliedTime.Time).To(BeTemporally("==", start.Add(time.Minute)))
+		})
 	})
 
-	Describe("finalize", func() {
-		// Test data for table-driven approach
-		DescribeTable("should handle different deletion scenarios",
-			func(setupNamespace func() *corev1.Namespace, crNamespace string, shouldFindNS bool, expectedLabelsAfter map[string]string) {
-				// Setup namespace if provided
-				var ns *corev1.Namespace
-				if setupNamespace != nil {
-					ns = setupNamespace()
-				}
+	Describe("annotations", func() {
+		It("should apply, update and clean up namespace annotations", func() {
+			ns := f.createNamespace("test-ns", nil, map[string]string{"unmanaged": "keep"})
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Annotations: map[string]string{
+					"cost-center": "1234",
+					"contact":     "team@example.com",
+				},
+			})
 
-				// Create CR with finalizer
-				cr := createCR("test-cr", crNamespace, nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{})
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
 
-				// Call finalize
-				result, err := reconciler.finalize(ctx, cr)
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Annotations).To(HaveKeyWithValue("cost-center", "1234"))
+			Expect(updatedNS.Annotations).To(HaveKeyWithValue("contact", "team@example.com"))
+			Expect(readAppliedAnnotationsTracking(&updatedNS)).To(Equal(map[string]string{
+				"cost-center": "1234",
+				"contact":     "team@example.com",
+			}))
 
-				// Should always succeed and not requeue
+			By("removing an annotation from the spec")
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			cr.Spec.Annotations = map[string]string{"cost-center": "5678"}
+			Expect(fakeClient.Update(ctx, cr)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Annotations).To(HaveKeyWithValue("cost-center", "5678"))
+			Expect(updatedNS.Annotations).NotTo(HaveKey("contact"))
+			Expect(updatedNS.Annotations).To(HaveKeyWithValue("unmanaged", "keep"))
+
+			By("finalizing the CR")
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			_, err = reconciler.finalize(ctx, cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Annotations).NotTo(HaveKey("cost-center"))
+			Expect(updatedNS.Annotations).NotTo(HaveKey(appliedAnnotationsAnnoKey))
+			Expect(updatedNS.Annotations).To(HaveKeyWithValue("unmanaged", "keep"))
+		})
+
+		It("should never overwrite the operator's tracking annotations", func() {
+			ns := f.createNamespace("test-ns", nil, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:      map[string]string{"env": "prod"},
+				Annotations: map[string]string{appliedAnnoKey: "bogus"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(readAppliedAnnotation(&updatedNS)).To(Equal(map[string]string{"env": "prod"}))
+		})
+	})
+
+	Describe("externally mutated values", func() {
+		It("should report label values rewritten by another admission webhook", func() {
+			// Simulate a mutating webhook that truncates the "team" label on every namespace write
+			truncating := true
+			fakeClient = newClientBuilder(scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if err := c.Patch(ctx, obj, patch, opts...); err != nil {
+							return err
+						}
+						ns, ok := obj.(*corev1.Namespace)
+						if !ok || !truncating || len(ns.Labels["team"]) <= 4 {
+							return nil
+						}
+						ns.Labels["team"] = ns.Labels["team"][:4]
+						return c.Update(ctx, ns)
+					},
+				}).
+				Build()
+			f.setClient(fakeClient)
+
+			f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"team": "platform", "env": "prod"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			cond := meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionValueMutatedExternally)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Message).To(ContainSubstring("team"))
+			Expect(cond.Message).NotTo(ContainSubstring("env"))
+
+			By("reconciling once the webhook stops mutating")
+			truncating = false
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionValueMutatedExternally)).To(BeNil())
+		})
+	})
+
+	Describe("drift correction", func() {
+		It("should map a namespace to its NamespaceLabel only when one exists", func() {
+			ns := f.createNamespace("test-ns", nil, nil)
+			Expect(reconciler.mapNamespaceToRequests(ctx, ns)).To(BeEmpty())
+
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{})
+			Expect(reconciler.mapNamespaceToRequests(ctx, ns)).To(ConsistOf(reconcileRequest("labels", "test-ns")))
+		})
+
+		It("should re-apply a label removed out-of-band", func() {
+			ns := f.createNamespace("test-ns", nil, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod"},
+			})
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("deleting the label directly on the namespace")
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			delete(updatedNS.Labels, "env")
+			Expect(fakeClient.Update(ctx, &updatedNS)).To(Succeed())
+
+			for _, req := range reconciler.mapNamespaceToRequests(ctx, &updatedNS) {
+				_, err := reconciler.Reconcile(ctx, req)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(result.Requeue).To(BeFalse())
-				Expect(result.RequeueAfter).To(BeZero())
+			}
 
-				// Verify finalizer is removed
-				expectFinalizerRemoved(cr)
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("env", "prod"))
+		})
 
-				// Verify namespace state if it should exist
-				if shouldFindNS && ns != nil {
-					var updatedNS corev1.Namespace
-					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+		It("should re-apply labels on a namespace recreated with a stale applied annotation", func() {
+			ns := f.createNamespace("test-ns", map[string]string{"owner": "platform"}, map[string]string{
+				appliedAnnoKey: `{"env":"prod","legacy":"yes"}`,
+			})
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod"},
+			})
 
-					// Check expected labels
-					for k, v := range expectedLabelsAfter {
-						Expect(updatedNS.Labels).To(HaveKeyWithValue(k, v))
-					}
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
 
-					// Applied annotation should be cleared
-					Expect(updatedNS.Annotations).To(HaveKeyWithValue(appliedAnnoKey, "{}"))
-				}
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"owner": "platform", "env": "prod"}))
+			Expect(readAppliedAnnotation(&updatedNS)).To(Equal(map[string]string{"env": "prod"}))
+		})
+
+		It("should record when drift was last detected", func() {
+			start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+			fakeClock := clocktesting.NewFakeClock(start)
+			reconciler.Clock = fakeClock
+			ns := f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod", "team": "platform"},
+			})
+			lastDrift := func() *metav1.Time {
+				var updatedCR labelsv1alpha1.NamespaceLabel
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+				return updatedCR.Status.LastDriftDetected
+			}
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			fakeClock.Step(time.Minute)
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastDrift()).To(BeNil())
+
+			By("changing a label directly on the namespace")
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			updatedNS.Labels["env"] = "dev"
+			Expect(fakeClient.Update(ctx, &updatedNS)).To(Succeed())
+
+			fakeClock.Step(time.Minute)
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastDrift()).NotTo(BeNil())
+			Expect(lastDrift().Time).To(BeTemporally("==", start.Add(2*time.Minute)))
+
+			By("reconciling again without drift")
+			fakeClock.Step(time.Minute)
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastDrift().Time).To(BeTemporally("==", start.Add(2*time.Minute)))
+		})
+	})
+
+	Describe("label integrity", func() {
+		DescribeTable("should report whether applied labels survived since the last reconcile",
+			func(tamper func(labels map[string]string), integrityOK bool) {
+				f.createNamespace("test-ns", map[string]string{"env": "prod", "team": "platform", "other": "x"}, map[string]string{
+					appliedAnnoKey: `{"env":"prod","team":"platform"}`,
+				})
+				cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+					Labels: map[string]string{"env": "prod", "team": "platform"},
+				})
+
+				var ns corev1.Namespace
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &ns)).To(Succeed())
+				tamper(ns.Labels)
+				Expect(fakeClient.Update(ctx, &ns)).To(Succeed())
+
+				_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+				Expect(err).NotTo(HaveOccurred())
+
+				var updatedCR labelsv1alpha1.NamespaceLabel
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+				Expect(updatedCR.Status.IntegrityOK).To(Equal(integrityOK))
+
+				By("restoring integrity once the labels are re-applied")
+				_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+				Expect(updatedCR.Status.IntegrityOK).To(BeTrue())
 			},
-			Entry("namespace not found", nil, "nonexistent-ns", false, nil),
-			Entry("namespace with no applied labels",
-				func() *corev1.Namespace {
-					return createNamespace("test-ns", map[string]string{"existing": "label"}, nil)
-				}, "test-ns", true, map[string]string{"existing": "label"}),
-			Entry("namespace with applied labels to remove",
-				func() *corev1.Namespace {
-					return createNamespace("test-ns",
-						map[string]string{
-							"applied-by-operator": "value1",
-							"another-applied":     "value2",
-							"existing":            "keep-me",
-						},
-						map[string]string{
-							appliedAnnoKey: `{"applied-by-operator":"value1","another-applied":"value2"}`,
-						})
-				}, "test-ns", true, map[string]string{"existing": "keep-me"}),
+			Entry("intact labels", func(labels map[string]string) {}, true),
+			Entry("an unrelated label changed", func(labels map[string]string) { labels["other"] = "y" }, true),
+			Entry("a value changed", func(labels map[string]string) { labels["env"] = "dev" }, false),
+			Entry("a label removed", func(labels map[string]string) { delete(labels, "team") }, false),
 		)
 	})
 
-	Describe("getTargetNamespace", func() {
-		It("should get target namespace successfully", func() {
-			createNamespace("test-ns", nil, nil)
+	Describe("removal protection", func() {
+		It("should retain a removal-protected stale label and release it once unprotected", func() {
+			ns := f.createNamespace("test-ns", map[string]string{
+				"team": "payments",
+				"tier": "gold",
+			}, map[string]string{
+				appliedAnnoKey: `{"team":"payments","tier":"gold"}`,
+			})
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                    map[string]string{"env": "prod"},
+				RemovalProtectionPatterns: []string{"team"},
+			})
 
-			result, err := reconciler.getTargetNamespace(ctx, "test-ns")
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("team", "payments"))
+			Expect(updatedNS.Labels).NotTo(HaveKey("tier"))
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("env", "prod"))
+			Expect(readAppliedAnnotation(&updatedNS)).To(HaveKeyWithValue("team", "payments"))
 
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.RemovalProtected).To(Equal([]string{"team"}))
+
+			By("lifting the removal protection")
+			updatedCR.Spec.RemovalProtectionPatterns = nil
+			Expect(fakeClient.Update(ctx, &updatedCR)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result.Name).To(Equal("test-ns"))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).NotTo(HaveKey("team"))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.RemovalProtected).To(BeEmpty())
 		})
+	})
 
-		It("should return error for non-existent namespace", func() {
-			_, err := reconciler.getTargetNamespace(ctx, "non-existent")
+	Describe("stale label pruning", func() {
+		It("should keep labels dropped from the spec tracked until the CR is deleted when pruning is off", func() {
+			ns := f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:           map[string]string{"env": "prod", "team": "payments"},
+				PruneStaleLabels: ptr.To(false),
+			})
 
-			Expect(err).To(HaveOccurred())
-			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("dropping a label from the spec")
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			cr.Spec.Labels = map[string]string{"env": "staging"}
+			Expect(fakeClient.Update(ctx, cr)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("team", "payments"))
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("env", "staging"))
+			Expect(readAppliedAnnotation(&updatedNS)).To(Equal(map[string]string{"env": "staging", "team": "payments"}))
+
+			By("finalizing the CR")
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			_, err = reconciler.finalize(ctx, cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).NotTo(HaveKey("team"))
+			Expect(updatedNS.Labels).NotTo(HaveKey("env"))
+		})
+
+		It("should not report stale labels as removals in a dry run when pruning is off", func() {
+			f.createNamespace("test-ns", map[string]string{"team": "payments"}, map[string]string{
+				appliedAnnoKey: `{"team":"payments"}`,
+			})
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:           map[string]string{"env": "prod"},
+				DryRun:           true,
+				PruneStaleLabels: ptr.To(false),
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			Expect(cr.Status.WouldApply).To(Equal([]string{"env"}))
+			Expect(cr.Status.WouldRemove).To(BeEmpty())
+		})
+	})
+
+	Describe("tier protection", func() {
+		DescribeTable("should protect labels according to the namespace's quota tier",
+			func(tier string, expectTeam string) {
+				f.createNamespace("test-ns", map[string]string{"quota-tier": tier, "team": "platform"}, nil)
+				f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+					Labels:              map[string]string{"team": "web", "env": "prod"},
+					ProtectionTierLabel: "quota-tier",
+					TierProtectedLabelPatterns: []labelsv1alpha1.TierProtectedLabelPatterns{
+						{Tier: "gold", Patterns: []string{"team"}},
+					},
+				})
+
+				_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+				Expect(err).NotTo(HaveOccurred())
+
+				var updatedNS corev1.Namespace
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &updatedNS)).To(Succeed())
+				Expect(updatedNS.Labels).To(HaveKeyWithValue("team", expectTeam))
+				Expect(updatedNS.Labels).To(HaveKeyWithValue("env", "prod"))
+			},
+			Entry("gold tier protects team", "gold", "platform"),
+			Entry("bronze tier leaves team mutable", "bronze", "web"),
+		)
+	})
+
+	Describe("allowed label patterns", func() {
+		It("should drop and report keys outside the allowlist", func() {
+			f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:               map[string]string{"team.example.com/owner": "web", "cost-center": "42", "env": "prod"},
+				AllowedLabelPatterns: []string{"team.example.com/*", "regex:^cost-"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("team.example.com/owner", "web"))
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("cost-center", "42"))
+			Expect(updatedNS.Labels).NotTo(HaveKey("env"))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			Expect(cr.Status.DisallowedLabels).To(Equal([]string{"env"}))
+			Expect(cr.Status.AllowedLabels).NotTo(HaveKey("env"))
+		})
+	})
+
+	Describe("list merge keys", func() {
+		nsLabels := func() map[string]string {
+			var ns corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &ns)).To(Succeed())
+			return ns.Labels
+		}
+
+		updateSpecLabels := func(labels map[string]string) {
+			var cr labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "labels", Namespace: "test-ns"}, &cr)).To(Succeed())
+			cr.Spec.Labels = labels
+			Expect(fakeClient.Update(ctx, &cr)).To(Succeed())
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("should merge list items with the existing value and remove only its own items", func() {
+			f.createNamespace("test-ns", map[string]string{"teams": "frontend_data", "env": "dev"}, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:        map[string]string{"teams": "backend", "env": "prod"},
+				ListMergeKeys: []string{"teams"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsLabels()).To(HaveKeyWithValue("teams", "backend_data_frontend"))
+			Expect(nsLabels()).To(HaveKeyWithValue("env", "prod"), "non-list keys are still overwritten")
+
+			By("reconciling again without changes")
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsLabels()).To(HaveKeyWithValue("teams", "backend_data_frontend"))
+
+			By("replacing the contributed item")
+			updateSpecLabels(map[string]string{"teams": "ops", "env": "prod"})
+			Expect(nsLabels()).To(HaveKeyWithValue("teams", "data_frontend_ops"))
+
+			By("dropping the key from the spec")
+			updateSpecLabels(map[string]string{"env": "prod"})
+			Expect(nsLabels()).To(HaveKeyWithValue("teams", "data_frontend"))
+		})
+
+		It("should remove the label once none of the items came from elsewhere", func() {
+			f.createNamespace("test-ns", nil, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:        map[string]string{"teams": "backend_ops"},
+				ListMergeKeys: []string{"teams"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsLabels()).To(HaveKeyWithValue("teams", "backend_ops"))
+
+			updateSpecLabels(nil)
+			Expect(nsLabels()).NotTo(HaveKey("teams"))
+		})
+
+		It("should keep the namespace value when the merged value would be invalid", func() {
+			long := strings.Repeat("a", 60)
+			f.createNamespace("test-ns", map[string]string{"teams": long}, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:        map[string]string{"teams": "backend"},
+				ListMergeKeys: []string{"teams"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsLabels()).To(HaveKeyWithValue("teams", long))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			cond := meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionListLabelsMerged)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("InvalidMergedValue"))
+			Expect(cond.Message).To(ContainSubstring("teams"))
+		})
+	})
+
+	Describe("events", func() {
+		var recorder *record.FakeRecorder
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(10)
+			reconciler.Recorder = recorder
+		})
+
+		It("should emit a Normal event when labels are applied", func() {
+			f.createNamespace("test-ns", nil, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod", "team": "web"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(Equal("Normal LabelsApplied Applied 2 labels to namespace 'test-ns': env, team")))
+
+			By("reconciling again without changes")
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should emit a Normal event only when stale labels are removed", func() {
+			f.createNamespace("test-ns", map[string]string{"env": "dev", "team": "web", "owner": "alice"},
+				map[string]string{appliedAnnoKey: `{"env":"dev","team":"web"}`})
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "dev", "team": "web"},
+			})
+
+			By("reconciling without stale labels")
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(BeEmpty())
+
+			By("dropping labels from the spec")
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr)).To(Succeed())
+			cr.Spec.Labels = map[string]string{"env": "prod"}
+			Expect(fakeClient.Update(ctx, cr)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(Equal("Normal LabelsApplied Applied 1 labels to namespace 'test-ns': env")))
+			Expect(recorder.Events).To(Receive(Equal("Normal StaleLabelsRemoved Removed 1 stale labels from namespace 'test-ns': team")))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should emit a Warning event with the conflicting value when a protected label is skipped", func() {
+			f.createNamespace("test-ns", map[string]string{"kubernetes.io/managed-by": "other"}, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"kubernetes.io/managed-by": "me"},
+				ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(Equal(
+				"Warning ProtectedLabelSkipped Skipped protected label 'kubernetes.io/managed-by': namespace has 'other', spec requests 'me'")))
+		})
+
+		It("should emit a single aggregated Warning event when configured", func() {
+			reconciler.AggregateWarningEvents = true
+			f.createNamespace("test-ns", map[string]string{
+				"kubernetes.io/managed-by": "other",
+				"kubernetes.io/owner":      "platform",
+			}, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"kubernetes.io/managed-by": "me", "kubernetes.io/owner": "me"},
+				ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(Equal(
+				"Warning ProtectedLabelsSkipped Skipped 2 protected labels: kubernetes.io/managed-by, kubernetes.io/owner")))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should emit a Warning event on a fail-mode conflict", func() {
+			f.createNamespace("test-ns", map[string]string{"kubernetes.io/managed-by": "other"}, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"kubernetes.io/managed-by": "me"},
+				ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var event string
+			Expect(recorder.Events).To(Receive(&event))
+			Expect(event).To(HavePrefix("Warning ProtectedLabelConflict"))
+			Expect(event).To(ContainSubstring("kubernetes.io/managed-by"))
+			Expect(event).To(ContainSubstring("'other'"))
+		})
+	})
+
+	Describe("protection conflict condition", func() {
+		It("should be set alongside Ready on a fail-mode conflict and cleared once it resolves", func() {
+			ns := f.createNamespace("test-ns", map[string]string{
+				"kubernetes.io/managed-by": "other",
+				"kubernetes.io/owner":      "platform",
+			}, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"kubernetes.io/managed-by": "me", "kubernetes.io/owner": "me", "env": "prod"},
+				ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			cond := meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionProtectionConflict)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal("ProtectedLabelConflict"))
+			Expect(cond.Message).To(Equal("Fail-mode protection blocks labels: kubernetes.io/managed-by, kubernetes.io/owner"))
+			Expect(meta.IsStatusConditionFalse(updatedCR.Status.Conditions, "Ready")).To(BeTrue())
+
+			By("resolving the conflict on the namespace")
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			updatedNS.Labels = map[string]string{"kubernetes.io/managed-by": "me", "kubernetes.io/owner": "me"}
+			Expect(fakeClient.Update(ctx, &updatedNS)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionProtectionConflict)).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(updatedCR.Status.Conditions, "Ready")).To(BeTrue())
+		})
+	})
+
+	Describe("protection relaxation", func() {
+		DescribeTable("should keep or apply a value protection kept once protection is relaxed",
+			func(preserve bool, relaxedValue string) {
+				ns := f.createNamespace("test-ns", map[string]string{"owner": "platform"}, nil)
+				cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+					Labels:                  map[string]string{"owner": "me", "env": "prod"},
+					ProtectedLabelPatterns:  []string{"owner"},
+					ProtectionMode:          labelsv1alpha1.ProtectionModeFail,
+					PreserveProtectedValues: preserve,
+				})
+				reconcileWith := func(mutate func(spec *labelsv1alpha1.NamespaceLabelSpec)) (*corev1.Namespace, *labelsv1alpha1.NamespaceLabel) {
+					var updatedCR labelsv1alpha1.NamespaceLabel
+					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+					mutate(&updatedCR.Spec)
+					Expect(fakeClient.Update(ctx, &updatedCR)).To(Succeed())
+					_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+					Expect(err).NotTo(HaveOccurred())
+					var updatedNS corev1.Namespace
+					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+					return &updatedNS, &updatedCR
+				}
+
+				_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+				Expect(err).NotTo(HaveOccurred())
+
+				By("relaxing the mode to skip, which still keeps the protected value")
+				updatedNS, updatedCR := reconcileWith(func(spec *labelsv1alpha1.NamespaceLabelSpec) {
+					spec.ProtectionMode = labelsv1alpha1.ProtectionModeSkip
+				})
+				Expect(updatedNS.Labels).To(HaveKeyWithValue("owner", "platform"))
+				Expect(updatedCR.Status.ProtectedLabelsSkipped).To(ConsistOf("owner"))
+
+				By("dropping the protection pattern")
+				updatedNS, updatedCR = reconcileWith(func(spec *labelsv1alpha1.NamespaceLabelSpec) {
+					spec.ProtectedLabelPatterns = nil
+				})
+				Expect(updatedNS.Labels).To(HaveKeyWithValue("owner", relaxedValue))
+				Expect(updatedNS.Labels).To(HaveKeyWithValue("env", "prod"))
+				if preserve {
+					Expect(updatedCR.Status.ProtectedLabelsSkipped).To(ConsistOf("owner"))
+					Expect(updatedCR.Status.PreservedLabels).To(Equal(map[string]string{"owner": "platform"}))
+
+					By("releasing the label once the namespace value changes")
+					updatedNS.Labels["owner"] = "someone-else"
+					Expect(fakeClient.Update(ctx, updatedNS)).To(Succeed())
+					updatedNS, updatedCR = reconcileWith(func(*labelsv1alpha1.NamespaceLabelSpec) {})
+					Expect(updatedNS.Labels).To(HaveKeyWithValue("owner", "me"))
+				}
+				Expect(updatedCR.Status.PreservedLabels).To(BeEmpty())
+			},
+			Entry("by default the desired value is applied", false, "me"),
+			Entry("with preserveProtectedValues the kept value stays", true, "platform"),
+		)
+	})
+
+	Describe("overwrite policy", func() {
+		It("should only set labels the operator owns under ifOwned", func() {
+			ns := f.createNamespace("test-ns", map[string]string{
+				"team":  "platform",
+				"env":   "dev",
+				"owner": "web",
+			}, map[string]string{
+				appliedAnnoKey: `{"env":"dev"}`,
+			})
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:          map[string]string{"team": "web", "env": "prod", "owner": "web", "tier": "gold"},
+				OverwritePolicy: labelsv1alpha1.OverwritePolicyIfOwned,
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"team": "platform", "env": "prod", "owner": "web", "tier": "gold"}))
+			Expect(readAppliedAnnotation(&updatedNS)).To(Equal(map[string]string{"env": "prod", "tier": "gold"}))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.OverwriteConflicts).To(Equal([]string{"team"}))
+
+			By("overwriting everything once the policy is always")
+			updatedCR.Spec.OverwritePolicy = labelsv1alpha1.OverwritePolicyAlways
+			Expect(fakeClient.Update(ctx, &updatedCR)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("team", "web"))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.OverwriteConflicts).To(BeEmpty())
+		})
+	})
+
+	Describe("skipped label message", func() {
+		It("should cap the keys listed in Ready and keep the full list in status", func() {
+			existing := map[string]string{}
+			spec := map[string]string{"env": "prod"}
+			for i := 0; i < 15; i++ {
+				key := fmt.Sprintf("kubernetes.io/key-%02d", i)
+				existing[key] = "other"
+				spec[key] = "mine"
+			}
+			f.createNamespace("test-ns", existing, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 spec,
+				ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeWarn,
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.ProtectedLabelsSkipped).To(HaveLen(15))
+			cond := meta.FindStatusCondition(updatedCR.Status.Conditions, "Ready")
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Message).To(Equal("Applied 1 labels to namespace 'test-ns', skipped 15 protected labels (" +
+				"kubernetes.io/key-00, kubernetes.io/key-01, kubernetes.io/key-02, kubernetes.io/key-03, kubernetes.io/key-04, " +
+				"kubernetes.io/key-05, kubernetes.io/key-06, kubernetes.io/key-07, kubernetes.io/key-08, kubernetes.io/key-09 and 5 more)"))
+		})
+	})
+
+	Describe("dry run", func() {
+		It("should report the plan without touching the namespace", func() {
+			ns := f.createNamespace("test-ns", map[string]string{
+				"old-label": "old-value",
+				"env":       "dev",
+			}, map[string]string{
+				appliedAnnoKey: `{"old-label":"old-value","env":"dev"}`,
+			})
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod", "team": "web"},
+				DryRun: true,
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"old-label": "old-value", "env": "dev"}))
+			Expect(updatedNS.Annotations).To(HaveKeyWithValue(appliedAnnoKey, `{"old-label":"old-value","env":"dev"}`))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.WouldApply).To(Equal([]string{"env", "team"}))
+			Expect(updatedCR.Status.WouldRemove).To(Equal([]string{"old-label"}))
+			Expect(updatedCR.Status.Applied).To(BeFalse())
+			Expect(meta.FindStatusCondition(updatedCR.Status.Conditions, "Ready").Reason).To(Equal("DryRun"))
+
+			By("turning dry run off")
+			updatedCR.Spec.DryRun = false
+			Expect(fakeClient.Update(ctx, &updatedCR)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"env": "prod", "team": "web"}))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.WouldApply).To(BeEmpty())
+			Expect(updatedCR.Status.WouldRemove).To(BeEmpty())
+			Expect(updatedCR.Status.Applied).To(BeTrue())
+		})
+
+		It("should report pending changes against the last applied spec revision", func() {
+			f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "dev", "team": "web", "tier": "gold"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(readLastAppliedSpec(&updatedCR, LastAppliedSpecAnnoKey)).To(Equal(map[string]string{"env": "dev", "team": "web", "tier": "gold"}))
+			Expect(updatedCR.Status.PendingChanges).To(BeEmpty())
+
+			By("previewing the next revision")
+			updatedCR.Spec.Labels = map[string]string{"env": "prod", "team": "web", "region": "eu"}
+			updatedCR.Spec.DryRun = true
+			Expect(fakeClient.Update(ctx, &updatedCR)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.PendingChanges).To(Equal([]labelsv1alpha1.LabelChange{
+				{Key: "env", Action: labelsv1alpha1.LabelChangeUpdate, OldValue: "dev", NewValue: "prod"},
+				{Key: "region", Action: labelsv1alpha1.LabelChangeAdd, NewValue: "eu"},
+				{Key: "tier", Action: labelsv1alpha1.LabelChangeRemove, OldValue: "gold"},
+			}))
+			Expect(readLastAppliedSpec(&updatedCR, LastAppliedSpecAnnoKey)).To(HaveKeyWithValue("env", "dev"), "a dry run does not move the snapshot")
+
+			By("committing the revision")
+			updatedCR.Spec.DryRun = false
+			Expect(fakeClient.Update(ctx, &updatedCR)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.PendingChanges).To(BeEmpty())
+			Expect(updatedCR.Status.Applied).To(BeTrue())
+			Expect(readLastAppliedSpec(&updatedCR, LastAppliedSpecAnnoKey)).To(Equal(map[string]string{"env": "prod", "team": "web", "region": "eu"}))
+		})
+	})
+
+	Describe("namespace opt-out", func() {
+		It("should leave an opted-out namespace untouched and report why", func() {
+			ns := f.createNamespace("test-ns", map[string]string{"env": "dev"}, map[string]string{IgnoreAnnoKey: "true"})
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod", "team": "web"},
+			})
+
+			result, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"env": "dev"}))
+			Expect(updatedNS.Annotations).To(Equal(map[string]string{IgnoreAnnoKey: "true"}))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.Applied).To(BeFalse())
+			cond := meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionNamespaceOptedOut)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(meta.FindStatusCondition(updatedCR.Status.Conditions, "Ready").Reason).To(Equal("NamespaceOptedOut"))
+
+			By("opting back in")
+			delete(updatedNS.Annotations, IgnoreAnnoKey)
+			Expect(fakeClient.Update(ctx, &updatedNS)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"env": "prod", "team": "web"}))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionNamespaceOptedOut)).To(BeNil())
+		})
+
+		It("should release the CR without removing labels from an opted-out namespace", func() {
+			ns := f.createNamespace("test-ns", map[string]string{"env": "prod"},
+				map[string]string{appliedAnnoKey: `{"env":"prod"}`, IgnoreAnnoKey: "true"})
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{})
+
+			_, err := reconciler.finalize(ctx, cr)
+			Expect(err).NotTo(HaveOccurred())
+			expectFinalizerRemoved(cr)
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"env": "prod"}))
+			Expect(updatedNS.Annotations).To(HaveKeyWithValue(appliedAnnoKey, `{"env":"prod"}`))
+		})
+	})
+
+	Describe("last applied spec annotation", func() {
+		It("should record the applied spec labels under a configured key", func() {
+			reconciler.LastAppliedSpecAnnotation = "gitops.example.com/last-applied-labels"
+			f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod", "team": "web"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Annotations).To(HaveKeyWithValue("gitops.example.com/last-applied-labels", `{"env":"prod","team":"web"}`))
+			Expect(updatedCR.Annotations).NotTo(HaveKey(LastAppliedSpecAnnoKey))
+
+			By("diffing a dry run against the configured key")
+			updatedCR.Spec.Labels = map[string]string{"env": "prod"}
+			updatedCR.Spec.DryRun = true
+			Expect(fakeClient.Update(ctx, &updatedCR)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.PendingChanges).To(Equal([]labelsv1alpha1.LabelChange{
+				{Key: "team", Action: labelsv1alpha1.LabelChangeRemove, OldValue: "web"},
+			}))
+		})
+	})
+
+	Describe("protection status label", func() {
+		const statusLabel = "labels.shahaf.com/protection"
+
+		namespaceLabels := func() map[string]string {
+			var ns corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &ns)).To(Succeed())
+			return ns.Labels
+		}
+
+		It("should follow the CR's protection through its lifecycle", func() {
+			reconciler.ProtectionStatusLabel = statusLabel
+			f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"env": "prod"},
+				ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+				KeyPrefix:              "team.example.com/",
+				AllowedLabelPatterns:   []string{"team.example.com/*"},
+			})
+
+			By("labeling the namespace while protection is configured")
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespaceLabels()).To(HaveKeyWithValue(statusLabel, ProtectionStatusLabelValue))
+			Expect(namespaceLabels()).To(HaveKeyWithValue("team.example.com/env", "prod"))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.LabelSources).To(HaveKeyWithValue(statusLabel, labelSourceOperator))
+
+			By("removing the label once protection is dropped")
+			updatedCR.Spec.ProtectedLabelPatterns = nil
+			Expect(fakeClient.Update(ctx, &updatedCR)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespaceLabels()).NotTo(HaveKey(statusLabel))
+
+			By("restoring it with removal protection")
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			updatedCR.Spec.RemovalProtectionPatterns = []string{"team.example.com/env"}
+			Expect(fakeClient.Update(ctx, &updatedCR)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespaceLabels()).To(HaveKeyWithValue(statusLabel, ProtectionStatusLabelValue))
+
+			By("cleaning it up when the CR is deleted")
+			Expect(fakeClient.Delete(ctx, cr)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespaceLabels()).NotTo(HaveKey(statusLabel))
+		})
+
+		It("should not be set when disabled", func() {
+			f.createNamespace("test-ns", nil, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"env": "prod"},
+				ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(namespaceLabels()).To(Equal(map[string]string{"env": "prod"}))
+		})
+	})
+
+	Describe("kill switch", func() {
+		setKillSwitch := func(crd *apiextensionsv1.CustomResourceDefinition, active bool) {
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(crd), crd)).To(Succeed())
+			if active {
+				crd.Annotations = map[string]string{KillSwitchAnnoKey: "true"}
+			} else {
+				crd.Annotations = nil
+			}
+			Expect(fakeClient.Update(ctx, crd)).To(Succeed())
+		}
+
+		It("should halt label operations while active and resume once cleared", func() {
+			crd := &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: crdName}}
+			Expect(fakeClient.Create(ctx, crd)).To(Succeed())
+			ns := f.createNamespace("test-ns", map[string]string{"app": "old"}, map[string]string{
+				appliedAnnoKey: `{"app":"old"}`,
+			})
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod"},
+			})
+
+			By("activating the kill switch")
+			setKillSwitch(crd, true)
+			result, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"app": "old"}))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			cond := meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionKillSwitchActive)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+
+			By("clearing the kill switch")
+			setKillSwitch(crd, false)
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"env": "prod"}))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionKillSwitchActive)).To(BeNil())
+			Expect(updatedCR.Status.Applied).To(BeTrue())
+		})
+
+		It("should treat a missing CRD as an inactive kill switch", func() {
+			active, err := reconciler.isKillSwitchActive(ctx)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeFalse())
+		})
+	})
+
+	Describe("delayed apply", func() {
+		It("should hold labels until applyAfter elapses since creation", func() {
+			created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+			fakeClock := clocktesting.NewFakeClock(created.Add(time.Minute))
+			reconciler.Clock = fakeClock
+
+			ns := f.createNamespace("test-ns", nil, nil)
+			cr := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "labels",
+					Namespace:         "test-ns",
+					Finalizers:        []string{FinalizerName},
+					CreationTimestamp: metav1.NewTime(created),
+				},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					Labels:     map[string]string{"env": "prod"},
+					ApplyAfter: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			}
+			Expect(fakeClient.Create(ctx, cr)).To(Succeed())
+
+			By("reconciling before the delay elapses")
+			result, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(9 * time.Minute))
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).NotTo(HaveKey("env"))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(updatedCR.Status.Conditions, ConditionPendingDelayedApply)).To(BeTrue())
+
+			By("reconciling once the delay has elapsed")
+			fakeClock.Step(9 * time.Minute)
+			result, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("env", "prod"))
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionPendingDelayedApply)).To(BeNil())
+			Expect(updatedCR.Status.Applied).To(BeTrue())
+		})
+	})
+
+	Describe("conditional on namespace", func() {
+		BeforeEach(func() {
+			reconciler.ConditionalNamespaces = []string{"feature-flags"}
+		})
+
+		It("should apply labels only while the control namespace carries the required label", func() {
+			control := f.createNamespace("feature-flags", nil, nil)
+			ns := f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"mesh": "enabled"},
+				ConditionalOnNamespace: &labelsv1alpha1.NamespaceCondition{
+					Name: "feature-flags", LabelKey: "mesh-rollout", LabelValue: "on",
+				},
+			})
+			setFlag := func(value string) {
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(control), control)).To(Succeed())
+				control.Labels = map[string]string{"mesh-rollout": value}
+				Expect(fakeClient.Update(ctx, control)).To(Succeed())
+			}
+			notReady := func() *metav1.Condition {
+				var updatedCR labelsv1alpha1.NamespaceLabel
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+				return meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionNamespaceNotReady)
+			}
+			nsLabels := func() map[string]string {
+				var updatedNS corev1.Namespace
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+				return updatedNS.Labels
+			}
+
+			By("holding labels while the flag is missing")
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsLabels()).NotTo(HaveKey("mesh"))
+			Expect(notReady()).NotTo(BeNil())
+			Expect(notReady().Message).To(Equal("Namespace 'feature-flags' does not have label 'mesh-rollout'"))
+
+			By("holding labels while the flag has another value")
+			setFlag("off")
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsLabels()).NotTo(HaveKey("mesh"))
+			Expect(notReady().Message).To(ContainSubstring("set to 'off', waiting for 'on'"))
+
+			By("applying labels once the flag is turned on")
+			setFlag("on")
+			Expect(reconciler.mapNamespaceToRequests(ctx, control)).To(ContainElement(reconcileRequest("labels", "test-ns")))
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsLabels()).To(HaveKeyWithValue("mesh", "enabled"))
+			Expect(notReady()).To(BeNil())
+
+			By("leaving applied labels in place when the flag is turned off again")
+			setFlag("off")
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsLabels()).To(HaveKeyWithValue("mesh", "enabled"))
+			Expect(notReady()).NotTo(BeNil())
+		})
+
+		It("should hold labels when gating on a namespace that is not allowed", func() {
+			f.createNamespace("other-team", map[string]string{"mesh-rollout": "on"}, nil)
+			f.createNamespace("test-ns", map[string]string{"mesh-rollout": "on"}, nil)
+			cond := &labelsv1alpha1.NamespaceCondition{Name: "other-team", LabelKey: "mesh-rollout", LabelValue: "on"}
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"mesh": "enabled"},
+				ConditionalOnNamespace: cond,
+			})
+
+			_, err := f.reconcile("labels", "test-ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.getNamespace("test-ns").Labels).NotTo(HaveKey("mesh"))
+			notReady := meta.FindStatusCondition(f.getCR("labels", "test-ns").Status.Conditions, ConditionNamespaceNotReady)
+			Expect(notReady).NotTo(BeNil())
+			Expect(notReady.Reason).To(Equal("NamespaceNotAllowed"))
+			Expect(notReady.Message).To(Equal("Gating on namespace 'other-team' is not allowed on this operator"))
+
+			By("always allowing the CR's own namespace")
+			cr := f.getCR("labels", "test-ns")
+			cond.Name = "test-ns"
+			cr.Spec.ConditionalOnNamespace = cond
+			Expect(fakeClient.Update(ctx, cr)).To(Succeed())
+			_, err = f.reconcile("labels", "test-ns")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.getNamespace("test-ns").Labels).To(HaveKeyWithValue("mesh", "enabled"))
+			Expect(meta.FindStatusCondition(f.getCR("labels", "test-ns").Status.Conditions, ConditionNamespaceNotReady)).To(BeNil())
+		})
+	})
+
+	Describe("inherited labels", func() {
+		BeforeEach(func() {
+			reconciler.InheritSourceNamespaces = []string{"parent"}
+		})
+
+		It("should copy the listed keys from the source namespace with spec labels winning", func() {
+			source := f.createNamespace("parent", map[string]string{"team": "payments", "cost-center": "cc-1", "secret-tier": "x"}, nil)
+			ns := f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:      map[string]string{"team": "checkout", "env": "prod"},
+				InheritFrom: "parent",
+				InheritKeys: []string{"team", "cost-center", "region"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"team": "checkout", "env": "prod", "cost-center": "cc-1"}))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.LabelSources).To(HaveKeyWithValue("cost-center", "namespace/parent"))
+			Expect(updatedCR.Status.LabelSources).To(HaveKeyWithValue("team", labelSourceSpec))
+			Expect(reconciler.mapNamespaceToRequests(ctx, source)).To(ContainElement(reconcileRequest("labels", "test-ns")))
+		})
+
+		It("should report a missing source namespace without failing the reconcile", func() {
+			ns := f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:      map[string]string{"env": "prod"},
+				InheritFrom: "parent",
+				InheritKeys: []string{"team"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"env": "prod"}))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			cond := meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionInheritedLabelsResolved)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("SourceNamespaceNotFound"))
+			Expect(cond.Message).To(Equal("Source namespace 'parent' for inherited labels not found"))
+
+			By("resolving once the source namespace exists")
+			f.createNamespace("parent", map[string]string{"team": "payments"}, nil)
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("team", "payments"))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionInheritedLabelsResolved)).To(BeNil())
+		})
+
+		It("should refuse a source namespace outside the allowed ones", func() {
+			f.createNamespace("other-tenant", map[string]string{"team": "payments"}, nil)
+			ns := f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:      map[string]string{"env": "prod"},
+				InheritFrom: "other-tenant",
+				InheritKeys: []string{"team"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"env": "prod"}))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			cond := meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionInheritedLabelsResolved)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal("SourceNamespaceNotAllowed"))
+			Expect(cond.Message).To(Equal("Inheriting labels from namespace 'other-tenant' is not allowed on this operator"))
+		})
+
+		It("should drop reserved keys unless the namespace opted in", func() {
+			reconciler.SpecValidator = &webhookv1alpha1.NamespaceLabelCustomValidator{
+				Client: fakeClient, ReservedLabelPrefixes: webhookv1alpha1.DefaultReservedLabelPrefixes}
+			f.createNamespace("parent", map[string]string{"team": "payments", "kubernetes.io/metadata.name": "parent"}, nil)
+			ns := f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				InheritFrom: "parent",
+				InheritKeys: []string{"team", "kubernetes.io/metadata.name"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"team": "payments"}))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			cond := meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionInheritedLabelsResolved)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal("ReservedLabelKeys"))
+			Expect(cond.Message).To(ContainSubstring("Inherited label 'kubernetes.io/metadata.name' uses a reserved prefix"))
+
+			By("copying them once the namespace opts in")
+			updatedNS.Annotations = map[string]string{webhookv1alpha1.AllowReservedLabelsAnnoKey: "true"}
+			Expect(fakeClient.Update(ctx, &updatedNS)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("kubernetes.io/metadata.name", "parent"))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionInheritedLabelsResolved)).To(BeNil())
+		})
+	})
+
+	Describe("remove labels", func() {
+		It("should delete listed keys the operator never applied", func() {
+			ns := f.createNamespace("test-ns", map[string]string{
+				"legacy-team": "payments",
+				"keep":        "me",
+				"locked":      "yes",
+			}, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                    map[string]string{"team": "payments"},
+				RemoveLabels:              []string{"legacy-team", "locked", "absent"},
+				RemovalProtectionPatterns: []string{"locked"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"team": "payments", "keep": "me", "locked": "yes"}))
+			Expect(updatedNS.Annotations).To(HaveKeyWithValue(appliedAnnoKey, `{"team":"payments"}`))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.LabelsRemoved).To(Equal([]string{"legacy-team"}))
+			Expect(updatedCR.Status.RemovalProtected).To(Equal([]string{"locked"}))
+
+			By("keeping the report once nothing is left to remove")
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.LabelsRemoved).To(Equal([]string{"legacy-team"}))
+		})
+
+		It("should report listed keys as would-remove in dry run", func() {
+			ns := f.createNamespace("test-ns", map[string]string{"legacy-team": "payments"}, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:       map[string]string{"team": "payments"},
+				RemoveLabels: []string{"legacy-team"},
+				DryRun:       true,
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"legacy-team": "payments"}))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.WouldRemove).To(Equal([]string{"legacy-team"}))
+			Expect(updatedCR.Status.LabelsRemoved).To(BeEmpty())
+		})
+
+		DescribeTable("should keep listed keys that protection covers",
+			func(namespace, key, value string, spec labelsv1alpha1.NamespaceLabelSpec, configure func(*NamespaceLabelReconciler)) {
+				if configure != nil {
+					configure(reconciler)
+				}
+				ns := f.createNamespace(namespace, map[string]string{key: value, "legacy-team": "payments"}, nil)
+				spec.RemoveLabels = []string{key, "legacy-team"}
+				cr := f.createCR("labels", namespace, nil, []string{FinalizerName}, spec)
+
+				_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", namespace))
+				Expect(err).NotTo(HaveOccurred())
+
+				var updatedNS corev1.Namespace
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+				Expect(updatedNS.Labels).To(HaveKeyWithValue(key, value))
+				Expect(updatedNS.Labels).NotTo(HaveKey("legacy-team"))
+
+				var updatedCR labelsv1alpha1.NamespaceLabel
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+				Expect(updatedCR.Status.LabelsRemoved).To(Equal([]string{"legacy-team"}))
+				Expect(updatedCR.Status.RemovalProtected).To(Equal([]string{key}))
+			},
+			Entry("the CR's own pattern", "test-ns", "example.com/owner", "platform",
+				labelsv1alpha1.NamespaceLabelSpec{ProtectedLabelPatterns: []string{"example.com/*"}}, nil),
+			Entry("the CR's protected values", "test-ns", "owner", "platform-team",
+				labelsv1alpha1.NamespaceLabelSpec{ProtectedValuePatterns: []string{"platform-*"}}, nil),
+			Entry("a default pattern the CR excepts", "test-ns", "pod-security.kubernetes.io/enforce", "restricted",
+				labelsv1alpha1.NamespaceLabelSpec{ProtectedLabelPatterns: []string{"!pod-security.kubernetes.io/*"}},
+				func(r *NamespaceLabelReconciler) {
+					r.DefaultProtectedLabelPatterns = []string{"pod-security.kubernetes.io/*"}
+				}),
+			Entry("system namespace protection", "kube-public", "pod-security.kubernetes.io/enforce", "privileged",
+				labelsv1alpha1.NamespaceLabelSpec{}, nil),
+			Entry("a mandatory pattern", "test-ns", "cost-center", "42",
+				labelsv1alpha1.NamespaceLabelSpec{}, func(r *NamespaceLabelReconciler) {
+					r.MandatoryLabelPatterns = []string{"cost-center"}
+				}),
+		)
+	})
+
+	Describe("apply preflight", func() {
+		It("should report a namespace update that a dry run shows would be rejected", func() {
+			rejecting := true
+			realPatches := 0
+			fakeClient = newClientBuilder(scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							patchOpts := &client.PatchOptions{}
+							patchOpts.ApplyOptions(opts)
+							if len(patchOpts.DryRun) == 0 {
+								realPatches++
+							} else if rejecting {
+								return apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "test-ns",
+									fmt.Errorf("team and tier labels must be set together"))
+							}
+						}
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).
+				Build()
+			f.setClient(fakeClient)
+			reconciler.PreflightNamespaceUpdates = true
+
+			ns := f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"team": "web"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(realPatches).To(BeZero())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).NotTo(HaveKey("team"))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.Applied).To(BeFalse())
+			cond := meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionApplyWouldBeRejected)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Message).To(ContainSubstring("team and tier labels must be set together"))
+			Expect(meta.FindStatusCondition(updatedCR.Status.Conditions, "Ready").Reason).To(Equal(ConditionApplyWouldBeRejected))
+
+			By("applying once admission accepts the update")
+			rejecting = false
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(realPatches).To(BeNumerically(">", 0))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(HaveKeyWithValue("team", "web"))
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.Applied).To(BeTrue())
+			Expect(meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionApplyWouldBeRejected)).To(BeNil())
+		})
+	})
+
+	Describe("label value charset", func() {
+		It("should hold back rendered values outside the charset", func() {
+			reconciler.ValueCharset = webhookv1alpha1.ValueCharsetASCIIPrintable
+			reconciler.InheritSourceNamespaces = []string{"parent"}
+			ns := f.createNamespace("test-ns", nil, nil)
+			f.createNamespace("parent", map[string]string{"team": "café"}, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:      map[string]string{"env": "prod"},
+				InheritFrom: "parent",
+				InheritKeys: []string{"team"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"env": "prod"}))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			cond := meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionLabelValuesInCharset)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("ValuesOutsideCharset"))
+			Expect(cond.Message).To(Equal("Values outside the ascii-printable charset are not applied: team"))
+
+			By("clearing the condition once every value fits")
+			reconciler.ValueCharset = webhookv1alpha1.ValueCharsetAny
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(meta.FindStatusCondition(updatedCR.Status.Conditions, ConditionLabelValuesInCharset)).To(BeNil())
+		})
+	})
+
+	Describe("periodic resync", func() {
+		DescribeTable("should requeue a successful reconcile after the resync interval",
+			func(global time.Duration, override *metav1.Duration, expected time.Duration) {
+				start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+				reconciler.Clock = clocktesting.NewFakeClock(start)
+				reconciler.ResyncInterval = global
+				f.createNamespace("test-ns", nil, nil)
+				f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+					Labels:            map[string]string{"env": "prod"},
+					ReconcileInterval: override,
+				})
+
+				result, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(expected))
+
+				var updatedCR labelsv1alpha1.NamespaceLabel
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "labels", Namespace: "test-ns"}, &updatedCR)).To(Succeed())
+				if expected == 0 {
+					Expect(updatedCR.Status.NextReconcileAt).To(BeNil())
+				} else {
+					Expect(updatedCR.Status.NextReconcileAt).NotTo(BeNil())
+					Expect(updatedCR.Status.NextReconcileAt.Time).To(BeTemporally("==", start.Add(expected)))
+				}
+			},
+			Entry("no resync configured", time.Duration(0), nil, time.Duration(0)),
+			Entry("global interval", time.Hour, nil, time.Hour),
+			Entry("per-CR override", time.Hour, &metav1.Duration{Duration: 30 * time.Second}, 30*time.Second),
+			Entry("per-CR override without a global interval", time.Duration(0), &metav1.Duration{Duration: 5 * time.Minute}, 5*time.Minute),
+			Entry("zero override falls back to the global interval", time.Hour, &metav1.Duration{}, time.Hour),
+		)
+
+		It("should report the backoff of a protection conflict as the next reconcile", func() {
+			start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+			reconciler.Clock = clocktesting.NewFakeClock(start)
+			reconciler.FailRequeueInterval = time.Minute
+			f.createNamespace("test-ns", map[string]string{"owner": "platform"}, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"owner": "me"},
+				ProtectedLabelPatterns: []string{"owner"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+			})
+
+			for _, expected := range []time.Duration{time.Minute, 2 * time.Minute} {
+				result, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(expected))
+
+				var updatedCR labelsv1alpha1.NamespaceLabel
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "labels", Namespace: "test-ns"}, &updatedCR)).To(Succeed())
+				Expect(updatedCR.Status.NextReconcileAt.Time).To(BeTemporally("==", start.Add(expected)))
+			}
+		})
+	})
+
+	Describe("owner UID annotation", func() {
+		It("should record the managing CR's UID and remove it on deletion", func() {
+			reconciler.OwnerUIDAnnotation = true
+			ns := f.createNamespace("test-ns", nil, nil)
+			cr := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "labels",
+					Namespace:  "test-ns",
+					UID:        types.UID("cr-uid"),
+					Finalizers: []string{FinalizerName},
+				},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"env": "prod"}},
+			}
+			Expect(fakeClient.Create(ctx, cr)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Annotations).To(HaveKeyWithValue(OwnerUIDAnnoKey, "cr-uid"))
+
+			By("deleting the CR")
+			Expect(fakeClient.Delete(ctx, cr)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Annotations).NotTo(HaveKey(OwnerUIDAnnoKey))
+			Expect(updatedNS.Labels).NotTo(HaveKey("env"))
+		})
+
+		It("should remove the annotation once the option is turned off", func() {
+			f.createNamespace("test-ns", nil, map[string]string{OwnerUIDAnnoKey: "cr-uid"})
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &updatedNS)).To(Succeed())
+			Expect(updatedNS.Annotations).NotTo(HaveKey(OwnerUIDAnnoKey))
+		})
+	})
+
+	Describe("finalize", func() {
+		// Test data for table-driven approach
+		DescribeTable("should handle different deletion scenarios",
+			func(setupNamespace func() *corev1.Namespace, crNamespace string, shouldFindNS bool, expectedLabelsAfter map[string]string) {
+				// Setup namespace if provided
+				var ns *corev1.Namespace
+				if setupNamespace != nil {
+					ns = setupNamespace()
+				}
+
+				// Create CR with finalizer
+				cr := f.createCR("test-cr", crNamespace, nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{})
+
+				// Call finalize
+				result, err := reconciler.finalize(ctx, cr)
+
+				// Should always succeed and not requeue
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeFalse())
+				Expect(result.RequeueAfter).To(BeZero())
+
+				// Verify finalizer is removed
+				expectFinalizerRemoved(cr)
+
+				// Verify namespace state if it should exist
+				if shouldFindNS && ns != nil {
+					var updatedNS corev1.Namespace
+					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+
+					// Check expected labels
+					for k, v := range expectedLabelsAfter {
+						Expect(updatedNS.Labels).To(HaveKeyWithValue(k, v))
+					}
+
+					// Applied annotation should be cleared
+					Expect(updatedNS.Annotations).To(HaveKeyWithValue(appliedAnnoKey, "{}"))
+				}
+			},
+			Entry("namespace not found", nil, "nonexistent-ns", false, nil),
+			Entry("namespace with no applied labels",
+				func() *corev1.Namespace {
+					return f.createNamespace("test-ns", map[string]string{"existing": "label"}, nil)
+				}, "test-ns", true, map[string]string{"existing": "label"}),
+			Entry("namespace with applied labels to remove",
+				func() *corev1.Namespace {
+					return f.createNamespace("test-ns",
+						map[string]string{
+							"applied-by-operator": "value1",
+							"another-applied":     "value2",
+							"existing":            "keep-me",
+						},
+						map[string]string{
+							appliedAnnoKey: `{"applied-by-operator":"value1","another-applied":"value2"}`,
+						})
+				}, "test-ns", true, map[string]string{"existing": "keep-me"}),
+		)
+
+		Context("with mandatory labels", func() {
+			var ns *corev1.Namespace
+
+			BeforeEach(func() {
+				reconciler.MandatoryLabelPatterns = []string{"compliance/*"}
+				ns = f.createNamespace("test-ns",
+					map[string]string{"compliance/owner": "team-a", "env": "prod"},
+					map[string]string{appliedAnnoKey: `{"compliance/owner":"team-a","env":"prod"}`})
+			})
+
+			It("should preserve them unmanaged by default", func() {
+				cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{})
+
+				_, err := reconciler.finalize(ctx, cr)
+				Expect(err).NotTo(HaveOccurred())
+				expectFinalizerRemoved(cr)
+
+				var updatedNS corev1.Namespace
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+				Expect(updatedNS.Labels).To(Equal(map[string]string{"compliance/owner": "team-a"}))
+				Expect(updatedNS.Annotations).To(HaveKeyWithValue(appliedAnnoKey, "{}"))
+			})
+
+			It("should remove them with a Warning event under the warn policy", func() {
+				recorder := record.NewFakeRecorder(10)
+				reconciler.Recorder = recorder
+				reconciler.MandatoryLabelPolicy = MandatoryLabelPolicyWarn
+				cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{})
+
+				_, err := reconciler.finalize(ctx, cr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(recorder.Events).To(Receive(Equal(
+					"Warning MandatoryLabelsRemoved Removed mandatory labels from namespace 'test-ns': compliance/owner")))
+
+				var updatedNS corev1.Namespace
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+				Expect(updatedNS.Labels).To(BeEmpty())
+			})
+		})
+
+		DescribeTable("should release the finalizer without touching a terminating namespace",
+			func(terminate func(ns *corev1.Namespace)) {
+				ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-ns",
+					Labels:      map[string]string{"env": "prod"},
+					Annotations: map[string]string{appliedAnnoKey: `{"env":"prod"}`},
+					Finalizers:  []string{"example.com/hold"},
+				}}
+				Expect(fakeClient.Create(ctx, ns)).To(Succeed())
+				terminate(ns)
+				cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{})
+
+				result, err := reconciler.finalize(ctx, cr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(reconcile.Result{}))
+				expectFinalizerRemoved(cr)
+
+				var updatedNS corev1.Namespace
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+				Expect(updatedNS.Labels).To(Equal(map[string]string{"env": "prod"}))
+				Expect(updatedNS.Annotations).To(HaveKeyWithValue(appliedAnnoKey, `{"env":"prod"}`))
+			},
+			Entry("namespace with a deletion timestamp", func(ns *corev1.Namespace) {
+				Expect(fakeClient.Delete(ctx, ns)).To(Succeed())
+			}),
+			Entry("namespace in the Terminating phase", func(ns *corev1.Namespace) {
+				ns.Status.Phase = corev1.NamespaceTerminating
+				Expect(fakeClient.Status().Update(ctx, ns)).To(Succeed())
+			}),
+		)
+
+		It("should not write status while the CR is being deleted", func() {
+			statusWrites := 0
+			fakeClient = newClientBuilder(scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						statusWrites++
+						return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+					},
+					SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+						statusWrites++
+						return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+					},
+				}).
+				Build()
+			f.setClient(fakeClient)
+
+			f.createNamespace("test-ns", map[string]string{"env": "prod"},
+				map[string]string{appliedAnnoKey: `{"env":"prod"}`})
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod"},
+			})
+			Expect(fakeClient.Delete(ctx, cr)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusWrites).To(BeZero())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).NotTo(HaveKey("env"))
+			Expect(apierrors.IsNotFound(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), cr))).To(BeTrue())
+		})
+
+		It("should remove only the managed keys with a single patch", func() {
+			var patches []string
+			nsUpdates := 0
+			fakeClient = newClientBuilder(scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							data, err := patch.Data(obj)
+							Expect(err).NotTo(HaveOccurred())
+							patches = append(patches, string(data))
+						}
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+					Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							nsUpdates++
+						}
+						return c.Update(ctx, obj, opts...)
+					},
+				}).
+				Build()
+			f.setClient(fakeClient)
+
+			ns := f.createNamespace("test-ns",
+				map[string]string{"env": "prod", "team": "a", "existing": "keep-me"},
+				map[string]string{
+					appliedAnnoKey:            `{"env":"prod","team":"a"}`,
+					appliedAnnotationsAnnoKey: `{"contact":"a@b.c"}`,
+					"contact":                 "a@b.c",
+					"owner":                   "platform",
+				})
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{})
+
+			_, err := reconciler.finalize(ctx, cr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsUpdates).To(BeZero())
+			Expect(patches).To(HaveLen(1))
+			Expect(patches[0]).To(ContainSubstring(`"env":null`))
+			Expect(patches[0]).To(ContainSubstring(`"team":null`))
+			Expect(patches[0]).To(ContainSubstring(`"contact":null`))
+			Expect(patches[0]).NotTo(ContainSubstring("existing"))
+			Expect(patches[0]).NotTo(ContainSubstring(`"owner"`))
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"existing": "keep-me"}))
+			Expect(updatedNS.Annotations).To(Equal(map[string]string{appliedAnnoKey: "{}", "owner": "platform"}))
+		})
+
+		It("should remove every recorded label, including keys since dropped from the spec", func() {
+			ns := f.createNamespace("test-ns",
+				map[string]string{"env": "prod", "dropped": "old", "existing": "keep-me"},
+				map[string]string{appliedAnnoKey: `{"env":"prod","dropped":"old"}`})
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod"},
+			})
+
+			_, err := reconciler.finalize(ctx, cr)
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(ns), &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"existing": "keep-me"}))
+		})
+	})
+
+	Describe("namespace update conflicts", func() {
+		It("should re-read the namespace and re-apply protection when the update conflicts", func() {
+			nsUpdates := 0
+			fakeClient = newClientBuilder(scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							nsUpdates++
+							if nsUpdates == 1 {
+								// Someone else sets the protected label just before our write lands
+								var fresh corev1.Namespace
+								Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), &fresh)).To(Succeed())
+								fresh.Labels["owner"] = "platform"
+								Expect(c.Update(ctx, &fresh)).To(Succeed())
+							}
+						}
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).
+				Build()
+			f.setClient(fakeClient)
+
+			f.createNamespace("test-ns", map[string]string{"team": "a"}, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"env": "prod", "owner": "me"},
+				ProtectedLabelPatterns: []string{"owner"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"team": "a", "env": "prod", "owner": "platform"}))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.ProtectedLabelsSkipped).To(ConsistOf("owner"))
+		})
+
+		It("should recompute the diff against a namespace edited between the read and the write", func() {
+			nsPatches := 0
+			fakeClient = newClientBuilder(scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							nsPatches++
+							if nsPatches == 1 {
+								// A user edits the namespace labels directly while the reconcile is mid-flight
+								var fresh corev1.Namespace
+								Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), &fresh)).To(Succeed())
+								fresh.Labels["team"] = "platform"
+								fresh.Labels["cost-center"] = "1234"
+								Expect(c.Update(ctx, &fresh)).To(Succeed())
+							}
+						}
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).
+				Build()
+			f.setClient(fakeClient)
+
+			f.createNamespace("test-ns", map[string]string{"app": "web"}, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:          map[string]string{"env": "prod", "team": "web"},
+				OverwritePolicy: labelsv1alpha1.OverwritePolicyIfOwned,
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsPatches).To(Equal(2))
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).To(Equal(map[string]string{"app": "web", "env": "prod", "team": "platform", "cost-center": "1234"}))
+			Expect(readAppliedAnnotation(&updatedNS)).To(Equal(map[string]string{"env": "prod"}))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(updatedCR.Status.OverwriteConflicts).To(Equal([]string{"team"}))
+		})
+
+		It("should leave the namespace alone when it opts out between the read and the write", func() {
+			nsPatches := 0
+			fakeClient = newClientBuilder(scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							nsPatches++
+							var fresh corev1.Namespace
+							Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), &fresh)).To(Succeed())
+							fresh.Annotations = map[string]string{IgnoreAnnoKey: "true"}
+							Expect(c.Update(ctx, &fresh)).To(Succeed())
+						}
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).
+				Build()
+			f.setClient(fakeClient)
+
+			f.createNamespace("test-ns", nil, nil)
+			cr := f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsPatches).To(Equal(1))
+
+			var updatedNS corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &updatedNS)).To(Succeed())
+			Expect(updatedNS.Labels).NotTo(HaveKey("env"))
+
+			var updatedCR labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &updatedCR)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(updatedCR.Status.Conditions, ConditionNamespaceOptedOut)).To(BeTrue())
+		})
+	})
+
+	Describe("namespace patches", func() {
+		It("should patch only the labels and keep concurrent changes to other fields", func() {
+			var nsPatches, nsUpdates int
+			fakeClient = newClientBuilder(scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							data, err := patch.Data(obj)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(string(data)).NotTo(ContainSubstring("team"))
+							nsPatches++
+						}
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+					Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							nsUpdates++
+						}
+						return c.Update(ctx, obj, opts...)
+					},
+				}).
+				Build()
+			f.setClient(fakeClient)
+
+			f.createNamespace("test-ns", map[string]string{"team": "a"}, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsPatches).To(Equal(1))
+
+			By("changing another field of the namespace before the next patch")
+			var ns corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &ns)).To(Succeed())
+			ns.Annotations["owner"] = "platform"
+			Expect(fakeClient.Update(ctx, &ns)).To(Succeed())
+			nsUpdates = 0
+
+			var cr labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "labels", Namespace: "test-ns"}, &cr)).To(Succeed())
+			cr.Spec.Labels["tier"] = "gold"
+			Expect(fakeClient.Update(ctx, &cr)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nsPatches).To(Equal(2))
+			Expect(nsUpdates).To(BeZero())
+
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &ns)).To(Succeed())
+			Expect(ns.Labels).To(Equal(map[string]string{"team": "a", "env": "prod", "tier": "gold"}))
+			Expect(ns.Annotations).To(HaveKeyWithValue("owner", "platform"))
+		})
+
+		It("should fall back to a full update when the patch fails", func() {
+			fakeClient = newClientBuilder(scheme).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if _, ok := obj.(*corev1.Namespace); ok {
+							return apierrors.NewMethodNotSupported(schema.GroupResource{Resource: "namespaces"}, "patch")
+						}
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).
+				Build()
+			f.setClient(fakeClient)
+
+			f.createNamespace("test-ns", nil, nil)
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var ns corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &ns)).To(Succeed())
+			Expect(ns.Labels).To(HaveKeyWithValue("env", "prod"))
+		})
+	})
+
+	Describe("processNamespaceLabels", func() {
+		It("should leave the namespace in the same state as a full Reconcile", func() {
+			spec := labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"env": "prod", "team": "web", "kubernetes.io/owner": "me"},
+				ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+			}
+			existing := map[string]string{"stale": "x", "kubernetes.io/owner": "platform"}
+			applied := map[string]string{appliedAnnoKey: `{"stale":"x"}`}
+
+			By("reconciling through Reconcile")
+			f.createNamespace("test-ns", maps.Clone(existing), maps.Clone(applied))
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, spec)
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("calling processNamespaceLabels directly on an identical namespace")
+			f.createNamespace("other-ns", maps.Clone(existing), maps.Clone(applied))
+			cr := f.createCR("labels", "other-ns", nil, []string{FinalizerName}, spec)
+			_, err = reconciler.processNamespaceLabels(ctx, cr, "other-ns", true)
+			Expect(err).NotTo(HaveOccurred())
+
+			var viaReconcile, direct corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &viaReconcile)).To(Succeed())
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "other-ns"}, &direct)).To(Succeed())
+			Expect(direct.Labels).To(Equal(viaReconcile.Labels))
+			Expect(direct.Annotations).To(Equal(viaReconcile.Annotations))
+			Expect(viaReconcile.Labels).To(Equal(map[string]string{"env": "prod", "team": "web", "kubernetes.io/owner": "platform"}))
+		})
+	})
+
+	Describe("getTargetNamespace", func() {
+		It("should get target namespace successfully", func() {
+			f.createNamespace("test-ns", nil, nil)
+
+			result, err := reconciler.getTargetNamespace(ctx, "test-ns")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Name).To(Equal("test-ns"))
+		})
+
+		It("should return error for non-existent namespace", func() {
+			_, err := reconciler.getTargetNamespace(ctx, "non-existent")
+
+			Expect(err).To(HaveOccurred())
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Describe("label changes status", func() {
+		It("should report what the last changing reconcile added, updated and removed", func() {
+			f.createNamespace("test-ns",
+				map[string]string{"env": "dev", "stale": "x", "team": "a"},
+				map[string]string{appliedAnnoKey: `{"env":"dev","stale":"x"}`})
+			f.createCR("labels", "test-ns", nil, []string{FinalizerName}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod", "tier": "web"},
+			})
+
+			_, err := reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var cr labelsv1alpha1.NamespaceLabel
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "labels", Namespace: "test-ns"}, &cr)).To(Succeed())
+			expected := &labelsv1alpha1.LabelChanges{
+				Added:   []labelsv1alpha1.LabelChange{{Key: "tier", Action: labelsv1alpha1.LabelChangeAdd, NewValue: "web"}},
+				Updated: []labelsv1alpha1.LabelChange{{Key: "env", Action: labelsv1alpha1.LabelChangeUpdate, OldValue: "dev", NewValue: "prod"}},
+				Removed: []labelsv1alpha1.LabelChange{{Key: "stale", Action: labelsv1alpha1.LabelChangeRemove, OldValue: "x"}},
+			}
+			Expect(cr.Status.LabelChanges).To(Equal(expected))
+
+			By("keeping the last diff across a reconcile that changes nothing")
+			_, err = reconciler.Reconcile(ctx, reconcileRequest("labels", "test-ns"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "labels", Namespace: "test-ns"}, &cr)).To(Succeed())
+			Expect(cr.Status.LabelChanges).To(Equal(expected))
 		})
 	})
 
@@ -297,9 +2417,13 @@ var _ = Describe("NamespaceLabelReconciler", Label("controller"), func() {
 				"old": "label",
 			}
 
-			changed := reconciler.applyLabelsToNamespace(ns, desired, prevApplied)
+			changes, retained := reconciler.applyLabelsToNamespace(ns, desired, prevApplied, nil, true)
 
-			Expect(changed).To(BeTrue())
+			Expect(changes).To(Equal([]labelsv1alpha1.LabelChange{
+				{Key: "new", Action: labelsv1alpha1.LabelChangeAdd, NewValue: "label"},
+				{Key: "updated", Action: labelsv1alpha1.LabelChangeAdd, NewValue: "value"},
+			}))
+			Expect(retained).To(BeEmpty())
 			Expect(ns.Labels).To(HaveKeyWithValue("existing", "label"))
 			Expect(ns.Labels).To(HaveKeyWithValue("new", "label"))
 			Expect(ns.Labels).To(HaveKeyWithValue("updated", "value"))
@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// OrphanSweeper periodically removes the labels and tracking annotations left on namespaces whose
+// NamespaceLabel was deleted without its finalizer running. A namespace is first marked with the time it was
+// found orphaned and only cleaned up once GracePeriod has passed, so a CR being recreated is not raced.
+type OrphanSweeper struct {
+	Client client.Client
+
+	// Interval is how often namespaces are scanned
+	Interval time.Duration
+	// GracePeriod is how long a namespace stays orphaned before its tracked labels are removed
+	GracePeriod time.Duration
+
+	// WriteBudget is the reconciler's write budget, charged for every namespace the sweeper updates.
+	// Unlimited when nil.
+	WriteBudget *WriteBudget
+
+	// Clock is used for the grace period. Defaults to the real clock when nil.
+	Clock clock.PassiveClock
+}
+
+// liveOwners records which tracking annotations still have a NamespaceLabel to own them
+type liveOwners struct {
+	// namespaces holds every namespace with at least one NamespaceLabel, which owns all of its own tracking
+	namespaces map[string]bool
+	// selector is set while any NamespaceLabel has spec.namespaceSelector, which owns selectorAppliedAnnoKey
+	selector bool
+}
+
+// Start runs the sweeper until ctx is cancelled; it implements manager.Runnable
+func (s *OrphanSweeper) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, s.sweep, s.Interval)
+	return nil
+}
+
+// sweep checks every namespace carrying the operator's tracking annotations. Failures are logged per namespace.
+func (s *OrphanSweeper) sweep(ctx context.Context) {
+	l := log.FromContext(ctx)
+
+	var crs labelsv1alpha1.NamespaceLabelList
+	if err := s.Client.List(ctx, &crs); err != nil {
+		l.Error(err, "failed to list NamespaceLabels for orphan sweep")
+		return
+	}
+	owners := liveOwners{namespaces: map[string]bool{}}
+	for _, cr := range crs.Items {
+		owners.namespaces[cr.Namespace] = true
+		if cr.Spec.NamespaceSelector != nil {
+			owners.selector = true
+		}
+	}
+
+	var list corev1.NamespaceList
+	if err := s.Client.List(ctx, &list); err != nil {
+		l.Error(err, "failed to list namespaces for orphan sweep")
+		return
+	}
+	for i := range list.Items {
+		ns := &list.Items[i]
+		if isOptedOut(ns) {
+			continue
+		}
+		if err := s.sweepNamespace(ctx, ns, owners); err != nil {
+			l.Error(err, "failed to sweep orphaned tracking", "namespace", ns.Name)
+		}
+	}
+}
+
+// sweepNamespace marks, clears or cleans up one namespace depending on whether its tracking is still owned
+func (s *OrphanSweeper) sweepNamespace(ctx context.Context, ns *corev1.Namespace, owners liveOwners) error {
+	ownKeys, ownTracked := ownTrackingKeys(ns)
+	orphanedOwn := ownTracked && !owners.namespaces[ns.Name]
+	orphanedSelector := len(readTrackingAnnotation(ns, selectorAppliedAnnoKey)) > 0 && !owners.selector
+
+	_, marked := ns.Annotations[orphanedSinceAnnoKey]
+	if !orphanedOwn && !orphanedSelector {
+		// Nothing is tracked or a CR is back, so the namespace is no longer orphaned
+		if !marked {
+			return nil
+		}
+		delete(ns.Annotations, orphanedSinceAnnoKey)
+		return s.update(ctx, ns)
+	}
+
+	now := s.now()
+	since, err := time.Parse(time.RFC3339, ns.Annotations[orphanedSinceAnnoKey])
+	if err != nil {
+		ns.Annotations[orphanedSinceAnnoKey] = now.UTC().Format(time.RFC3339)
+		return s.update(ctx, ns)
+	}
+	if now.Sub(since) < s.GracePeriod {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("Removing labels of deleted NamespaceLabel", "namespace", ns.Name, "orphanedSince", since,
+		"namespaceLabel", orphanedOwn, "selector", orphanedSelector)
+	if orphanedOwn {
+		for _, key := range ownKeys {
+			if isAnnotationsTrackingKey(key) {
+				removeStaleLabels(ns.Annotations, nil, readTrackingAnnotation(ns, key), nil)
+			} else {
+				removeStaleLabels(ns.Labels, nil, readTrackingAnnotation(ns, key), nil)
+			}
+			delete(ns.Annotations, key)
+		}
+		delete(ns.Annotations, OwnerUIDAnnoKey)
+	}
+	if orphanedSelector {
+		removeStaleLabels(ns.Labels, nil, readTrackingAnnotation(ns, selectorAppliedAnnoKey), nil)
+		delete(ns.Annotations, selectorAppliedAnnoKey)
+	}
+	delete(ns.Annotations, orphanedSinceAnnoKey)
+	return s.update(ctx, ns)
+}
+
+// ownTrackingKeys returns the namespace's own tracking annotation keys, covering the per-CR keys of
+// MultipleNamespaceLabels, and whether any of them still tracks something. A finalized CR leaves an empty
+// applied annotation behind, which tracks nothing.
+func ownTrackingKeys(ns *corev1.Namespace) ([]string, bool) {
+	var keys []string
+	tracked := false
+	for key := range ns.Annotations {
+		if !isAnnotationsTrackingKey(key) && key != appliedAnnoKey && !strings.HasPrefix(key, appliedAnnoKey+".") {
+			continue
+		}
+		keys = append(keys, key)
+		if len(readTrackingAnnotation(ns, key)) > 0 {
+			tracked = true
+		}
+	}
+	sort.Strings(keys)
+	return keys, tracked
+}
+
+// isAnnotationsTrackingKey reports whether key tracks applied annotations rather than labels
+func isAnnotationsTrackingKey(key string) bool {
+	return key == appliedAnnotationsAnnoKey || strings.HasPrefix(key, appliedAnnotationsAnnoKey+".")
+}
+
+// update charges the write to the WriteBudget before updating ns
+func (s *OrphanSweeper) update(ctx context.Context, ns *corev1.Namespace) error {
+	s.WriteBudget.charge(s.now())
+	return s.Client.Update(ctx, ns)
+}
+
+// now returns the current time from the injected clock, falling back to the real clock
+func (s *OrphanSweeper) now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
+	}
+	return s.Clock.Now()
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in orphan_sweeper.go
+
+var _ = Describe("OrphanSweeper", Label("controller"), func() {
+	var (
+		f          *testFixture
+		sweeper    *OrphanSweeper
+		fakeClient client.Client
+		fakeClock  *clocktesting.FakeClock
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		f = newTestFixture()
+		fakeClient, ctx = f.client, f.ctx
+		fakeClock = clocktesting.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+		sweeper = &OrphanSweeper{Client: fakeClient, Interval: time.Minute, GracePeriod: time.Hour, Clock: fakeClock}
+	})
+
+	It("should clean up orphaned tracking once the grace period has passed", func() {
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "orphan",
+			Labels: map[string]string{"env": "prod", "owner": "platform"},
+			Annotations: map[string]string{
+				"contact":                 "oncall",
+				"note":                    "kept",
+				appliedAnnoKey:            `{"env":"prod"}`,
+				appliedAnnotationsAnnoKey: `{"contact":"oncall"}`,
+			},
+		}})).To(Succeed())
+
+		sweeper.sweep(ctx)
+		ns := f.getNamespace("orphan")
+		Expect(ns.Annotations).To(HaveKeyWithValue(orphanedSinceAnnoKey, "2025-01-01T12:00:00Z"))
+		Expect(ns.Labels).To(HaveKey("env"))
+
+		By("sweeping again within the grace period")
+		fakeClock.Step(30 * time.Minute)
+		sweeper.sweep(ctx)
+		Expect(f.getNamespace("orphan").Labels).To(HaveKey("env"))
+
+		By("sweeping after the grace period")
+		fakeClock.Step(30 * time.Minute)
+		sweeper.sweep(ctx)
+		ns = f.getNamespace("orphan")
+		Expect(ns.Labels).To(Equal(map[string]string{"owner": "platform"}))
+		Expect(ns.Annotations).To(Equal(map[string]string{"note": "kept"}))
+	})
+
+	It("should leave namespaces with a NamespaceLabel alone and clear a stale orphan mark", func() {
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "managed",
+			Labels: map[string]string{"env": "prod"},
+			Annotations: map[string]string{
+				appliedAnnoKey:       `{"env":"prod"}`,
+				orphanedSinceAnnoKey: "2024-01-01T00:00:00Z",
+			},
+		}})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "managed"},
+		})).To(Succeed())
+
+		sweeper.sweep(ctx)
+		ns := f.getNamespace("managed")
+		Expect(ns.Labels).To(HaveKeyWithValue("env", "prod"))
+		Expect(ns.Annotations).To(Equal(map[string]string{appliedAnnoKey: `{"env":"prod"}`}))
+	})
+
+	It("should treat an empty applied annotation left by the finalizer as no tracking", func() {
+		f.createNamespace("finalized", map[string]string{"owner": "platform"}, map[string]string{
+			appliedAnnoKey:               "{}",
+			appliedAnnoKey + ".payments": "[]",
+			appliedAnnotationsAnnoKey:    "{}",
+		})
+
+		sweeper.sweep(ctx)
+		Expect(f.getNamespace("finalized").Annotations).NotTo(HaveKey(orphanedSinceAnnoKey))
+	})
+
+	It("should not mark a namespace while any of its NamespaceLabels is live", func() {
+		f.createNamespace("shared", map[string]string{"env": "prod", "team": "payments"}, map[string]string{
+			appliedAnnoKey:               `{"env":"prod"}`,
+			appliedAnnoKey + ".payments": `{"team":"payments"}`,
+		})
+		f.createCR("payments", "shared", nil, nil, labelsv1alpha1.NamespaceLabelSpec{})
+
+		sweeper.sweep(ctx)
+		fakeClock.Step(2 * time.Hour)
+		sweeper.sweep(ctx)
+		ns := f.getNamespace("shared")
+		Expect(ns.Labels).To(Equal(map[string]string{"env": "prod", "team": "payments"}))
+		Expect(ns.Annotations).NotTo(HaveKey(orphanedSinceAnnoKey))
+	})
+
+	It("should clean up per-CR tracking keys once no NamespaceLabel is left", func() {
+		f.createNamespace("multi", map[string]string{"env": "prod", "team": "payments"}, map[string]string{
+			appliedAnnoKey:                          "{}",
+			appliedAnnoKey + ".payments":            `[{"key":"team","value":"payments"}]`,
+			appliedAnnotationsAnnoKey + ".payments": "{}",
+		})
+
+		sweeper.sweep(ctx)
+		fakeClock.Step(time.Hour)
+		sweeper.sweep(ctx)
+		ns := f.getNamespace("multi")
+		Expect(ns.Labels).To(Equal(map[string]string{"env": "prod"}))
+		Expect(ns.Annotations).To(BeEmpty())
+	})
+
+	It("should clean up selector tracking only once no selector NamespaceLabel is left", func() {
+		f.createNamespace("tenant", map[string]string{"env": "prod", "cost-center": "shared"}, map[string]string{
+			appliedAnnoKey:         `{"env":"prod"}`,
+			selectorAppliedAnnoKey: `{"cost-center":"shared"}`,
+		})
+		f.createCR(StandardCRName, "tenant", nil, nil, labelsv1alpha1.NamespaceLabelSpec{})
+		selectorCR := f.createCR(StandardCRName, "platform", nil, nil, labelsv1alpha1.NamespaceLabelSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+		})
+
+		sweeper.sweep(ctx)
+		Expect(f.getNamespace("tenant").Annotations).NotTo(HaveKey(orphanedSinceAnnoKey))
+
+		By("deleting the selector NamespaceLabel")
+		Expect(fakeClient.Delete(ctx, selectorCR)).To(Succeed())
+		sweeper.sweep(ctx)
+		fakeClock.Step(time.Hour)
+		sweeper.sweep(ctx)
+		ns := f.getNamespace("tenant")
+		Expect(ns.Labels).To(Equal(map[string]string{"env": "prod"}))
+		Expect(ns.Annotations).To(Equal(map[string]string{appliedAnnoKey: `{"env":"prod"}`}))
+	})
+
+	It("should charge its namespace updates to the write budget", func() {
+		sweeper.WriteBudget = NewWriteBudget(1, 1)
+		f.createNamespace("orphan", map[string]string{"env": "prod"}, map[string]string{appliedAnnoKey: `{"env":"prod"}`})
+
+		sweeper.sweep(ctx)
+		Expect(f.getNamespace("orphan").Annotations).To(HaveKey(orphanedSinceAnnoKey))
+		Expect(sweeper.WriteBudget.acquire(reconcileRequest(StandardCRName, "other").NamespacedName, fakeClock.Now())).
+			To(BeNumerically(">", 0))
+	})
+})
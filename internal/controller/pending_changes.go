@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
+)
+
+// lastAppliedSpecKey returns the CR annotation key holding the last applied spec labels
+func (r *NamespaceLabelReconciler) lastAppliedSpecKey() string {
+	if r.LastAppliedSpecAnnotation != "" {
+		return r.LastAppliedSpecAnnotation
+	}
+	return LastAppliedSpecAnnoKey
+}
+
+// readLastAppliedSpec returns the spec labels snapshotted under key at the last successful apply.
+// A missing or unreadable snapshot is treated as empty, so every spec label shows up as an addition.
+func readLastAppliedSpec(cr *labelsv1alpha1.NamespaceLabel, key string) map[string]string {
+	snapshot := map[string]string{}
+	if raw := cr.Annotations[key]; raw != "" {
+		_ = json.Unmarshal([]byte(raw), &snapshot)
+	}
+	return snapshot
+}
+
+// pendingChanges diffs the current spec labels against the last applied ones, sorted by key
+func pendingChanges(lastApplied, desired map[string]string) []labelsv1alpha1.LabelChange {
+	var changes []labelsv1alpha1.LabelChange
+	for key, value := range desired {
+		old, existed := lastApplied[key]
+		switch {
+		case !existed:
+			changes = append(changes, labelsv1alpha1.LabelChange{Key: key, Action: labelsv1alpha1.LabelChangeAdd, NewValue: value})
+		case old != value:
+			changes = append(changes, labelsv1alpha1.LabelChange{Key: key, Action: labelsv1alpha1.LabelChangeUpdate, OldValue: old, NewValue: value})
+		}
+	}
+	for key, old := range lastApplied {
+		if _, wanted := desired[key]; !wanted {
+			changes = append(changes, labelsv1alpha1.LabelChange{Key: key, Action: labelsv1alpha1.LabelChangeRemove, OldValue: old})
+		}
+	}
+	sortLabelChanges(changes)
+	return changes
+}
+
+// recordLastAppliedSpec snapshots the spec labels on the CR after a successful apply,
+// so a later dry run can report what the new revision would change
+func (r *NamespaceLabelReconciler) recordLastAppliedSpec(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel) error {
+	labels := webhookv1alpha1.SpecLabels(cr.Spec)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	raw, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+	key := r.lastAppliedSpecKey()
+	if cr.Annotations[key] == string(raw) {
+		return nil
+	}
+	if cr.Annotations == nil {
+		cr.Annotations = map[string]string{}
+	}
+	cr.Annotations[key] = string(raw)
+
+	// Keep the computed status across the metadata update, which returns the stored status
+	status := cr.Status.DeepCopy()
+	if err := r.Update(ctx, cr); err != nil {
+		return err
+	}
+	cr.Status = *status
+	return nil
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	"github.com/sbahar619/namespace-label-operator/internal/protection"
+)
+
+const (
+	// ProtectionStatusLabelValue is the value of the protection status label on protected namespaces
+	ProtectionStatusLabelValue = "enabled"
+	// labelSourceOperator marks a label the operator adds on its own rather than from the spec
+	labelSourceOperator = "operator"
+)
+
+// hasActiveProtection reports whether any protection applies to the namespace: protected key patterns or
+// rules, including those of the namespace's tier, protected values, or removal protection
+func hasActiveProtection(spec labelsv1alpha1.NamespaceLabelSpec, nsLabels map[string]string) bool {
+	return len(protection.ActivePatterns(spec, nsLabels)) > 0 ||
+		len(spec.ProtectedLabelRules) > 0 ||
+		len(spec.ProtectedValuePatterns) > 0 ||
+		len(spec.RemovalProtectionPatterns) > 0
+}
+
+// protectionStatusLabels returns the protection status label when key is set and the CR protects the namespace.
+// It is tracked like any other managed label, so it is removed once protection is dropped or the CR is deleted.
+func protectionStatusLabels(key string, spec labelsv1alpha1.NamespaceLabelSpec, nsLabels map[string]string) map[string]string {
+	if key == "" || !hasActiveProtection(spec, nsLabels) {
+		return nil
+	}
+	return map[string]string{key: ProtectionStatusLabelValue}
+}
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// reconcileHealth tracks when a reconcile last succeeded, shared by every reconcile worker
+type reconcileHealth struct {
+	// lastSuccess is the UnixNano time of the last successful reconcile, 0 before the first
+	lastSuccess atomic.Int64
+}
+
+// recordSuccessfulReconcile marks now as the last time a NamespaceLabel was reconciled successfully
+func (r *NamespaceLabelReconciler) recordSuccessfulReconcile() {
+	r.health.lastSuccess.Store(r.now().UnixNano())
+}
+
+// ReconcileHealthCheck returns a healthz checker that fails once no reconcile has succeeded within window while
+// NamespaceLabels exist, so a liveness probe restarts a stuck controller. Periodic resync must be shorter than
+// window, or an idle but healthy controller looks stuck. A replica reports healthy until elected is closed, since
+// only the leader reconciles, and the window starts again when it first sees itself elected.
+func (r *NamespaceLabelReconciler) ReconcileHealthCheck(window time.Duration, elected <-chan struct{}) healthz.Checker {
+	var electedAt atomic.Int64
+	return func(req *http.Request) error {
+		select {
+		case <-elected:
+		default:
+			return nil
+		}
+		now := r.now()
+		electedAt.CompareAndSwap(0, now.UnixNano())
+		since := now.Sub(time.Unix(0, max(r.health.lastSuccess.Load(), electedAt.Load())))
+		if since <= window {
+			return nil
+		}
+
+		var list labelsv1alpha1.NamespaceLabelList
+		if err := r.List(req.Context(), &list, client.Limit(1)); err != nil {
+			return fmt.Errorf("failed to list NamespaceLabels: %w", err)
+		}
+		if len(list.Items) == 0 {
+			return nil
+		}
+		return fmt.Errorf("no successful reconcile for %s, exceeding the %s staleness window", since.Round(time.Second), window)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// Tests for functions in reconcile_health.go
+
+var _ = Describe("ReconcileHealthCheck", Label("controller"), func() {
+	var (
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		fakeClock  *clocktesting.FakeClock
+		elected    chan struct{}
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		f := newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+		fakeClock = clocktesting.NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+		reconciler.Clock = fakeClock
+		elected = make(chan struct{})
+	})
+
+	It("should fail once reconciles go stale while NamespaceLabels exist", func() {
+		checker := reconciler.ReconcileHealthCheck(10*time.Minute, elected)
+		check := func() error { return checker(httptest.NewRequest("GET", "/healthz", nil)) }
+
+		By("staying healthy before this replica is elected")
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "test-ns", Finalizers: []string{FinalizerName}},
+			Spec:       labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"env": "prod"}},
+		})).To(Succeed())
+		fakeClock.Step(time.Hour)
+		Expect(check()).To(Succeed())
+
+		By("starting the window at election")
+		close(elected)
+		Expect(check()).To(Succeed())
+		fakeClock.Step(11 * time.Minute)
+		err := check()
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("no successful reconcile for 11m0s"))
+
+		By("recovering after a successful reconcile")
+		_, err = reconciler.Reconcile(ctx, reconcileRequest(StandardCRName, "test-ns"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(check()).To(Succeed())
+		fakeClock.Step(9 * time.Minute)
+		Expect(check()).To(Succeed())
+		fakeClock.Step(2 * time.Minute)
+		Expect(check()).NotTo(Succeed())
+	})
+
+	It("should count reconciles halted by the kill switch as successful", func() {
+		close(elected)
+		checker := reconciler.ReconcileHealthCheck(10*time.Minute, elected)
+		check := func() error { return checker(httptest.NewRequest("GET", "/healthz", nil)) }
+
+		Expect(fakeClient.Create(ctx, &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: crdName, Annotations: map[string]string{KillSwitchAnnoKey: "true"}},
+		})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "test-ns", Finalizers: []string{FinalizerName}},
+			Spec:       labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"env": "prod"}},
+		})).To(Succeed())
+
+		Expect(check()).To(Succeed())
+		fakeClock.Step(11 * time.Minute)
+		Expect(check()).NotTo(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcileRequest(StandardCRName, "test-ns"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(check()).To(Succeed())
+	})
+
+	It("should stay healthy without NamespaceLabels", func() {
+		close(elected)
+		checker := reconciler.ReconcileHealthCheck(time.Minute, elected)
+		Expect(checker(httptest.NewRequest("GET", "/healthz", nil))).To(Succeed())
+		fakeClock.Step(time.Hour)
+		Expect(checker(httptest.NewRequest("GET", "/healthz", nil))).To(Succeed())
+	})
+})
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	"github.com/sbahar619/namespace-label-operator/internal/protection"
+)
+
+// reconcileStats counts the outcome of one reconcile of cr: the labels its plan requested, applied, skipped and
+// failed on, the removals among changes, and the labels in nsLabels its key protection covers
+func reconcileStats(cr *labelsv1alpha1.NamespaceLabel, plan labelPlan, nsLabels map[string]string,
+	changes []labelsv1alpha1.LabelChange) *labelsv1alpha1.ReconcileStats {
+	// Every filter before protection drops keys from desired, so they are added back to count the request
+	requested := len(plan.desired) + len(plan.disallowed) + len(plan.outsideCharset) + len(plan.overwriteConflicts) +
+		len(plan.claimed)
+
+	var removed int32
+	for _, change := range changes {
+		if change.Action == labelsv1alpha1.LabelChangeRemove {
+			removed++
+		}
+	}
+
+	matchers := protection.CompileRules(cr.Spec.ProtectedLabelRules, plan.protectionPatterns, cr.Spec.ProtectionMode)
+	var protected int32
+	for key := range nsLabels {
+		if _, ok := protection.Protecting(key, matchers); ok {
+			protected++
+		}
+	}
+
+	// A fail-mode conflict fails the whole reconcile, so nothing is applied
+	applied := len(plan.protection.AllowedLabels)
+	if plan.protection.ShouldFail {
+		applied = 0
+	}
+
+	return &labelsv1alpha1.ReconcileStats{
+		Requested: int32(requested),
+		Applied:   int32(applied),
+		Skipped:   int32(len(plan.protection.ProtectedSkipped)),
+		Failed:    int32(len(plan.protection.ConflictingKeys)),
+		Removed:   removed,
+		Protected: protected,
+	}
+}
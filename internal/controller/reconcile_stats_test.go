@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+)
+
+// Tests for functions in reconcile_stats.go
+
+var _ = Describe("Reconcile stats", Label("controller"), func() {
+	var f *testFixture
+
+	BeforeEach(func() {
+		f = newTestFixture()
+	})
+
+	reconcileStatsOf := func(nsLabels, nsAnnotations map[string]string, spec labelsv1alpha1.NamespaceLabelSpec) *labelsv1alpha1.ReconcileStats {
+		f.createNamespace("test-ns", nsLabels, nsAnnotations)
+		cr, _, _ := f.reconcileSpec("test-ns", spec)
+		return cr.Status.Stats
+	}
+
+	It("should count the protection and apply results of a successful reconcile", func() {
+		stats := reconcileStatsOf(
+			map[string]string{"team": "platform", "owner": "infra", "old": "x"},
+			map[string]string{appliedAnnoKey: `{"old":"x"}`},
+			labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"env": "prod", "team": "web", "tier": "gold", "debug": "on"},
+				ProtectedLabelPatterns: []string{"team", "owner"},
+				AllowedLabelPatterns:   []string{"env", "team", "tier"},
+			})
+
+		Expect(stats).To(Equal(&labelsv1alpha1.ReconcileStats{
+			Requested: 4,
+			Applied:   2,
+			Skipped:   1,
+			Failed:    0,
+			Removed:   1,
+			Protected: 2,
+		}))
+	})
+
+	It("should count the conflicts of a fail-mode reconcile as failed", func() {
+		stats := reconcileStatsOf(map[string]string{"team": "platform"}, nil, labelsv1alpha1.NamespaceLabelSpec{
+			Labels:                 map[string]string{"env": "prod", "team": "web"},
+			ProtectedLabelPatterns: []string{"team"},
+			ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+		})
+
+		Expect(stats).To(Equal(&labelsv1alpha1.ReconcileStats{
+			Requested: 2,
+			Applied:   0,
+			Skipped:   0,
+			Failed:    1,
+			Removed:   0,
+			Protected: 1,
+		}))
+	})
+})
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// checkSpecValidation re-runs the webhook's spec validation at reconcile time. A passing spec is stamped
+// with the validated annotation carrying the generation it was checked at, so CRs admitted while the
+// webhook was off can be told apart from ones that have been confirmed. The result is reported in the
+// SpecValidated condition; an invalid spec is reported but not blocked here. Without a SpecValidator only
+// the checks that do not depend on webhook flags are run.
+func (r *NamespaceLabelReconciler) checkSpecValidation(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel) error {
+	validator := r.SpecValidator
+	if validator == nil {
+		validator = &webhookv1alpha1.NamespaceLabelCustomValidator{Client: r.Client}
+	}
+	if err := validator.ValidateSpec(ctx, cr); err != nil {
+		log.FromContext(ctx).Info("NamespaceLabel spec failed validation", "namespace", cr.Namespace, "error", err.Error())
+		setCondition(cr, ConditionSpecValidated, metav1.ConditionFalse, "ValidationFailed", err.Error())
+		return nil
+	}
+
+	setCondition(cr, ConditionSpecValidated, metav1.ConditionTrue, "Validated", "Spec passed validation")
+
+	generation := strconv.FormatInt(cr.Generation, 10)
+	if cr.Annotations[ValidatedAnnoKey] == generation {
+		return nil
+	}
+	if cr.Annotations == nil {
+		cr.Annotations = map[string]string{}
+	}
+	cr.Annotations[ValidatedAnnoKey] = generation
+
+	// Keep the computed status across the metadata update, which returns the stored status
+	status := cr.Status.DeepCopy()
+	if err := r.Update(ctx, cr); err != nil {
+		return err
+	}
+	cr.Status = *status
+	return nil
+}
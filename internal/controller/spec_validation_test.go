@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
+)
+
+// Tests for functions in spec_validation.go
+
+var _ = Describe("Spec validation at reconcile", Label("controller"), func() {
+	var f *testFixture
+
+	BeforeEach(func() {
+		f = newTestFixture()
+		f.createNamespace("test-ns", nil, nil)
+	})
+
+	reconcileCR := func(spec labelsv1alpha1.NamespaceLabelSpec) *labelsv1alpha1.NamespaceLabel {
+		cr, _, err := f.reconcileSpec("test-ns", spec)
+		Expect(err).NotTo(HaveOccurred())
+		return cr
+	}
+
+	It("should stamp a valid spec with the validated generation", func() {
+		cr := reconcileCR(labelsv1alpha1.NamespaceLabelSpec{
+			Labels:                 map[string]string{"env": "prod"},
+			ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+		})
+
+		Expect(cr.Annotations).To(HaveKeyWithValue(ValidatedAnnoKey, strconv.FormatInt(cr.Generation, 10)))
+		Expect(meta.IsStatusConditionTrue(cr.Status.Conditions, ConditionSpecValidated)).To(BeTrue())
+		Expect(cr.Status.Applied).To(BeTrue())
+	})
+
+	It("should report an unvalidated spec admitted while the webhook was off", func() {
+		cr := reconcileCR(labelsv1alpha1.NamespaceLabelSpec{
+			Labels:                 map[string]string{"env": "prod"},
+			ProtectedLabelPatterns: []string{"regex:^(unclosed"},
+		})
+
+		Expect(cr.Annotations).NotTo(HaveKey(ValidatedAnnoKey))
+		cond := meta.FindStatusCondition(cr.Status.Conditions, ConditionSpecValidated)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("invalid regex protection pattern"))
+	})
+
+	It("should report label keys that collide after normalization", func() {
+		cr := reconcileCR(labelsv1alpha1.NamespaceLabelSpec{
+			Labels: map[string]string{"Env": "prod", "env": "dev", "team": "a"},
+		})
+
+		cond := meta.FindStatusCondition(cr.Status.Conditions, ConditionSpecValidated)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("differ only by case or whitespace: Env, env"))
+	})
+
+	It("should run the flag-dependent checks with the configured validator", func() {
+		f.reconciler.SpecValidator = &webhookv1alpha1.NamespaceLabelCustomValidator{
+			Client:    f.client,
+			MaxLabels: 1,
+		}
+		cr := reconcileCR(labelsv1alpha1.NamespaceLabelSpec{
+			Labels: map[string]string{"env": "prod", "team": "a"},
+		})
+
+		Expect(cr.Annotations).NotTo(HaveKey(ValidatedAnnoKey))
+		cond := meta.FindStatusCondition(cr.Status.Conditions, ConditionSpecValidated)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("too many labels: 2 exceeds limit 1"))
+	})
+})
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
+
+// StateArchiver records the labels the operator managed on a namespace once the namespace is deleted
+type StateArchiver interface {
+	Archive(ctx context.Context, namespace string, labels map[string]string) error
+}
+
+// ConfigMapArchiver keeps the final managed labels of every deleted namespace in a single ConfigMap,
+// one JSON entry per namespace name. The ConfigMap is created on first use.
+type ConfigMapArchiver struct {
+	Client    client.Client
+	Namespace string
+	Name      string
+}
+
+// Archive stores labels under the namespace's key, replacing an entry left by an earlier namespace of the same name
+func (a *ConfigMapArchiver) Archive(ctx context.Context, namespace string, labels map[string]string) error {
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+
+	var cm corev1.ConfigMap
+	err = a.Client.Get(ctx, types.NamespacedName{Namespace: a.Namespace, Name: a.Name}, &cm)
+	if apierrors.IsNotFound(err) {
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: a.Namespace, Name: a.Name},
+			Data:       map[string]string{namespace: string(data)},
+		}
+		return a.Client.Create(ctx, &cm)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get archive ConfigMap '%s/%s': %w", a.Namespace, a.Name, err)
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[namespace] = string(data)
+	return a.Client.Update(ctx, &cm)
+}
+
+// archiveDeletedNamespace hands the labels recorded in a deleted namespace's applied annotation to the StateArchiver.
+// Nothing is enqueued; the CR was already finalized while the namespace was terminating.
+func (r *NamespaceLabelReconciler) archiveDeletedNamespace(ctx context.Context, e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	ns, ok := e.Object.(*corev1.Namespace)
+	if !ok || r.StateArchiver == nil {
+		return
+	}
+
+	applied := readAppliedAnnotation(ns)
+	dropPhantomEntries(applied, ns.Labels)
+	if len(applied) == 0 {
+		return
+	}
+	managed := make(map[string]string, len(applied))
+	for key := range applied {
+		managed[key] = ns.Labels[key]
+	}
+
+	if err := r.StateArchiver.Archive(ctx, ns.Name, managed); err != nil {
+		log.FromContext(ctx).Error(err, "failed to archive managed labels of deleted namespace", "namespace", ns.Name)
+	}
+}
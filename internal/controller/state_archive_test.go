@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in state_archive.go
+
+var _ = Describe("State archive", Label("controller"), func() {
+	var (
+		f          *testFixture
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		ctx        context.Context
+	)
+
+	archiveKey := types.NamespacedName{Namespace: "operator-system", Name: "namespace-archive"}
+
+	BeforeEach(func() {
+		f = newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+		reconciler.StateArchiver = &ConfigMapArchiver{Client: fakeClient, Namespace: archiveKey.Namespace, Name: archiveKey.Name}
+	})
+
+	archived := func() map[string]string {
+		var cm corev1.ConfigMap
+		Expect(fakeClient.Get(ctx, archiveKey, &cm)).To(Succeed())
+		return cm.Data
+	}
+
+	It("should archive the managed labels when the namespace is deleted", func() {
+		request := reconcileRequest(StandardCRName, "test-ns")
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-ns",
+			Labels:     map[string]string{"owner": "platform"},
+			Finalizers: []string{"kubernetes"},
+		}})).To(Succeed())
+		cr := f.createCR(StandardCRName, "test-ns", nil, []string{FinalizerName},
+			labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"env": "prod", "team": "payments"}})
+		_, err := reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("deleting the namespace, which finalizes the CR while the namespace terminates")
+		var ns corev1.Namespace
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &ns)).To(Succeed())
+		Expect(fakeClient.Delete(ctx, &ns)).To(Succeed())
+		Expect(fakeClient.Delete(ctx, cr)).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Name: "test-ns"}, &ns)).To(Succeed())
+		Expect(ns.Labels).To(HaveKeyWithValue("env", "prod"))
+
+		reconciler.archiveDeletedNamespace(ctx, event.DeleteEvent{Object: &ns}, nil)
+		Expect(archived()).To(HaveKeyWithValue("test-ns", `{"env":"prod","team":"payments"}`))
+	})
+
+	It("should add entries to an existing archive and skip unmanaged namespaces", func() {
+		Expect(fakeClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: archiveKey.Namespace, Name: archiveKey.Name},
+			Data:       map[string]string{"old-ns": `{"env":"dev"}`},
+		})).To(Succeed())
+
+		reconciler.archiveDeletedNamespace(ctx, event.DeleteEvent{Object: &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Labels: map[string]string{"env": "prod"}},
+		}}, nil)
+		reconciler.archiveDeletedNamespace(ctx, event.DeleteEvent{Object: &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-ns",
+				Labels:      map[string]string{"env": "prod", "owner": "platform"},
+				Annotations: map[string]string{appliedAnnoKey: `{"env":"prod","gone":"yes"}`},
+			},
+		}}, nil)
+
+		Expect(archived()).To(Equal(map[string]string{
+			"old-ns":  `{"env":"dev"}`,
+			"test-ns": `{"env":"prod"}`,
+		}))
+	})
+})
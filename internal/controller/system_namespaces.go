@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/sbahar619/namespace-label-operator/internal/protection"
+)
+
+// enforceSystemNamespaceProtection applies the built-in policy of system namespaces on top of the CR's protection:
+// changing the value of a kubernetes.io label there is a fail-mode conflict. The CR's patterns, exceptions and
+// mode are not consulted, so they can only add to this protection.
+func enforceSystemNamespaceProtection(result *ProtectionResult, nsName string, desired, existing map[string]string) {
+	if !protection.IsSystemNamespace(nsName) {
+		return
+	}
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		existingValue, hasExisting := existing[key]
+		if !hasExisting || existingValue == desired[key] {
+			continue
+		}
+		matcher, protected := protection.First(key, protection.SystemProtectedLabels)
+		if !protected {
+			continue
+		}
+		result.ShouldFail = true
+		if slices.Contains(result.ConflictingKeys, key) {
+			continue
+		}
+		if result.Reasons == nil {
+			result.Reasons = map[string]string{}
+		}
+		result.Reasons[key] = matcher.Pattern
+		delete(result.AllowedLabels, key)
+		result.ProtectedSkipped = slices.DeleteFunc(result.ProtectedSkipped, func(k string) bool { return k == key })
+		result.ConflictingKeys = append(result.ConflictingKeys, key)
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"Label '%s' is protected in system namespace '%s' and has existing value '%s' (attempting to set '%s')",
+			key, nsName, existingValue, desired[key]))
+	}
+	sort.Strings(result.ConflictingKeys)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in system_namespaces.go
+
+var _ = Describe("System namespace protection", Label("controller"), func() {
+	var (
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		f := newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+	})
+
+	// reconcileNamespace labels nsName with node.kubernetes.io/pool=a and reconciles a CR setting it to b
+	// under skip-mode protection that excepts the key
+	reconcileNamespace := func(nsName string) (string, error) {
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: nsName, Labels: map[string]string{"node.kubernetes.io/pool": "a"},
+		}})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: nsName, Finalizers: []string{FinalizerName}},
+			Spec: labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"node.kubernetes.io/pool": "b", "team": "platform"},
+				ProtectedLabelPatterns: []string{"*", "!node.kubernetes.io/*"},
+				AllowMatchAll:          true,
+				ProtectionMode:         labelsv1alpha1.ProtectionModeSkip,
+			},
+		})).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcileRequest(StandardCRName, nsName))
+		var ns corev1.Namespace
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: nsName}, &ns)).To(Succeed())
+		return ns.Labels["node.kubernetes.io/pool"], err
+	}
+
+	It("should fail on kubernetes.io label changes in a system namespace despite the CR's settings", func() {
+		value, err := reconcileNamespace("kube-public")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("a"))
+
+		var cr labelsv1alpha1.NamespaceLabel
+		Expect(fakeClient.Get(ctx, types.NamespacedName{Name: StandardCRName, Namespace: "kube-public"}, &cr)).To(Succeed())
+		Expect(cr.Status.Applied).To(BeFalse())
+		ready := meta.FindStatusCondition(cr.Status.Conditions, "Ready")
+		Expect(ready.Reason).To(Equal("ProtectedLabelConflict"))
+		Expect(ready.Message).To(ContainSubstring("protected in system namespace 'kube-public'"))
+	})
+
+	It("should leave other namespaces to the CR's protection", func() {
+		value, err := reconcileNamespace("apps")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("b"))
+	})
+
+	It("should escalate a label the CR skips to a conflict", func() {
+		result := ProtectionResult{
+			AllowedLabels:    map[string]string{"team": "platform"},
+			ProtectedSkipped: []string{"kubernetes.io/os"},
+		}
+		enforceSystemNamespaceProtection(&result, "kube-system",
+			map[string]string{"kubernetes.io/os": "windows", "team": "platform", "kubernetes.io/new": "x"},
+			map[string]string{"kubernetes.io/os": "linux"})
+		Expect(result.ShouldFail).To(BeTrue())
+		Expect(result.ConflictingKeys).To(Equal([]string{"kubernetes.io/os"}))
+		Expect(result.ProtectedSkipped).To(BeEmpty())
+		Expect(result.Reasons).To(Equal(map[string]string{"kubernetes.io/os": "kubernetes.io/*"}))
+		Expect(result.AllowedLabels).To(Equal(map[string]string{"team": "platform"}))
+	})
+})
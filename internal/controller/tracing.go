@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// tracerName is the instrumentation scope of the controller's spans
+const tracerName = "github.com/sbahar619/namespace-label-operator/internal/controller"
+
+// startReconcileSpan starts the root span of a reconcile with the reconciler's TracerProvider
+func (r *NamespaceLabelReconciler) startReconcileSpan(ctx context.Context, req ctrl.Request) (context.Context, trace.Span) {
+	provider := r.TracerProvider
+	if provider == nil {
+		provider = noop.NewTracerProvider()
+	}
+	return provider.Tracer(tracerName).Start(ctx, "Reconcile", trace.WithAttributes(
+		attribute.String("namespacelabel.namespace", req.Namespace),
+		attribute.String("namespacelabel.name", req.Name),
+	))
+}
+
+// startSpan starts a child of the span in ctx from the same provider, so helpers without the reconciler at hand
+// still join the reconcile's trace. Without a span in ctx the returned span is a no-op.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(tracerName).Start(ctx, name)
+}
+
+// endSpan marks span as failed with err, if any, and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
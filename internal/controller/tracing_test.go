@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in tracing.go
+
+var _ = Describe("Reconcile tracing", Label("controller"), func() {
+	var (
+		reconciler *NamespaceLabelReconciler
+		fakeClient client.Client
+		recorder   *tracetest.SpanRecorder
+		ctx        context.Context
+	)
+
+	BeforeEach(func() {
+		f := newTestFixture()
+		reconciler, fakeClient, ctx = f.reconciler, f.client, f.ctx
+		recorder = tracetest.NewSpanRecorder()
+		reconciler.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	})
+
+	spanNames := func() []string {
+		var names []string
+		for _, span := range recorder.Ended() {
+			names = append(names, span.Name())
+		}
+		return names
+	}
+
+	It("should trace the phases of a reconcile under one root span", func() {
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}})).To(Succeed())
+		Expect(fakeClient.Create(ctx, &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "test-ns", Finalizers: []string{FinalizerName}},
+			Spec:       labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"env": "prod"}},
+		})).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcileRequest(StandardCRName, "test-ns"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(spanNames()).To(ContainElements("Reconcile", "processNamespaceLabels", "getTargetNamespace",
+			"applyProtectionLogic", "patchNamespace"))
+		spans := recorder.Ended()
+		root := spans[len(spans)-1]
+		Expect(root.Name()).To(Equal("Reconcile"))
+		for _, span := range spans {
+			Expect(span.SpanContext().TraceID()).To(Equal(root.SpanContext().TraceID()))
+		}
+	})
+
+	It("should trace finalization of a deleted CR", func() {
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}})).To(Succeed())
+		cr := &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "test-ns", Finalizers: []string{FinalizerName}},
+			Spec:       labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"env": "prod"}},
+		}
+		Expect(fakeClient.Create(ctx, cr)).To(Succeed())
+		Expect(fakeClient.Delete(ctx, cr)).To(Succeed())
+
+		_, err := reconciler.Reconcile(ctx, reconcileRequest(StandardCRName, "test-ns"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spanNames()).To(ContainElements("Reconcile", "finalize"))
+	})
+
+	It("should record a failed reconcile on the root span", func() {
+		_, err := reconciler.Reconcile(ctx, reconcileRequest(StandardCRName, ""))
+		Expect(err).To(HaveOccurred())
+		spans := recorder.Ended()
+		root := spans[len(spans)-1]
+		Expect(root.Name()).To(Equal("Reconcile"))
+		Expect(root.Status().Code.String()).To(Equal("Error"))
+	})
+
+	It("should not trace without a TracerProvider", func() {
+		reconciler.TracerProvider = nil
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}})).To(Succeed())
+		_, err := reconciler.Reconcile(ctx, reconcileRequest(StandardCRName, "test-ns"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorder.Ended()).To(BeEmpty())
+	})
+})
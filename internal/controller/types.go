@@ -1,20 +1,214 @@
 package controller
 
 import (
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
 )
 
 const (
-	appliedAnnoKey = "labels.shahaf.com/applied" // JSON of map[string]string
-	FinalizerName  = "labels.shahaf.com/finalizer"
-	StandardCRName = "labels" // Standard name for NamespaceLabel CRs (singleton pattern)
+	appliedAnnoKey = "labels.shahaf.com/applied"   // JSON of map[string]string or ordered []appliedEntry
+	FinalizerName  = webhookv1alpha1.FinalizerName // Also injected at creation by the defaulting webhook
+	StandardCRName = "labels"                      // Standard name for NamespaceLabel CRs (singleton pattern)
+
+	// ValidatedAnnoKey records the CR generation whose spec the controller last confirmed as valid
+	ValidatedAnnoKey = "labels.shahaf.com/validated"
+	// LastAppliedSpecAnnoKey snapshots the spec labels of the last successful apply as JSON
+	LastAppliedSpecAnnoKey = "labels.shahaf.com/last-applied-spec"
+	// OwnerUIDAnnoKey on a namespace carries the UID of the NamespaceLabel managing its labels, since a
+	// cluster-scoped namespace cannot hold an owner reference to a namespaced CR
+	OwnerUIDAnnoKey = "labels.shahaf.com/owner-uid"
+	// ChangelogAnnoKey on a namespace lists its most recent label changes as JSON, oldest first
+	ChangelogAnnoKey = "labels.shahaf.com/changelog"
+
+	// appliedAnnotationsAnnoKey tracks namespace annotations applied from spec.annotations, in the same formats
+	appliedAnnotationsAnnoKey = "labels.shahaf.com/applied-annotations"
+	// orphanedSinceAnnoKey records when the orphan sweeper found tracking annotations without a NamespaceLabel
+	orphanedSinceAnnoKey = "labels.shahaf.com/orphaned-since"
+	// selectorAppliedAnnoKey tracks labels applied by the spec.namespaceSelector NamespaceLabel, kept apart
+	// from appliedAnnoKey so they never mix with the labels of the namespace's own CR
+	selectorAppliedAnnoKey = "labels.shahaf.com/selector-applied"
+
+	// KillSwitchAnnoKey halts all label operations cluster-wide when set to "true" on the NamespaceLabel CRD
+	KillSwitchAnnoKey = "labels.shahaf.com/kill-switch"
+	crdName           = "namespacelabels.labels.shahaf.com"
+
+	// IgnoreAnnoKey on a namespace set to "true" opts it out of management: no NamespaceLabel mutates it
+	IgnoreAnnoKey = "labels.shahaf.com/ignore"
+
+	ConditionKillSwitchActive = "KillSwitchActive"
+	// ConditionHashLabelsResolved is set to False while a referenced ConfigMap is missing
+	ConditionHashLabelsResolved = "HashLabelsResolved"
+	// ConditionInheritedLabelsResolved is set to False while the spec.inheritFrom namespace is missing
+	ConditionInheritedLabelsResolved = "InheritedLabelsResolved"
+	// ConditionLabelTemplatesRendered is set to False while a templated label value cannot be rendered
+	ConditionLabelTemplatesRendered = "LabelTemplatesRendered"
+	// ConditionLabelValuesInCharset is set to False while label values outside ValueCharset are held back
+	ConditionLabelValuesInCharset = "LabelValuesInCharset"
+	// ConditionLabelKeysValid is set to False while labels with an empty key, which no namespace can store, are dropped
+	ConditionLabelKeysValid = "LabelKeysValid"
+	// ConditionListLabelsMerged is set to False while a list-merge label is held at its namespace value because
+	// merging would produce an invalid label value
+	ConditionListLabelsMerged = "ListLabelsMerged"
+	// ConditionNearLabelLimit is set while the namespace label count is within the warning margin of the limit
+	ConditionNearLabelLimit = "NearLabelLimit"
+	// ConditionPendingDelayedApply is set while labels wait for spec.applyAfter to elapse
+	ConditionPendingDelayedApply = "PendingDelayedApply"
+	// ConditionValueMutatedExternally is set when label values read back after an update differ from what was written
+	ConditionValueMutatedExternally = "ValueMutatedExternally"
+	// ConditionNamespaceOptedOut is set while the namespace carries IgnoreAnnoKey and is left untouched
+	ConditionNamespaceOptedOut = "NamespaceOptedOut"
+	// ConditionProtectionConflict is True while fail-mode protection blocks the apply; Ready stays the overall health
+	ConditionProtectionConflict = "ProtectionConflict"
+	// ConditionApplyWouldBeRejected is True while a dry run of the namespace update is refused by admission
+	ConditionApplyWouldBeRejected = "ApplyWouldBeRejected"
+	// ConditionNamespaceNotReady is set while spec.conditionalOnNamespace is unmet and labels are held back
+	ConditionNamespaceNotReady = "NamespaceNotReady"
+	// ConditionSpecValidated reports whether the spec passed the webhook's validation when re-checked at reconcile
+	ConditionSpecValidated = "SpecValidated"
+	// ConditionExpired is True once spec.ttlSeconds has elapsed and the applied labels were removed
+	ConditionExpired = "Expired"
+	// ConditionLabelsExported is False when the applied labels could not be written to spec.exportConfigMap
+	ConditionLabelsExported = "LabelsExported"
+
+	// defaultFailRequeueInterval is used when FailRequeueInterval is unset
+	defaultFailRequeueInterval = 5 * time.Minute
+	// defaultMaxFailRequeueInterval caps the fail requeue backoff when MaxFailRequeueInterval is unset
+	defaultMaxFailRequeueInterval = time.Hour
+
+	// eventSourceLabel links a NamespaceLabelEvent or exported ConfigMap to the NamespaceLabel that produced it
+	eventSourceLabel = "labels.shahaf.com/namespacelabel"
+)
+
+// MandatoryLabelPolicy decides what deleting a NamespaceLabel does to managed labels that are mandatory
+type MandatoryLabelPolicy string
+
+const (
+	// MandatoryLabelPolicyPreserve leaves mandatory labels on the namespace, no longer managed by the operator
+	MandatoryLabelPolicyPreserve MandatoryLabelPolicy = "preserve"
+	// MandatoryLabelPolicyWarn removes mandatory labels like any other, with a log line and a Warning event
+	MandatoryLabelPolicyWarn MandatoryLabelPolicy = "warn"
 )
 
 // NamespaceLabelReconciler reconciles a NamespaceLabel object
 type NamespaceLabelReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Clock is used for all time-based decisions. Defaults to the real clock when nil.
+	Clock clock.PassiveClock
+
+	// OrderedAppliedAnnotation stores the applied annotation as an ordered list of entries instead of a JSON map
+	OrderedAppliedAnnotation bool
+
+	// WriteBudget caps the rate of mutating API calls across all reconciles. Unlimited when nil.
+	WriteBudget *WriteBudget
+
+	// EventResources enables NamespaceLabelEvent audit records for applied/skipped/failed reconciles
+	EventResources bool
+	// EventResourceTTL is how long NamespaceLabelEvent records are kept before being cleaned up
+	EventResourceTTL time.Duration
+
+	// FailRequeueInterval is how long to wait before retrying after a protection-fail conflict. Defaults to 5 minutes.
+	FailRequeueInterval time.Duration
+	// MaxFailRequeueInterval caps the backoff of a CR that keeps conflicting, as FailRequeueInterval doubles with
+	// every consecutive conflict. Defaults to 1 hour.
+	MaxFailRequeueInterval time.Duration
+	// ResyncInterval requeues every successfully reconciled CR after this long unless its spec.reconcileInterval
+	// overrides it. 0 leaves periodic resync to the manager's cache sync period.
+	ResyncInterval time.Duration
+
+	// StatusUpdateRetries is how many times a conflicting status update is attempted. Defaults to retry.DefaultRetry.
+	StatusUpdateRetries int
+
+	// Recorder emits Kubernetes Events for the NamespaceLabel. Defaults to the manager's recorder.
+	Recorder record.EventRecorder
+	// AggregateWarningEvents emits one Warning event listing every skipped label instead of one event per label
+	AggregateWarningEvents bool
+
+	// OwnerUIDAnnotation records the managing CR's UID in OwnerUIDAnnoKey on its namespace
+	OwnerUIDAnnotation bool
+	// ChangelogEntries keeps this many of the latest label changes in ChangelogAnnoKey on the namespace.
+	// 0 disables the changelog.
+	ChangelogEntries int
+	// MandatoryLabelPatterns are glob or "regex:" patterns of labels every namespace must keep. Deleting a
+	// NamespaceLabel handles the matching labels it manages on its namespace according to MandatoryLabelPolicy.
+	MandatoryLabelPatterns []string
+	// MandatoryLabelPolicy is MandatoryLabelPolicyPreserve when empty
+	MandatoryLabelPolicy MandatoryLabelPolicy
+	// DefaultProtectedLabelPatterns are glob or "regex:" patterns protected on every namespace on top of each
+	// CR's own patterns. The CR's exceptions do not apply to them, so a NamespaceLabel can only add to them.
+	DefaultProtectedLabelPatterns []string
+
+	// ProtectionStatusLabel, when set, is added with ProtectionStatusLabelValue to every namespace whose CR
+	// configures protection, so dashboards can select protected namespaces. Empty disables the label.
+	ProtectionStatusLabel string
+
+	// ValueCharset restricts the characters of applied label values, matching the webhook's --label-value-charset.
+	// Rendered and inherited values are only known here, so labels with values outside it are not applied.
+	ValueCharset webhookv1alpha1.ValueCharset
+
+	// SpecValidator re-runs the webhook's spec validation at reconcile. It should be configured with the
+	// webhook's flags, so the limits it checks are the ones the webhook enforces.
+	SpecValidator *webhookv1alpha1.NamespaceLabelCustomValidator
+
+	// PreflightNamespaceUpdates server-side dry-runs every namespace update first. An update admission would
+	// refuse, e.g. by a policy on label combinations, is reported in ApplyWouldBeRejected and not attempted.
+	PreflightNamespaceUpdates bool
+
+	// LastAppliedSpecAnnotation is the CR annotation key that snapshots the spec labels of the last successful
+	// apply for dry-run diffs and external tooling. Defaults to LastAppliedSpecAnnoKey.
+	LastAppliedSpecAnnotation string
+
+	// MaxConcurrentReconciles is how many CRs are reconciled in parallel; 0 keeps controller-runtime's default of 1.
+	// The workqueue never hands the same CR to two workers, and every namespace write is either optimistically
+	// locked or a merge patch of a single key, so CRs sharing a namespace can safely race.
+	MaxConcurrentReconciles int
+
+	// MaxLabels is the namespace label count operators should stay under. 0 disables the warning.
+	MaxLabels int
+	// LabelLimitMargin is how close to MaxLabels the namespace may get before a warning is raised
+	LabelLimitMargin int
+
+	// MultipleNamespaceLabels lets a namespace hold several NamespaceLabels, matching the webhook's
+	// --allow-multiple-namespace-labels. Each tracks its labels under its own applied annotation, and a label
+	// several set is left to the one with the highest spec.priority, then the alphabetically first name.
+	MultipleNamespaceLabels bool
+
+	// SelectorNamespace is the only namespace whose NamespaceLabel may use spec.namespaceSelector to label
+	// other namespaces. Selectors are rejected everywhere when empty.
+	SelectorNamespace string
+
+	// InheritSourceNamespaces are the namespaces spec.inheritFrom may name, since inheriting reads another
+	// namespace's labels with the operator's cluster-wide access. Inheritance is refused everywhere when empty.
+	InheritSourceNamespaces []string
+
+	// ConditionalNamespaces are the namespaces, besides its own, spec.conditionalOnNamespace may name, since gating
+	// reads another namespace's labels with the operator's cluster-wide access. Any other never satisfies the gate.
+	ConditionalNamespaces []string
+
+	// StateArchiver receives the managed labels of each deleted namespace. Archiving is disabled when nil.
+	StateArchiver StateArchiver
+
+	// TracerProvider traces each reconcile and its phases. Tracing is disabled when nil.
+	TracerProvider trace.TracerProvider
+
+	// health records successful reconciles for ReconcileHealthCheck
+	health reconcileHealth
+	// failBackoff counts consecutive protection-fail conflicts per CR for failRequeueInterval
+	failBackoff failBackoff
+}
+
+// appliedEntry is a single label in the ordered applied annotation format
+type appliedEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
 }
 
 // ProtectionResult represents the result of applying protection logic
@@ -23,4 +217,10 @@ type ProtectionResult struct {
 	ProtectedSkipped []string
 	Warnings         []string
 	ShouldFail       bool
+	// ConflictingKeys are the sorted keys whose fail-mode conflict set ShouldFail
+	ConflictingKeys []string
+	// Reasons maps each skipped or conflicting key to the key or value pattern that protected it
+	Reasons map[string]string
+	// EmptyKeysDropped counts the desired labels dropped because their key is empty
+	EmptyKeysDropped int
 }
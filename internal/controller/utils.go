@@ -4,51 +4,199 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"path/filepath"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	"github.com/sbahar619/namespace-label-operator/internal/protection"
+	webhookv1alpha1 "github.com/sbahar619/namespace-label-operator/internal/webhook/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 func readAppliedAnnotation(ns *corev1.Namespace) map[string]string {
+	return readTrackingAnnotation(ns, appliedAnnoKey)
+}
+
+// readAppliedAnnotationsTracking returns the namespace annotations previously applied by the operator
+func readAppliedAnnotationsTracking(ns *corev1.Namespace) map[string]string {
+	return readTrackingAnnotation(ns, appliedAnnotationsAnnoKey)
+}
+
+// readTrackingAnnotation decodes a tracking annotation in either the JSON map or the ordered list format
+func readTrackingAnnotation(ns *corev1.Namespace, key string) map[string]string {
 	out := map[string]string{}
 	if ns.Annotations == nil {
 		return out
 	}
-	raw, ok := ns.Annotations[appliedAnnoKey]
+	raw, ok := ns.Annotations[key]
 	if !ok || raw == "" {
 		return out
 	}
+
+	// Ordered format is a JSON list of key/value entries
+	if strings.HasPrefix(raw, "[") {
+		var entries []appliedEntry
+		if err := json.Unmarshal([]byte(raw), &entries); err != nil {
+			return out
+		}
+		for _, e := range entries {
+			out[e.Key] = e.Value
+		}
+		return out
+	}
+
 	_ = json.Unmarshal([]byte(raw), &out)
 	return out
 }
 
-func writeAppliedAnnotation(ctx context.Context, c client.Client, ns *corev1.Namespace, applied map[string]string) error {
-	// Fetch a fresh copy of the namespace to avoid conflicts with the previously updated object
-	var freshNS corev1.Namespace
-	if err := c.Get(ctx, types.NamespacedName{Name: ns.Name}, &freshNS); err != nil {
-		return fmt.Errorf("failed to fetch namespace for annotation update: %w", err)
+// marshalApplied serializes applied labels either as a JSON map or as an ordered list of entries.
+// The ordered list follows order, the declaration order of spec.labelEntries, then lists the remaining
+// keys sorted, since spec.labels is a map, to stay stable across reconciles.
+func marshalApplied(applied map[string]string, order []string, ordered bool) ([]byte, error) {
+	if !ordered {
+		return json.Marshal(applied)
+	}
+
+	entries := make([]appliedEntry, 0, len(applied))
+	listed := make(map[string]bool, len(order))
+	for _, k := range order {
+		if v, ok := applied[k]; ok && !listed[k] {
+			entries = append(entries, appliedEntry{Key: k, Value: v})
+			listed[k] = true
+		}
+	}
+
+	keys := make([]string, 0, len(applied))
+	for k := range applied {
+		if !listed[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		entries = append(entries, appliedEntry{Key: k, Value: applied[k]})
+	}
+	return json.Marshal(entries)
+}
+
+// labelOrder returns the spec.labelEntries keys, with the key prefix applied, in the order they are listed
+func labelOrder(spec labelsv1alpha1.NamespaceLabelSpec) []string {
+	if len(spec.LabelEntries) == 0 {
+		return nil
+	}
+	order := make([]string, 0, len(spec.LabelEntries))
+	for _, entry := range spec.LabelEntries {
+		order = append(order, spec.KeyPrefix+entry.Key)
+	}
+	return order
+}
+
+// setAppliedAnnotation records applied on ns in memory so it is persisted with the same update as the labels.
+// Unlike the other tracking annotations it is kept when nothing is applied. It returns true when it changed.
+func setAppliedAnnotation(ns *corev1.Namespace, applied map[string]string, order []string, ordered bool) (bool, error) {
+	b, err := marshalApplied(applied, order, ordered)
+	if err != nil {
+		return false, fmt.Errorf("marshal applied: %w", err)
+	}
+	if cur, ok := ns.Annotations[appliedAnnoKey]; ok && cur == string(b) {
+		return false, nil
+	}
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
 	}
+	ns.Annotations[appliedAnnoKey] = string(b)
+	return true, nil
+}
 
-	if freshNS.Annotations == nil {
-		freshNS.Annotations = map[string]string{}
+// setTrackingAnnotation writes a tracking annotation on ns in memory, removing it when applied is empty.
+// order lists the keys the ordered format puts first. It returns true when the annotation changed.
+func setTrackingAnnotation(ns *corev1.Namespace, key string, applied map[string]string, order []string, ordered bool) (bool, error) {
+	cur, ok := ns.Annotations[key]
+	if len(applied) == 0 {
+		if ok {
+			delete(ns.Annotations, key)
+		}
+		return ok, nil
 	}
 
-	b, err := json.Marshal(applied)
+	b, err := marshalApplied(applied, order, ordered)
 	if err != nil {
-		return fmt.Errorf("marshal applied: %w", err)
+		return false, fmt.Errorf("marshal %s: %w", key, err)
+	}
+	if ok && cur == string(b) {
+		return false, nil
 	}
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+	ns.Annotations[key] = string(b)
+	return true, nil
+}
 
-	// Check if annotation already has the correct value
-	if cur, ok := freshNS.Annotations[appliedAnnoKey]; ok && cur == string(b) {
-		return nil // no change needed
+// setOwnerUIDAnnotation points OwnerUIDAnnoKey at uid, or removes it when uid is empty
+func setOwnerUIDAnnotation(ns *corev1.Namespace, uid string) bool {
+	if uid == "" {
+		if _, ok := ns.Annotations[OwnerUIDAnnoKey]; !ok {
+			return false
+		}
+		delete(ns.Annotations, OwnerUIDAnnoKey)
+		return true
+	}
+	if ns.Annotations[OwnerUIDAnnoKey] == uid {
+		return false
+	}
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
 	}
+	ns.Annotations[OwnerUIDAnnoKey] = uid
+	return true
+}
+
+// normalizeFinalizers drops duplicate finalizers and malformed variants of ours (stray whitespace or casing),
+// leaving exactly one FinalizerName entry. It returns true when the finalizer list changed.
+func normalizeFinalizers(cr *labelsv1alpha1.NamespaceLabel) bool {
+	seen := make(map[string]struct{}, len(cr.Finalizers))
+	normalized := make([]string, 0, len(cr.Finalizers)+1)
+	hasOurs := false
 
-	freshNS.Annotations[appliedAnnoKey] = string(b)
-	return c.Update(ctx, &freshNS)
+	for _, f := range cr.Finalizers {
+		if strings.EqualFold(strings.TrimSpace(f), FinalizerName) {
+			if !hasOurs {
+				normalized = append(normalized, FinalizerName)
+				hasOurs = true
+			}
+			continue
+		}
+		if _, dup := seen[f]; dup {
+			continue
+		}
+		seen[f] = struct{}{}
+		normalized = append(normalized, f)
+	}
+	if !hasOurs {
+		normalized = append(normalized, FinalizerName)
+	}
+
+	if slices.Equal(normalized, cr.Finalizers) {
+		return false
+	}
+	cr.Finalizers = normalized
+	return true
+}
+
+// removeFinalizer removes our finalizer, including any malformed variants that would otherwise block deletion
+func removeFinalizer(cr *labelsv1alpha1.NamespaceLabel) {
+	normalizeFinalizers(cr)
+	controllerutil.RemoveFinalizer(cr, FinalizerName)
 }
 
 func boolToCond(b bool) metav1.ConditionStatus {
@@ -59,103 +207,489 @@ func boolToCond(b bool) metav1.ConditionStatus {
 }
 
 // removeStaleLabels removes labels that were previously applied by this operator but are no longer desired
-func removeStaleLabels(current, desired, prevApplied map[string]string) bool {
-	changed := false
+// and returns the removals sorted by key. Keys matching removalProtection are left in place and returned,
+// sorted, as retained.
+func removeStaleLabels(current, desired, prevApplied map[string]string, removalProtection []protection.Matcher) ([]labelsv1alpha1.LabelChange, []string) {
+	var removed []labelsv1alpha1.LabelChange
+	var retained []string
 	for key, prevVal := range prevApplied {
 		if _, stillWanted := desired[key]; !stillWanted {
 			if cur, exists := current[key]; exists && cur == prevVal {
+				if protection.MatchesAny(key, removalProtection) {
+					retained = append(retained, key)
+					continue
+				}
 				delete(current, key)
-				changed = true
+				removed = append(removed, labelsv1alpha1.LabelChange{Key: key, Action: labelsv1alpha1.LabelChangeRemove, OldValue: prevVal})
 			}
 		}
 	}
-	return changed
+	sortLabelChanges(removed)
+	sort.Strings(retained)
+	return removed, retained
 }
 
-// applyDesiredLabels sets or updates labels to their desired values
-func applyDesiredLabels(current, desired map[string]string) bool {
-	changed := false
-	for key, val := range desired {
-		if current[key] != val {
-			current[key] = val
-			changed = true
+// pruneStaleLabels reports whether labels dropped from the spec of cr are removed, which is the default
+func pruneStaleLabels(cr *labelsv1alpha1.NamespaceLabel) bool {
+	return cr.Spec.PruneStaleLabels == nil || *cr.Spec.PruneStaleLabels
+}
+
+// keptStaleLabels returns the previously applied labels that are no longer desired but still on the namespace
+// with the value the operator applied, i.e. what removeStaleLabels would remove without removal protection
+func keptStaleLabels(current, desired, prevApplied map[string]string) map[string]string {
+	kept := map[string]string{}
+	for key, prevVal := range prevApplied {
+		if _, stillWanted := desired[key]; stillWanted {
+			continue
+		}
+		if cur, exists := current[key]; exists && cur == prevVal {
+			kept[key] = prevVal
 		}
 	}
-	return changed
+	return kept
 }
 
-// isLabelProtected checks if a label key matches any of the protection patterns
-func isLabelProtected(labelKey string, protectionPatterns []string) bool {
-	for _, pattern := range protectionPatterns {
-		// Skip empty patterns
-		if pattern == "" {
+// removalGuard is the protection a removeLabels entry can never strip: the CR's own rules, patterns and
+// protected values, the operator's default and mandatory patterns and, on system namespaces, the built-in
+// kubernetes.io policy. The CR's "!" exceptions only apply to its own patterns.
+type removalGuard struct {
+	own       []protection.Matcher
+	values    []protection.Matcher
+	defaults  []protection.Matcher
+	mandatory []protection.Matcher
+	system    bool
+}
+
+// removalGuard returns the protection guarding the labels of ns against the CR's removeLabels
+func (r *NamespaceLabelReconciler) removalGuard(spec labelsv1alpha1.NamespaceLabelSpec, ns *corev1.Namespace) removalGuard {
+	return removalGuard{
+		own:       protection.CompileRules(spec.ProtectedLabelRules, protection.ActivePatterns(spec, ns.Labels), spec.ProtectionMode),
+		values:    protection.CompilePatterns(spec.ProtectedValuePatterns),
+		defaults:  protection.CompileRules(nil, r.DefaultProtectedLabelPatterns, ""),
+		mandatory: protection.CompilePatterns(r.MandatoryLabelPatterns),
+		system:    protection.IsSystemNamespace(ns.Name),
+	}
+}
+
+// protects reports whether the label with the given key and value must not be removed, whatever the mode
+// of the protection that matches it
+func (g removalGuard) protects(key, value string) bool {
+	if _, ok := protection.Protecting(key, g.own); ok {
+		return true
+	}
+	if _, ok := protection.Protecting(key, g.defaults); ok {
+		return true
+	}
+	return protection.MatchesAny(value, g.values) || protection.MatchesAny(key, g.mandatory) || (g.system && protection.MatchesAny(key, protection.SystemProtectedLabels))
+}
+
+// removeListedLabels deletes the listed keys from the namespace labels whoever set them, except keys still
+// desired, keys under removal protection and keys the guard protects, which are returned as retained.
+// Changes and retained keys are sorted.
+func removeListedLabels(current map[string]string, keys []string, desired map[string]string,
+	removalProtection []protection.Matcher, guard removalGuard) ([]labelsv1alpha1.LabelChange, []string) {
+	var removed []labelsv1alpha1.LabelChange
+	var retained []string
+	for _, key := range keys {
+		cur, exists := current[key]
+		if _, wanted := desired[key]; !exists || wanted {
+			continue
+		}
+		if protection.MatchesAny(key, removalProtection) || guard.protects(key, cur) {
+			retained = append(retained, key)
+			continue
+		}
+		delete(current, key)
+		removed = append(removed, labelsv1alpha1.LabelChange{Key: key, Action: labelsv1alpha1.LabelChangeRemove, OldValue: cur})
+	}
+	sortLabelChanges(removed)
+	sort.Strings(retained)
+	return removed, retained
+}
+
+// driftedLabels returns, sorted, the applied keys whose namespace value no longer matches the applied annotation.
+// A list-merge key only drifts when one of our items is missing, since other items may be added freely.
+func driftedLabels(prevApplied, current map[string]string, listKeys []string) []string {
+	var drifted []string
+	for key, value := range prevApplied {
+		existing, exists := current[key]
+		switch {
+		case !exists:
+			drifted = append(drifted, key)
+		case slices.Contains(listKeys, key):
+			items := splitList(existing)
+			for _, item := range splitList(value) {
+				if !slices.Contains(items, item) {
+					drifted = append(drifted, key)
+					break
+				}
+			}
+		case existing != value:
+			drifted = append(drifted, key)
+		}
+	}
+	sort.Strings(drifted)
+	return drifted
+}
+
+// dropPhantomEntries removes applied entries whose label is absent from the namespace and returns their sorted keys
+func dropPhantomEntries(prevApplied, current map[string]string) []string {
+	var phantoms []string
+	for key := range prevApplied {
+		if _, exists := current[key]; !exists {
+			phantoms = append(phantoms, key)
+			delete(prevApplied, key)
+		}
+	}
+	sort.Strings(phantoms)
+	return phantoms
+}
+
+// splitList parses a list label value into its trimmed, non-empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, webhookv1alpha1.ListSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// joinList renders items as a sorted, de-duplicated list label value
+func joinList(items []string) string {
+	sorted := slices.Clone(items)
+	sort.Strings(sorted)
+	return strings.Join(slices.Compact(sorted), webhookv1alpha1.ListSeparator)
+}
+
+// mergeListLabels computes the labels to write for allowed, unioning list-merge keys with the items other
+// writers put on the namespace. Items this CR contributed before (per prevApplied) are not treated as
+// foreign, so items dropped from the spec disappear. It returns the effective labels to apply and the
+// labels to track in the applied annotation, where list-merge keys record only this CR's own items.
+// A list-merge key dropped from the spec keeps the foreign items and is no longer tracked. A key whose merged
+// value would not be a valid label value keeps its namespace value and previous tracking, and is returned sorted
+// in invalid.
+func mergeListLabels(current, allowed, prevApplied map[string]string, listKeys []string) (effective, tracked map[string]string,
+	invalid []string) {
+	effective = maps.Clone(allowed)
+	tracked = maps.Clone(allowed)
+	if effective == nil {
+		effective, tracked = map[string]string{}, map[string]string{}
+	}
+
+	for _, key := range listKeys {
+		existing, onNamespace := current[key]
+		ours := splitList(prevApplied[key])
+		var foreign []string
+		for _, item := range splitList(existing) {
+			if !slices.Contains(ours, item) {
+				foreign = append(foreign, item)
+			}
+		}
+
+		value, wanted := allowed[key]
+		switch {
+		case wanted:
+			mine := splitList(value)
+			merged := joinList(append(foreign, mine...))
+			if len(validation.IsValidLabelValue(merged)) > 0 {
+				invalid = append(invalid, key)
+				holdListLabel(effective, tracked, current, prevApplied, key)
+				continue
+			}
+			effective[key] = merged
+			tracked[key] = joinList(mine)
+		case onNamespace && len(foreign) > 0 && prevApplied[key] != "":
+			effective[key] = joinList(foreign)
+		}
+	}
+	sort.Strings(invalid)
+	return effective, tracked, invalid
+}
+
+// holdListLabel leaves a list-merge key as the namespace has it and keeps tracking the items applied before
+func holdListLabel(effective, tracked, current, prevApplied map[string]string, key string) {
+	delete(effective, key)
+	delete(tracked, key)
+	if existing, ok := current[key]; ok {
+		effective[key] = existing
+	}
+	if prev, ok := prevApplied[key]; ok {
+		tracked[key] = prev
+	}
+}
+
+// planLabelChanges runs the stale removal (when prune is set), spec.removeLabels and apply steps against a copy
+// of current and returns, sorted, the keys that would be added or changed and the keys that would be removed
+func planLabelChanges(current, desired, prevApplied map[string]string, removeKeys []string,
+	removalProtection []protection.Matcher, guard removalGuard, prune bool) ([]string, []string) {
+	planned := maps.Clone(current)
+	if planned == nil {
+		planned = map[string]string{}
+	}
+	if prune {
+		removeStaleLabels(planned, desired, prevApplied, removalProtection)
+	}
+	removeListedLabels(planned, removeKeys, desired, removalProtection, guard)
+	applyDesiredLabels(planned, desired)
+
+	var wouldApply, wouldRemove []string
+	for key, val := range planned {
+		if cur, ok := current[key]; !ok || cur != val {
+			wouldApply = append(wouldApply, key)
+		}
+	}
+	for key := range current {
+		if _, ok := planned[key]; !ok {
+			wouldRemove = append(wouldRemove, key)
+		}
+	}
+	sort.Strings(wouldApply)
+	sort.Strings(wouldRemove)
+	return wouldApply, wouldRemove
+}
+
+// applyDelayRemaining returns how long until the CR's applyAfter delay elapses, or zero when labels may be applied
+func applyDelayRemaining(cr *labelsv1alpha1.NamespaceLabel, now time.Time) time.Duration {
+	if cr.Spec.ApplyAfter == nil {
+		return 0
+	}
+	applyAt := cr.CreationTimestamp.Add(cr.Spec.ApplyAfter.Duration)
+	if !now.Before(applyAt) {
+		return 0
+	}
+	return applyAt.Sub(now)
+}
+
+// mergeLabels returns a new map with overrides layered on top of base
+func mergeLabels(base, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(overrides))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+// applyDesiredLabels sets or updates labels to their desired values and returns the changes sorted by key
+func applyDesiredLabels(current, desired map[string]string) []labelsv1alpha1.LabelChange {
+	var changes []labelsv1alpha1.LabelChange
+	for key, val := range desired {
+		old, exists := current[key]
+		switch {
+		case !exists:
+			changes = append(changes, labelsv1alpha1.LabelChange{Key: key, Action: labelsv1alpha1.LabelChangeAdd, NewValue: val})
+		case old != val:
+			changes = append(changes, labelsv1alpha1.LabelChange{Key: key, Action: labelsv1alpha1.LabelChangeUpdate, OldValue: old, NewValue: val})
+		default:
 			continue
 		}
+		current[key] = val
+	}
+	sortLabelChanges(changes)
+	return changes
+}
+
+// filterAllowedLabels keeps the desired labels whose key matches an allowlist pattern and returns, sorted,
+// the keys it dropped. An empty allowlist permits every key.
+func filterAllowedLabels(desired map[string]string, allowedPatterns []string) (map[string]string, []string) {
+	if len(allowedPatterns) == 0 {
+		return desired, nil
+	}
+
+	matchers := protection.CompilePatterns(allowedPatterns)
+	allowed := make(map[string]string, len(desired))
+	var disallowed []string
+	for key, value := range desired {
+		if protection.MatchesAny(key, matchers) {
+			allowed[key] = value
+		} else {
+			disallowed = append(disallowed, key)
+		}
+	}
+	sort.Strings(disallowed)
+	return allowed, disallowed
+}
+
+// filterValueCharset drops the desired labels whose values use characters outside charset and returns,
+// sorted, the keys it dropped
+func filterValueCharset(desired map[string]string, charset webhookv1alpha1.ValueCharset) (map[string]string, []string) {
+	if charset == webhookv1alpha1.ValueCharsetAny {
+		return desired, nil
+	}
+	valid := make(map[string]string, len(desired))
+	var invalid []string
+	for key, value := range desired {
+		if _, bad := charset.InvalidRune(value); bad {
+			invalid = append(invalid, key)
+		} else {
+			valid[key] = value
+		}
+	}
+	sort.Strings(invalid)
+	return valid, invalid
+}
 
-		// Use filepath.Match for glob pattern matching
-		if matched, err := filepath.Match(pattern, labelKey); err == nil && matched {
-			return true
+// filterUnownedLabels keeps the desired labels the namespace lacks or that the operator applied earlier, and
+// returns, sorted, the keys it dropped because the namespace has a different value. Unowned keys that already
+// hold the desired value are dropped without a conflict so they are never tracked, and so never removed, as ours.
+func filterUnownedLabels(desired, current, prevApplied map[string]string) (map[string]string, []string) {
+	owned := make(map[string]string, len(desired))
+	var conflicts []string
+	for key, value := range desired {
+		existing, exists := current[key]
+		_, applied := prevApplied[key]
+		switch {
+		case !exists || applied:
+			owned[key] = value
+		case existing != value:
+			conflicts = append(conflicts, key)
 		}
-		// If there's an error in pattern matching, log it but continue
-		// This prevents malformed patterns from breaking protection
 	}
-	return false
+	sort.Strings(conflicts)
+	return owned, conflicts
+}
+
+// isLabelProtected checks if a label key matches any of the protection patterns and none of their exceptions
+func isLabelProtected(labelKey string, protectionPatterns []string) bool {
+	_, protected := protection.Protecting(labelKey, protection.CompileRules(nil, protectionPatterns, ""))
+	return protected
 }
 
-// applyProtectionLogic processes desired labels against protection rules
+// applyProtectionLogic processes desired labels against protection rules. A label is protected when its key
+// matches a rule or pattern, or when its current value matches one of protectedValuePatterns.
 func applyProtectionLogic(
 	desired map[string]string,
 	existing map[string]string,
 	protectionPatterns []string,
+	protectionRules []labelsv1alpha1.ProtectedLabelRule,
 	protectionMode labelsv1alpha1.ProtectionMode,
+	protectCreation bool,
+	protectedValuePatterns []string,
 ) ProtectionResult {
 	result := ProtectionResult{
 		AllowedLabels:    make(map[string]string),
 		ProtectedSkipped: []string{},
 		Warnings:         []string{},
 		ShouldFail:       false,
+		Reasons:          make(map[string]string),
 	}
 
+	// Rules come first so their mode wins over the CR-wide mode for keys matched by both
+	matchers := protection.CompileRules(protectionRules, protectionPatterns, protectionMode)
+	valueMatchers := protection.CompilePatterns(protectedValuePatterns)
+
 	for key, value := range desired {
-		// Check if this label is protected
-		if isLabelProtected(key, protectionPatterns) {
-			existingValue, hasExisting := existing[key]
+		// Empty keys can never be stored on a namespace, so drop them instead of failing the update
+		if key == "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Label with empty key was dropped (value '%s')", value))
+			result.EmptyKeysDropped++
+			continue
+		}
 
-			// If the label exists with a different value, apply protection
+		existingValue, hasExisting := existing[key]
+		mode := protectionMode
+		var msg, reason string
+
+		// Check if this label is protected; the first matching pattern decides the mode unless an exception matches
+		if matcher, protected := protection.Protecting(key, matchers); protected {
+			mode = matcher.Mode
+			reason = matcher.Pattern
+			// If the label exists with a different value, or protectCreation forbids creating it, apply protection
 			if hasExisting && existingValue != value {
-				msg := fmt.Sprintf("Label '%s' is protected by pattern and has existing value '%s' (attempting to set '%s')",
+				msg = fmt.Sprintf("Label '%s' is protected by pattern and has existing value '%s' (attempting to set '%s')",
 					key, existingValue, value)
+			} else if !hasExisting && protectCreation {
+				msg = fmt.Sprintf("Label '%s' is protected by pattern and does not exist on the namespace (attempting to create it with '%s')",
+					key, value)
+			}
+			// Protected label with no conflict - allow it
+			// Either setting a new protected label without protectCreation or no change needed (existingValue == value)
+		}
 
-				switch protectionMode {
-				case labelsv1alpha1.ProtectionModeFail:
-					result.ShouldFail = true
-					result.Warnings = append(result.Warnings, msg)
-					return result
-				case labelsv1alpha1.ProtectionModeWarn:
-					result.Warnings = append(result.Warnings, msg)
-					result.ProtectedSkipped = append(result.ProtectedSkipped, key)
-					continue
-				default: // ProtectionModeSkip
-					result.ProtectedSkipped = append(result.ProtectedSkipped, key)
-					continue
-				}
+		// A protected value can't be changed whatever its key, using the CR-wide mode
+		if msg == "" && hasExisting && existingValue != value {
+			if matcher, ok := protection.First(existingValue, valueMatchers); ok {
+				mode = protectionMode
+				reason = matcher.Pattern
+				msg = fmt.Sprintf("Label '%s' has protected value '%s' (attempting to set '%s')", key, existingValue, value)
 			}
+		}
 
-			// Protected label with no conflict - allow it
-			// Either setting a new protected label (!hasExisting) or no change needed (existingValue == value)
+		if msg != "" {
+			result.Reasons[key] = reason
+			switch mode {
+			case labelsv1alpha1.ProtectionModeFail:
+				result.ShouldFail = true
+				result.Warnings = append(result.Warnings, msg)
+				result.ConflictingKeys = append(result.ConflictingKeys, key)
+				continue
+			case labelsv1alpha1.ProtectionModeWarn:
+				result.Warnings = append(result.Warnings, msg)
+				result.ProtectedSkipped = append(result.ProtectedSkipped, key)
+				continue
+			default: // ProtectionModeSkip
+				result.ProtectedSkipped = append(result.ProtectedSkipped, key)
+				continue
+			}
 		}
 
 		// Label is either not protected or safe to apply
 		result.AllowedLabels[key] = value
 	}
 
+	sort.Strings(result.ConflictingKeys)
 	return result
 }
 
+// preserveProtectedValues skips the allowed labels whose namespace value protection kept on an earlier reconcile,
+// as recorded in preserved, while the namespace still carries that value, so relaxing protection does not change
+// it. It returns the values kept this time, by protection or preservation, to record for the next reconcile.
+func preserveProtectedValues(result *ProtectionResult, nsLabels, preserved map[string]string) map[string]string {
+	for key, value := range preserved {
+		desired, allowed := result.AllowedLabels[key]
+		current, exists := nsLabels[key]
+		if !allowed || !exists || current != value || desired == value {
+			continue
+		}
+		delete(result.AllowedLabels, key)
+		result.ProtectedSkipped = append(result.ProtectedSkipped, key)
+	}
+
+	var kept map[string]string
+	for _, keys := range [][]string{result.ProtectedSkipped, result.ConflictingKeys} {
+		for _, key := range keys {
+			if value, exists := nsLabels[key]; exists {
+				if kept == nil {
+					kept = map[string]string{}
+				}
+				kept[key] = value
+			}
+		}
+	}
+	return kept
+}
+
+// protectedLabelReasons returns the result's reasons for status, or nil when no key was protected
+func protectedLabelReasons(result ProtectionResult) map[string]string {
+	if len(result.Reasons) == 0 {
+		return nil
+	}
+	return result.Reasons
+}
+
 func updateStatus(cr *labelsv1alpha1.NamespaceLabel, ok bool, reason, msg string, protectedSkipped, labelsApplied []string) {
 	cr.Status.Applied = ok
+	cr.Status.ObservedGeneration = cr.Generation
 	cr.Status.ProtectedLabelsSkipped = protectedSkipped
+	cr.Status.ProtectedLabelsSkippedCount = int32(len(protectedSkipped))
+	// Callers reporting a protection result set the reasons afterwards
+	cr.Status.ProtectedLabelReasons = nil
 	cr.Status.LabelsApplied = labelsApplied
+	cr.Status.LabelsAppliedCount = int32(len(labelsApplied))
 
 	// Update condition
 	cond := metav1.Condition{
@@ -179,3 +713,148 @@ func updateStatus(cr *labelsv1alpha1.NamespaceLabel, ok bool, reason, msg string
 	}
 	cr.Status.Conditions = append(cr.Status.Conditions, cond)
 }
+
+// setCondition sets an auxiliary condition next to Ready, keeping the transition time when the status is unchanged
+func setCondition(cr *labelsv1alpha1.NamespaceLabel, condType string, status metav1.ConditionStatus, reason, msg string) {
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: cr.Generation,
+	})
+}
+
+// setProtectionConflict sets ConditionProtectionConflict to True listing the conflicting keys, or removes it when there are none
+func setProtectionConflict(cr *labelsv1alpha1.NamespaceLabel, conflictingKeys []string) {
+	if len(conflictingKeys) == 0 {
+		meta.RemoveStatusCondition(&cr.Status.Conditions, ConditionProtectionConflict)
+		return
+	}
+	setCondition(cr, ConditionProtectionConflict, metav1.ConditionTrue, "ProtectedLabelConflict",
+		fmt.Sprintf("Fail-mode protection blocks labels: %s", summarizeKeys(conflictingKeys)))
+}
+
+// maxListedKeys caps how many keys a condition message lists; the full list is kept in the matching status field
+const maxListedKeys = 10
+
+// summarizeKeys joins the sorted keys for a condition message, listing at most maxListedKeys followed by
+// "and N more" so status stays readable and well within object size limits
+func summarizeKeys(keys []string) string {
+	sorted := slices.Clone(keys)
+	sort.Strings(sorted)
+	if len(sorted) <= maxListedKeys {
+		return strings.Join(sorted, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(sorted[:maxListedKeys], ", "), len(sorted)-maxListedKeys)
+}
+
+// hasReadyCondition reports whether the Ready condition already carries the given reason and message
+func hasReadyCondition(cr *labelsv1alpha1.NamespaceLabel, reason, msg string) bool {
+	cond := meta.FindStatusCondition(cr.Status.Conditions, "Ready")
+	return cond != nil && cond.Reason == reason && cond.Message == msg
+}
+
+// mapKeys returns the keys of a label map in sorted order
+func mapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sameKeys reports whether two key lists contain the same keys regardless of order
+func sameKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]struct{}, len(a))
+	for _, k := range a {
+		seen[k] = struct{}{}
+	}
+	for _, k := range b {
+		if _, ok := seen[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// updateCRStatus writes the CR status, re-fetching the CR and re-applying the computed status on conflicts
+// so a concurrent write doesn't drop it. A CR that is being deleted or already gone gets no status write,
+// since the write would only race with finalization.
+func (r *NamespaceLabelReconciler) updateCRStatus(ctx context.Context, cr *labelsv1alpha1.NamespaceLabel) error {
+	if !cr.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	backoff := retry.DefaultRetry
+	if r.StatusUpdateRetries > 0 {
+		backoff.Steps = r.StatusUpdateRetries
+	}
+
+	status := cr.Status.DeepCopy()
+	attempt := 0
+	return retry.RetryOnConflict(backoff, func() error {
+		attempt++
+		if attempt > 1 {
+			var latest labelsv1alpha1.NamespaceLabel
+			if err := r.Get(ctx, client.ObjectKeyFromObject(cr), &latest); err != nil {
+				return client.IgnoreNotFound(err)
+			}
+			if !latest.DeletionTimestamp.IsZero() {
+				return nil
+			}
+			latest.Status = *status
+			*cr = latest
+		}
+		return client.IgnoreNotFound(r.Status().Update(ctx, cr))
+	})
+}
+
+// recordEvent emits a Kubernetes Event for the CR when a recorder is configured
+func (r *NamespaceLabelReconciler) recordEvent(cr *labelsv1alpha1.NamespaceLabel, eventType, reason, msg string) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Event(cr, eventType, reason, msg)
+}
+
+// resyncInterval returns how long until a successfully reconciled CR is reconciled again, 0 for no periodic requeue
+func (r *NamespaceLabelReconciler) resyncInterval(cr *labelsv1alpha1.NamespaceLabel) time.Duration {
+	if cr.Spec.ReconcileInterval != nil && cr.Spec.ReconcileInterval.Duration > 0 {
+		return cr.Spec.ReconcileInterval.Duration
+	}
+	return r.ResyncInterval
+}
+
+// scheduleRequeue records in status when a requeue after interval will reconcile cr and returns interval.
+// An interval of 0 schedules nothing and clears NextReconcileAt.
+func (r *NamespaceLabelReconciler) scheduleRequeue(cr *labelsv1alpha1.NamespaceLabel, interval time.Duration) time.Duration {
+	if interval <= 0 {
+		cr.Status.NextReconcileAt = nil
+		return 0
+	}
+	cr.Status.NextReconcileAt = &metav1.Time{Time: r.now().Add(interval)}
+	return interval
+}
+
+// soonestRequeue returns the shortest non-zero interval, or 0 when every interval is 0
+func soonestRequeue(intervals ...time.Duration) time.Duration {
+	var soonest time.Duration
+	for _, d := range intervals {
+		if d > 0 && (soonest == 0 || d < soonest) {
+			soonest = d
+		}
+	}
+	return soonest
+}
+
+// now returns the current time from the injected clock, falling back to the real clock
+func (r *NamespaceLabelReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
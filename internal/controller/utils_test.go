@@ -18,16 +18,19 @@ package controller
 
 import (
 	"context"
+	"errors"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	"github.com/sbahar619/namespace-label-operator/internal/protection"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -56,6 +59,12 @@ var _ = Describe("readAppliedAnnotation", Label("controller"), func() {
 		Entry("invalid JSON",
 			map[string]string{"labels.shahaf.com/applied": `{invalid-json}`},
 			map[string]string{}),
+		Entry("ordered list annotation",
+			map[string]string{"labels.shahaf.com/applied": `[{"key":"app","value":"web"},{"key":"environment","value":"prod"}]`},
+			map[string]string{"app": "web", "environment": "prod"}),
+		Entry("invalid ordered list",
+			map[string]string{"labels.shahaf.com/applied": `[{"key":`},
+			map[string]string{}),
 	)
 
 	It("should handle nil annotations gracefully", func() {
@@ -69,11 +78,8 @@ var _ = Describe("readAppliedAnnotation", Label("controller"), func() {
 	})
 })
 
-var _ = Describe("writeAppliedAnnotation", func() {
+var _ = Describe("setAppliedAnnotation", func() {
 	It("should write annotation correctly", func() {
-		scheme := runtime.NewScheme()
-		Expect(corev1.AddToScheme(scheme)).To(Succeed())
-
 		ns := &corev1.Namespace{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:        "test-ns",
@@ -81,23 +87,155 @@ var _ = Describe("writeAppliedAnnotation", func() {
 			},
 		}
 
-		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns).Build()
-
 		appliedLabels := map[string]string{
 			"app": "web",
 			"env": "prod",
 		}
 
-		err := writeAppliedAnnotation(context.TODO(), fakeClient, ns, appliedLabels)
+		changed, err := setAppliedAnnotation(ns, appliedLabels, nil, false)
 		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		Expect(readAppliedAnnotation(ns)).To(Equal(appliedLabels))
+	})
+
+	It("should round-trip the ordered annotation format", func() {
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-ns",
+			},
+		}
 
-		// Verify the annotation was written
-		var updatedNS corev1.Namespace
-		err = fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(ns), &updatedNS)
+		appliedLabels := map[string]string{
+			"tier": "backend",
+			"app":  "web",
+			"env":  "prod",
+		}
+
+		_, err := setAppliedAnnotation(ns, appliedLabels, nil, true)
 		Expect(err).NotTo(HaveOccurred())
+		Expect(ns.Annotations[appliedAnnoKey]).To(Equal(
+			`[{"key":"app","value":"web"},{"key":"env","value":"prod"},{"key":"tier","value":"backend"}]`))
+		Expect(readAppliedAnnotation(ns)).To(Equal(appliedLabels))
+	})
+
+	It("should list labelEntries first in their declared order", func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}}
+		spec := labelsv1alpha1.NamespaceLabelSpec{
+			KeyPrefix: "acme.io/",
+			LabelEntries: []labelsv1alpha1.LabelEntry{
+				{Key: "zone", Value: "eu"},
+				{Key: "app", Value: "web"},
+				{Key: "removed", Value: "x"},
+			},
+		}
+		appliedLabels := map[string]string{
+			"acme.io/zone": "eu",
+			"acme.io/app":  "web",
+			"acme.io/env":  "prod",
+			"acme.io/tier": "backend",
+		}
+
+		_, err := setAppliedAnnotation(ns, appliedLabels, labelOrder(spec), true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ns.Annotations[appliedAnnoKey]).To(Equal(`[{"key":"acme.io/zone","value":"eu"},{"key":"acme.io/app","value":"web"},` +
+			`{"key":"acme.io/env","value":"prod"},{"key":"acme.io/tier","value":"backend"}]`))
+		Expect(readAppliedAnnotation(ns)).To(Equal(appliedLabels))
+	})
+
+	It("should report unchanged values and keep an empty annotation", func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}}
+		appliedLabels := map[string]string{"env": "prod"}
+		Expect(setAppliedAnnotation(ns, appliedLabels, nil, false)).To(BeTrue())
+
+		By("writing the same value again")
+		Expect(setAppliedAnnotation(ns, appliedLabels, nil, false)).To(BeFalse())
+
+		By("recording that nothing is applied")
+		Expect(setAppliedAnnotation(ns, map[string]string{}, nil, false)).To(BeTrue())
+		Expect(ns.Annotations).To(HaveKeyWithValue(appliedAnnoKey, "{}"))
+	})
+
+	It("should write labels and the applied annotation in one namespace patch", func() {
+		f := newTestFixture()
+		var nsUpdates, nsPatches int
+		f.setClient(newClientBuilder(f.scheme).
+			WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}},
+				&labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "test-ns", Finalizers: []string{FinalizerName}},
+					Spec:       labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"env": "prod"}},
+				}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok {
+						nsUpdates++
+					}
+					return c.Update(ctx, obj, opts...)
+				},
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if _, ok := obj.(*corev1.Namespace); ok {
+						nsPatches++
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build())
+
+		_, err := f.reconcile(StandardCRName, "test-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect([]int{nsUpdates, nsPatches}).To(Equal([]int{0, 1}))
+		ns := f.getNamespace("test-ns")
+		Expect(ns.Labels).To(HaveKeyWithValue("env", "prod"))
+		Expect(readAppliedAnnotation(ns)).To(Equal(map[string]string{"env": "prod"}))
+
+		By("not writing an unchanged namespace")
+		_, err = f.reconcile(StandardCRName, "test-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect([]int{nsUpdates, nsPatches}).To(Equal([]int{0, 1}))
+	})
+
+	It("should apply labelEntries and keep their order in the ordered annotation", func() {
+		f := newTestFixture()
+		f.reconciler.OrderedAppliedAnnotation = true
+		f.createNamespace("test-ns", nil, nil)
+		_, ns, err := f.reconcileSpec("test-ns", labelsv1alpha1.NamespaceLabelSpec{
+			Labels:       map[string]string{"env": "prod"},
+			LabelEntries: []labelsv1alpha1.LabelEntry{{Key: "zone", Value: "eu"}, {Key: "app", Value: "web"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ns.Labels).To(Equal(map[string]string{"env": "prod", "zone": "eu", "app": "web"}))
+		Expect(ns.Annotations[appliedAnnoKey]).To(Equal(
+			`[{"key":"zone","value":"eu"},{"key":"app","value":"web"},{"key":"env","value":"prod"}]`))
+	})
+})
+
+var _ = Describe("normalizeFinalizers", func() {
+	DescribeTable("finalizer normalization scenarios",
+		func(finalizers []string, expected []string, expectedChanged bool) {
+			cr := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Finalizers: finalizers},
+			}
+			Expect(normalizeFinalizers(cr)).To(Equal(expectedChanged))
+			Expect(cr.Finalizers).To(Equal(expected))
+		},
+		Entry("missing finalizer is added",
+			nil, []string{FinalizerName}, true),
+		Entry("single finalizer is untouched",
+			[]string{"other/finalizer", FinalizerName}, []string{"other/finalizer", FinalizerName}, false),
+		Entry("duplicate finalizers are collapsed",
+			[]string{FinalizerName, "other/finalizer", FinalizerName, "other/finalizer"},
+			[]string{FinalizerName, "other/finalizer"}, true),
+		Entry("malformed variants are replaced",
+			[]string{" Labels.Shahaf.com/Finalizer ", FinalizerName},
+			[]string{FinalizerName}, true),
+	)
 
-		result := readAppliedAnnotation(&updatedNS)
-		Expect(result).To(Equal(appliedLabels))
+	It("should remove malformed variants when removing the finalizer", func() {
+		cr := &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Finalizers: []string{FinalizerName, "LABELS.SHAHAF.COM/FINALIZER", "other/finalizer"}},
+		}
+		removeFinalizer(cr)
+		Expect(cr.Finalizers).To(Equal([]string{"other/finalizer"}))
 	})
 })
 
@@ -128,9 +266,11 @@ var _ = Describe("removeStaleLabels", func() {
 			"env":     "prod", // this should be removed (value changed)
 		}
 
-		changed := removeStaleLabels(current, desired, prevApplied)
+		removed, _ := removeStaleLabels(current, desired, prevApplied, nil)
 
-		Expect(changed).To(BeTrue())
+		Expect(removed).To(Equal([]labelsv1alpha1.LabelChange{
+			{Key: "version", Action: labelsv1alpha1.LabelChangeRemove, OldValue: "v1.0"},
+		}))
 		Expect(current).NotTo(HaveKey("version"))
 		Expect(current).To(HaveKeyWithValue("app", "myapp"))
 		Expect(current).To(HaveKeyWithValue("env", "prod")) // old value still there
@@ -151,15 +291,15 @@ var _ = Describe("removeStaleLabels", func() {
 			// user-label was never applied by operator
 		}
 
-		changed := removeStaleLabels(current, desired, prevApplied)
+		removed, _ := removeStaleLabels(current, desired, prevApplied, nil)
 
-		Expect(changed).To(BeTrue())
+		Expect(removed).To(HaveLen(1))
 		Expect(current).NotTo(HaveKey("version"))            // removed (was applied by operator)
 		Expect(current).To(HaveKey("user-label"))            // kept (not applied by operator)
 		Expect(current).To(HaveKeyWithValue("app", "myapp")) // kept (still desired)
 	})
 
-	It("should return false when no changes needed", func() {
+	It("should return no changes when no changes needed", func() {
 		current := map[string]string{
 			"app": "myapp",
 		}
@@ -170,11 +310,75 @@ var _ = Describe("removeStaleLabels", func() {
 			"app": "myapp",
 		}
 
-		changed := removeStaleLabels(current, desired, prevApplied)
+		removed, _ := removeStaleLabels(current, desired, prevApplied, nil)
 
-		Expect(changed).To(BeFalse())
+		Expect(removed).To(BeEmpty())
 		Expect(current).To(HaveKeyWithValue("app", "myapp"))
 	})
+
+	It("should retain stale labels matching removal protection", func() {
+		current := map[string]string{"team": "a", "tier": "b"}
+		prevApplied := map[string]string{"team": "a", "tier": "b"}
+
+		removed, retained := removeStaleLabels(current, map[string]string{}, prevApplied,
+			protection.CompilePatterns([]string{"regex:^te"}))
+
+		Expect(removed).To(Equal([]labelsv1alpha1.LabelChange{
+			{Key: "tier", Action: labelsv1alpha1.LabelChangeRemove, OldValue: "b"},
+		}))
+		Expect(retained).To(Equal([]string{"team"}))
+		Expect(current).To(Equal(map[string]string{"team": "a"}))
+	})
+})
+
+var _ = Describe("keptStaleLabels", func() {
+	It("should return previously applied labels no longer desired that still carry the applied value", func() {
+		current := map[string]string{"env": "prod", "team": "a", "tier": "edited"}
+		prevApplied := map[string]string{"env": "prod", "team": "a", "tier": "b", "gone": "x"}
+
+		kept := keptStaleLabels(current, map[string]string{"env": "staging"}, prevApplied)
+
+		Expect(kept).To(Equal(map[string]string{"team": "a"}))
+	})
+})
+
+var _ = Describe("filterAllowedLabels", func() {
+	It("should keep only keys matching the allowlist", func() {
+		allowed, disallowed := filterAllowedLabels(
+			map[string]string{"team/a": "1", "team/b": "2", "env": "prod", "zone": "eu"},
+			[]string{"team/*", "regex:^env$"},
+		)
+		Expect(allowed).To(Equal(map[string]string{"team/a": "1", "team/b": "2", "env": "prod"}))
+		Expect(disallowed).To(Equal([]string{"zone"}))
+	})
+
+	It("should allow everything without patterns", func() {
+		desired := map[string]string{"env": "prod"}
+		allowed, disallowed := filterAllowedLabels(desired, nil)
+		Expect(allowed).To(Equal(desired))
+		Expect(disallowed).To(BeEmpty())
+	})
+})
+
+var _ = Describe("driftedLabels", func() {
+	It("should report changed and missing labels and tolerate extra list items", func() {
+		prevApplied := map[string]string{"app": "myapp", "env": "prod", "team": "a", "owners": "x_y", "teams": "b"}
+		current := map[string]string{"app": "myapp", "env": "dev", "owners": "x_y_z", "teams": "c"}
+
+		Expect(driftedLabels(prevApplied, current, []string{"owners", "teams"})).To(Equal([]string{"env", "team", "teams"}))
+	})
+})
+
+var _ = Describe("dropPhantomEntries", func() {
+	It("should forget applied entries whose label is missing from the namespace", func() {
+		prevApplied := map[string]string{"app": "myapp", "team": "a", "env": "prod"}
+		current := map[string]string{"app": "other"}
+
+		phantoms := dropPhantomEntries(prevApplied, current)
+
+		Expect(phantoms).To(Equal([]string{"env", "team"}))
+		Expect(prevApplied).To(Equal(map[string]string{"app": "myapp"}))
+	})
 })
 
 var _ = Describe("applyDesiredLabels", func() {
@@ -186,9 +390,11 @@ var _ = Describe("applyDesiredLabels", func() {
 			"new": "label",
 		}
 
-		changed := applyDesiredLabels(current, desired)
+		changes := applyDesiredLabels(current, desired)
 
-		Expect(changed).To(BeTrue())
+		Expect(changes).To(Equal([]labelsv1alpha1.LabelChange{
+			{Key: "new", Action: labelsv1alpha1.LabelChangeAdd, NewValue: "label"},
+		}))
 		Expect(current).To(HaveKeyWithValue("existing", "label"))
 		Expect(current).To(HaveKeyWithValue("new", "label"))
 	})
@@ -201,13 +407,15 @@ var _ = Describe("applyDesiredLabels", func() {
 			"app": "newvalue",
 		}
 
-		changed := applyDesiredLabels(current, desired)
+		changes := applyDesiredLabels(current, desired)
 
-		Expect(changed).To(BeTrue())
+		Expect(changes).To(Equal([]labelsv1alpha1.LabelChange{
+			{Key: "app", Action: labelsv1alpha1.LabelChangeUpdate, OldValue: "oldvalue", NewValue: "newvalue"},
+		}))
 		Expect(current).To(HaveKeyWithValue("app", "newvalue"))
 	})
 
-	It("should return false when no changes needed", func() {
+	It("should return no changes when no changes needed", func() {
 		current := map[string]string{
 			"app": "myapp",
 		}
@@ -215,9 +423,9 @@ var _ = Describe("applyDesiredLabels", func() {
 			"app": "myapp",
 		}
 
-		changed := applyDesiredLabels(current, desired)
+		changes := applyDesiredLabels(current, desired)
 
-		Expect(changed).To(BeFalse())
+		Expect(changes).To(BeEmpty())
 		Expect(current).To(HaveKeyWithValue("app", "myapp"))
 	})
 })
@@ -236,7 +444,32 @@ var _ = Describe("isLabelProtected", func() {
 		Entry("multiple patterns - first matches", "k8s.io/app", []string{"k8s.io/*", "other/*"}, true),
 		Entry("multiple patterns - second matches", "istio.io/version", []string{"k8s.io/*", "istio.io/*"}, true),
 		Entry("multiple patterns - no match", "myapp/version", []string{"k8s.io/*", "istio.io/*"}, false),
+		Entry("regex match", "app.secret.io/token", []string{`regex:^.*\.secret\..*$`}, true),
+		Entry("regex no match", "app.public.io/token", []string{`regex:^.*\.secret\..*$`}, false),
+		Entry("regex substring match", "my-secret-key", []string{"regex:secret"}, true),
+		Entry("glob and regex mixed", "istio.io/rev", []string{"regex:secret", "istio.io/*"}, true),
+		Entry("invalid regex never matches", "anything", []string{"regex:^(unclosed"}, false),
+		Entry("double star across separators", "kubernetes.io/foo/bar", []string{"kubernetes.io/**"}, true),
+		Entry("single star stops at separators", "kubernetes.io/foo/bar", []string{"kubernetes.io/*"}, false),
+		Entry("double star in the prefix", "team.example.com/owner", []string{"**example.com/owner"}, true),
+		Entry("malformed double star glob never matches", "team/a", []string{"**/[unclosed"}, false),
+		Entry("exception carves a key out", "example.com/public", []string{"example.com/*", "!example.com/public"}, false),
+		Entry("exception listed first", "example.com/public", []string{"!example.com/public", "example.com/*"}, false),
+		Entry("exception leaves other keys protected", "example.com/owner", []string{"example.com/*", "!example.com/public"}, true),
+		Entry("regex exception", "example.com/public-docs", []string{"example.com/*", "!regex:/public"}, false),
+		Entry("exception alone protects nothing", "example.com/owner", []string{"!example.com/public"}, false),
 	)
+
+	It("should let an exception override a protection rule", func() {
+		result := applyProtectionLogic(
+			map[string]string{"example.com/public": "yes", "example.com/owner": "me"},
+			map[string]string{"example.com/public": "no", "example.com/owner": "them"},
+			[]string{"!example.com/public"},
+			[]labelsv1alpha1.ProtectedLabelRule{{Pattern: "example.com/*", Mode: labelsv1alpha1.ProtectionModeFail}},
+			labelsv1alpha1.ProtectionModeSkip, false, nil)
+		Expect(result.AllowedLabels).To(Equal(map[string]string{"example.com/public": "yes"}))
+		Expect(result.ConflictingKeys).To(Equal([]string{"example.com/owner"}))
+	})
 })
 
 var _ = Describe("applyProtectionLogic", func() {
@@ -250,7 +483,7 @@ var _ = Describe("applyProtectionLogic", func() {
 		}
 		patterns := []string{"kubernetes.io/*"}
 
-		result := applyProtectionLogic(desired, existing, patterns, labelsv1alpha1.ProtectionModeSkip)
+		result := applyProtectionLogic(desired, existing, patterns, nil, labelsv1alpha1.ProtectionModeSkip, false, nil)
 
 		Expect(result.ShouldFail).To(BeFalse())
 		Expect(result.AllowedLabels).To(HaveKeyWithValue("app", "myapp"))
@@ -269,7 +502,7 @@ var _ = Describe("applyProtectionLogic", func() {
 		}
 		patterns := []string{"kubernetes.io/*"}
 
-		result := applyProtectionLogic(desired, existing, patterns, labelsv1alpha1.ProtectionModeWarn)
+		result := applyProtectionLogic(desired, existing, patterns, nil, labelsv1alpha1.ProtectionModeWarn, false, nil)
 
 		Expect(result.ShouldFail).To(BeFalse())
 		Expect(result.AllowedLabels).To(HaveKeyWithValue("app", "myapp"))
@@ -289,11 +522,23 @@ var _ = Describe("applyProtectionLogic", func() {
 		}
 		patterns := []string{"kubernetes.io/*"}
 
-		result := applyProtectionLogic(desired, existing, patterns, labelsv1alpha1.ProtectionModeFail)
+		result := applyProtectionLogic(desired, existing, patterns, nil, labelsv1alpha1.ProtectionModeFail, false, nil)
 
 		Expect(result.ShouldFail).To(BeTrue())
 		Expect(result.Warnings).To(HaveLen(1))
 		Expect(result.Warnings[0]).To(ContainSubstring("Label 'kubernetes.io/managed-by' is protected"))
+		Expect(result.ConflictingKeys).To(Equal([]string{"kubernetes.io/managed-by"}))
+	})
+
+	It("should report every fail-mode conflict", func() {
+		desired := map[string]string{"kubernetes.io/owner": "me", "kubernetes.io/managed-by": "me", "app": "web"}
+		existing := map[string]string{"kubernetes.io/owner": "platform", "kubernetes.io/managed-by": "other"}
+
+		result := applyProtectionLogic(desired, existing, []string{"kubernetes.io/*"}, nil, labelsv1alpha1.ProtectionModeFail, false, nil)
+
+		Expect(result.ShouldFail).To(BeTrue())
+		Expect(result.ConflictingKeys).To(Equal([]string{"kubernetes.io/managed-by", "kubernetes.io/owner"}))
+		Expect(result.Warnings).To(HaveLen(2))
 	})
 
 	It("should allow protected labels with same values", func() {
@@ -305,7 +550,7 @@ var _ = Describe("applyProtectionLogic", func() {
 		}
 		patterns := []string{"kubernetes.io/*"}
 
-		result := applyProtectionLogic(desired, existing, patterns, labelsv1alpha1.ProtectionModeFail)
+		result := applyProtectionLogic(desired, existing, patterns, nil, labelsv1alpha1.ProtectionModeFail, false, nil)
 
 		Expect(result.ShouldFail).To(BeFalse())
 		Expect(result.AllowedLabels).To(HaveKeyWithValue("kubernetes.io/managed-by", "existing-operator"))
@@ -320,12 +565,144 @@ var _ = Describe("applyProtectionLogic", func() {
 		existing := map[string]string{}
 		patterns := []string{"kubernetes.io/*"}
 
-		result := applyProtectionLogic(desired, existing, patterns, labelsv1alpha1.ProtectionModeSkip)
+		result := applyProtectionLogic(desired, existing, patterns, nil, labelsv1alpha1.ProtectionModeSkip, false, nil)
 
 		Expect(result.ShouldFail).To(BeFalse())
 		Expect(result.AllowedLabels).To(HaveKeyWithValue("kubernetes.io/managed-by", "operator"))
 		Expect(result.ProtectedSkipped).To(BeEmpty())
 	})
+	It("should block creating a new protected label with protectCreation in skip mode", func() {
+		desired := map[string]string{
+			"app":                      "myapp",
+			"kubernetes.io/managed-by": "operator",
+		}
+		patterns := []string{"kubernetes.io/*"}
+
+		result := applyProtectionLogic(desired, map[string]string{}, patterns, nil, labelsv1alpha1.ProtectionModeSkip, true, nil)
+
+		Expect(result.ShouldFail).To(BeFalse())
+		Expect(result.AllowedLabels).To(Equal(map[string]string{"app": "myapp"}))
+		Expect(result.ProtectedSkipped).To(ConsistOf("kubernetes.io/managed-by"))
+	})
+
+	It("should fail on creating a new protected label with protectCreation in fail mode", func() {
+		desired := map[string]string{"kubernetes.io/managed-by": "operator"}
+		patterns := []string{"kubernetes.io/*"}
+
+		result := applyProtectionLogic(desired, map[string]string{}, patterns, nil, labelsv1alpha1.ProtectionModeFail, true, nil)
+
+		Expect(result.ShouldFail).To(BeTrue())
+		Expect(result.Warnings).To(ConsistOf(ContainSubstring("does not exist on the namespace")))
+	})
+
+	It("should still allow protected labels that already have the desired value with protectCreation", func() {
+		desired := map[string]string{"kubernetes.io/managed-by": "operator"}
+		existing := map[string]string{"kubernetes.io/managed-by": "operator"}
+
+		result := applyProtectionLogic(desired, existing, []string{"kubernetes.io/*"}, nil, labelsv1alpha1.ProtectionModeFail, true, nil)
+
+		Expect(result.ShouldFail).To(BeFalse())
+		Expect(result.AllowedLabels).To(Equal(desired))
+	})
+
+	It("should use the mode of the first matching rule", func() {
+		desired := map[string]string{
+			"kubernetes.io/managed-by": "operator",
+			"internal/owner":           "team-a",
+			"istio.io/rev":             "canary",
+		}
+		existing := map[string]string{
+			"kubernetes.io/managed-by": "existing-operator",
+			"internal/owner":           "team-b",
+			"istio.io/rev":             "stable",
+		}
+		rules := []labelsv1alpha1.ProtectedLabelRule{
+			{Pattern: "internal/*", Mode: labelsv1alpha1.ProtectionModeSkip},
+			{Pattern: "istio.io/*"},
+		}
+
+		By("skipping and warning without failing when only rule-matched keys conflict")
+		result := applyProtectionLogic(desired, existing, []string{"other/*"}, rules, labelsv1alpha1.ProtectionModeWarn, false, nil)
+		Expect(result.ShouldFail).To(BeFalse())
+		Expect(result.ProtectedSkipped).To(ConsistOf("internal/owner", "istio.io/rev"))
+		Expect(result.Warnings).To(ConsistOf(ContainSubstring("istio.io/rev")), "rules without a mode use the CR-wide mode")
+		Expect(result.AllowedLabels).To(HaveKeyWithValue("kubernetes.io/managed-by", "operator"))
+
+		By("failing when a fail rule matches")
+		rules = append(rules, labelsv1alpha1.ProtectedLabelRule{Pattern: "kubernetes.io/*", Mode: labelsv1alpha1.ProtectionModeFail})
+		result = applyProtectionLogic(desired, existing, nil, rules, labelsv1alpha1.ProtectionModeSkip, false, nil)
+		Expect(result.ShouldFail).To(BeTrue())
+
+		By("letting an earlier rule win over a later pattern for the same key")
+		result = applyProtectionLogic(desired, existing, []string{"internal/*"},
+			[]labelsv1alpha1.ProtectedLabelRule{{Pattern: "internal/*", Mode: labelsv1alpha1.ProtectionModeSkip}},
+			labelsv1alpha1.ProtectionModeFail, false, nil)
+		Expect(result.ShouldFail).To(BeFalse())
+		Expect(result.ProtectedSkipped).To(ConsistOf("internal/owner"))
+	})
+
+	DescribeTable("should protect labels whose current value matches a protected value pattern",
+		func(mode labelsv1alpha1.ProtectionMode, shouldFail bool, warnings int) {
+			desired := map[string]string{"tier": "standard", "app": "web"}
+			existing := map[string]string{"tier": "critical", "app": "api"}
+
+			result := applyProtectionLogic(desired, existing, nil, nil, mode, false, []string{"crit*"})
+
+			Expect(result.ShouldFail).To(Equal(shouldFail))
+			Expect(result.Warnings).To(HaveLen(warnings))
+			if shouldFail {
+				Expect(result.Warnings[0]).To(ContainSubstring("has protected value 'critical'"))
+				return
+			}
+			Expect(result.ProtectedSkipped).To(Equal([]string{"tier"}))
+			Expect(result.AllowedLabels).To(Equal(map[string]string{"app": "web"}))
+		},
+		Entry("skip mode", labelsv1alpha1.ProtectionModeSkip, false, 0),
+		Entry("warn mode", labelsv1alpha1.ProtectionModeWarn, false, 1),
+		Entry("fail mode", labelsv1alpha1.ProtectionModeFail, true, 1),
+	)
+
+	It("should only guard existing protected values", func() {
+		desired := map[string]string{"tier": "critical", "env": "critical"}
+		existing := map[string]string{"tier": "critical"}
+
+		result := applyProtectionLogic(desired, existing, nil, nil, labelsv1alpha1.ProtectionModeFail, true,
+			[]string{"regex:^critical$"})
+
+		Expect(result.ShouldFail).To(BeFalse())
+		Expect(result.AllowedLabels).To(Equal(desired))
+	})
+
+	It("should report the pattern protecting each skipped or conflicting key", func() {
+		desired := map[string]string{"internal/owner": "team-a", "istio.io/rev": "canary", "tier": "standard", "app": "web"}
+		existing := map[string]string{"internal/owner": "team-b", "istio.io/rev": "stable", "tier": "critical", "app": "api"}
+		rules := []labelsv1alpha1.ProtectedLabelRule{{Pattern: "regex:^istio\\.io/", Mode: labelsv1alpha1.ProtectionModeFail}}
+
+		result := applyProtectionLogic(desired, existing, []string{"other/*", "internal/*"}, rules,
+			labelsv1alpha1.ProtectionModeSkip, false, []string{"crit*"})
+
+		Expect(result.Reasons).To(Equal(map[string]string{
+			"internal/owner": "internal/*",
+			"istio.io/rev":   "regex:^istio\\.io/",
+			"tier":           "crit*",
+		}))
+	})
+
+	It("should drop labels with empty keys and report a warning", func() {
+		desired := map[string]string{
+			"":    "orphan",
+			"app": "myapp",
+		}
+		existing := map[string]string{}
+
+		result := applyProtectionLogic(desired, existing, nil, nil, labelsv1alpha1.ProtectionModeFail, false, nil)
+
+		Expect(result.ShouldFail).To(BeFalse())
+		Expect(result.AllowedLabels).To(Equal(map[string]string{"app": "myapp"}))
+		Expect(result.Warnings).To(HaveLen(1))
+		Expect(result.Warnings[0]).To(ContainSubstring("empty key"))
+		Expect(result.EmptyKeysDropped).To(Equal(1))
+	})
 })
 
 var _ = Describe("updateStatus", func() {
@@ -362,4 +739,99 @@ var _ = Describe("updateStatus", func() {
 		Expect(condition.Reason).To(Equal("InvalidName"))
 		Expect(condition.Message).To(Equal("CR must be named 'labels'"))
 	})
+
+	It("should count applied and skipped labels for the printer columns", func() {
+		cr := &labelsv1alpha1.NamespaceLabel{}
+
+		updateStatus(cr, true, "Synced", "Applied 2 labels", []string{"kubernetes.io/owner"}, []string{"env", "team"})
+		Expect(cr.Status.LabelsAppliedCount).To(Equal(int32(2)))
+		Expect(cr.Status.ProtectedLabelsSkippedCount).To(Equal(int32(1)))
+
+		updateStatus(cr, false, "ProtectedLabelConflict", "Protected label conflicts", nil, nil)
+		Expect(cr.Status.LabelsAppliedCount).To(BeZero())
+		Expect(cr.Status.ProtectedLabelsSkippedCount).To(BeZero())
+	})
+})
+
+var _ = Describe("updateCRStatus", func() {
+	var (
+		ctx       context.Context
+		conflicts int
+		attempts  int
+	)
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+		attempts = 0
+	})
+
+	newReconciler := func(retries int) (*NamespaceLabelReconciler, client.Client) {
+		f := newTestFixture()
+		f.setClient(newClientBuilder(f.scheme).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+					attempts++
+					if attempts <= conflicts {
+						return apierrors.NewConflict(schema.GroupResource{Resource: "namespacelabels"}, obj.GetName(), errors.New("object was modified"))
+					}
+					return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+				},
+			}).
+			Build())
+		f.reconciler.StatusUpdateRetries = retries
+		return f.reconciler, f.client
+	}
+
+	createCR := func(c client.Client) *labelsv1alpha1.NamespaceLabel {
+		cr := &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+		}
+		Expect(c.Create(ctx, cr)).To(Succeed())
+		return cr
+	}
+
+	It("should retry a conflicting status update and keep the computed status", func() {
+		conflicts = 1
+		reconciler, fakeClient := newReconciler(0)
+		cr := createCR(fakeClient)
+
+		updateStatus(cr, true, "Synced", "Applied 1 labels", nil, []string{"env"})
+		Expect(reconciler.updateCRStatus(ctx, cr)).To(Succeed())
+		Expect(attempts).To(Equal(2))
+
+		var stored labelsv1alpha1.NamespaceLabel
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(cr), &stored)).To(Succeed())
+		Expect(stored.Status.Applied).To(BeTrue())
+		Expect(stored.Status.LabelsApplied).To(Equal([]string{"env"}))
+	})
+
+	It("should give up after the configured number of attempts", func() {
+		conflicts = 10
+		reconciler, fakeClient := newReconciler(2)
+		cr := createCR(fakeClient)
+
+		err := reconciler.updateCRStatus(ctx, cr)
+		Expect(apierrors.IsConflict(err)).To(BeTrue())
+		Expect(attempts).To(Equal(2))
+	})
+
+	It("should skip the write for a CR that is being deleted", func() {
+		reconciler, fakeClient := newReconciler(0)
+		cr := createCR(fakeClient)
+		now := metav1.Now()
+		cr.DeletionTimestamp = &now
+
+		Expect(reconciler.updateCRStatus(ctx, cr)).To(Succeed())
+		Expect(attempts).To(BeZero())
+	})
+
+	It("should tolerate a CR that was deleted before the write", func() {
+		reconciler, fakeClient := newReconciler(0)
+		cr := createCR(fakeClient)
+		Expect(fakeClient.Delete(ctx, cr.DeepCopy())).To(Succeed())
+
+		updateStatus(cr, true, "Synced", "Applied 1 labels", nil, []string{"env"})
+		Expect(reconciler.updateCRStatus(ctx, cr)).To(Succeed())
+		Expect(attempts).To(Equal(1))
+	})
 })
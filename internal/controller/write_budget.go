@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WriteBudget is a global token bucket shared by all reconciles to cap the operator's API write rate.
+// Admitting a reconcile takes one token, so the queue is paced even when nothing needs writing, and every
+// create, update, patch and delete it then makes takes one more. A write never waits: it runs the bucket into
+// debt and pushes back the waiting reconciles, so a selector reconcile labeling many namespaces holds the
+// others back for as long as its writes would have taken. A reconcile that finds the bucket empty keeps its
+// reservation and is requeued until its slot arrives, so namespaces are served in arrival order instead of
+// racing for freed tokens.
+type WriteBudget struct {
+	limiter *rate.Limiter
+
+	mu       sync.Mutex
+	reserved map[types.NamespacedName]time.Time
+}
+
+// NewWriteBudget creates a write budget allowing qps mutating calls per second with the given burst,
+// raised to one if needed so a reconcile can always be admitted
+func NewWriteBudget(qps float64, burst int) *WriteBudget {
+	if burst < 1 {
+		burst = 1
+	}
+	return &WriteBudget{
+		limiter:  rate.NewLimiter(rate.Limit(qps), burst),
+		reserved: map[types.NamespacedName]time.Time{},
+	}
+}
+
+// acquire takes the admission token for one reconcile of key. It returns zero when the reconcile may proceed,
+// or the delay after which it should be requeued. A nil budget never delays.
+func (b *WriteBudget) acquire(key types.NamespacedName, now time.Time) time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// A previously delayed request owns its slot once the reserved time is reached
+	if at, ok := b.reserved[key]; ok {
+		if !now.Before(at) {
+			delete(b.reserved, key)
+			return 0
+		}
+		return at.Sub(now)
+	}
+
+	res := b.limiter.ReserveN(now, 1)
+	if !res.OK() {
+		return 0
+	}
+	delay := res.DelayFrom(now)
+	if delay > 0 {
+		b.reserved[key] = now.Add(delay)
+	}
+	return delay
+}
+
+// charge takes the token for one write made by an admitted reconcile. A nil budget charges nothing.
+func (b *WriteBudget) charge(now time.Time) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.limiter.ReserveN(now, 1)
+
+	// The write takes a token the waiting reconciles were counting on, so each of their slots moves back
+	if len(b.reserved) > 0 {
+		shift := time.Duration(float64(time.Second) / float64(b.limiter.Limit()))
+		for key, at := range b.reserved {
+			b.reserved[key] = at.Add(shift)
+		}
+	}
+}
+
+// release drops the slot reserved for key, once its CR is gone and will not come back for it
+func (b *WriteBudget) release(key types.NamespacedName) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.reserved, key)
+}
+
+// Create charges the write to the WriteBudget before creating obj
+func (r *NamespaceLabelReconciler) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	r.WriteBudget.charge(r.now())
+	return r.Client.Create(ctx, obj, opts...)
+}
+
+// Update charges the write to the WriteBudget before updating obj
+func (r *NamespaceLabelReconciler) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	r.WriteBudget.charge(r.now())
+	return r.Client.Update(ctx, obj, opts...)
+}
+
+// Patch charges the write to the WriteBudget before patching obj
+func (r *NamespaceLabelReconciler) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	r.WriteBudget.charge(r.now())
+	return r.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// Delete charges the write to the WriteBudget before deleting obj
+func (r *NamespaceLabelReconciler) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	r.WriteBudget.charge(r.now())
+	return r.Client.Delete(ctx, obj, opts...)
+}
+
+// Status returns a status writer whose writes are charged to the WriteBudget
+func (r *NamespaceLabelReconciler) Status() client.SubResourceWriter {
+	return budgetedStatusWriter{SubResourceWriter: r.Client.Status(), r: r}
+}
+
+// budgetedStatusWriter charges every status write to the reconciler's WriteBudget
+type budgetedStatusWriter struct {
+	client.SubResourceWriter
+	r *NamespaceLabelReconciler
+}
+
+func (w budgetedStatusWriter) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	w.r.WriteBudget.charge(w.r.now())
+	return w.SubResourceWriter.Create(ctx, obj, subResource, opts...)
+}
+
+func (w budgetedStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	w.r.WriteBudget.charge(w.r.now())
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (w budgetedStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	w.r.WriteBudget.charge(w.r.now())
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
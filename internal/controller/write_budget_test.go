@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Tests for functions in write_budget.go
+
+var _ = Describe("WriteBudget", Label("controller"), func() {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	key := func(ns string) types.NamespacedName {
+		return types.NamespacedName{Name: StandardCRName, Namespace: ns}
+	}
+
+	It("should never delay when nil", func() {
+		var budget *WriteBudget
+		Expect(budget.acquire(key("a"), start)).To(BeZero())
+	})
+
+	It("should serve delayed requests in arrival order", func() {
+		// One admission per second
+		budget := NewWriteBudget(1, 1)
+
+		Expect(budget.acquire(key("a"), start)).To(BeZero())
+		Expect(budget.acquire(key("b"), start)).To(Equal(time.Second))
+		Expect(budget.acquire(key("c"), start)).To(Equal(2 * time.Second))
+
+		By("retrying before the reserved slot keeps the original place")
+		Expect(budget.acquire(key("c"), start.Add(500*time.Millisecond))).To(Equal(1500 * time.Millisecond))
+
+		By("admitting each request once its slot arrives")
+		Expect(budget.acquire(key("b"), start.Add(time.Second))).To(BeZero())
+		Expect(budget.acquire(key("c"), start.Add(time.Second))).To(Equal(time.Second))
+		Expect(budget.acquire(key("c"), start.Add(2*time.Second))).To(BeZero())
+	})
+
+	It("should raise a burst that is too small for a single reconcile", func() {
+		budget := NewWriteBudget(1, 0)
+		Expect(budget.acquire(key("a"), start)).To(BeZero())
+	})
+
+	It("should push waiting requests back for every write charged", func() {
+		budget := NewWriteBudget(1, 1)
+		Expect(budget.acquire(key("a"), start)).To(BeZero())
+		Expect(budget.acquire(key("b"), start)).To(Equal(time.Second))
+
+		By("charging two writes of the admitted reconcile")
+		budget.charge(start)
+		budget.charge(start)
+		Expect(budget.acquire(key("b"), start)).To(Equal(3 * time.Second))
+		Expect(budget.acquire(key("c"), start)).To(Equal(4 * time.Second))
+	})
+
+	It("should drop the slot of a released request", func() {
+		budget := NewWriteBudget(1, 1)
+		Expect(budget.acquire(key("a"), start)).To(BeZero())
+		Expect(budget.acquire(key("b"), start)).To(Equal(time.Second))
+
+		budget.release(key("b"))
+		Expect(budget.reserved).To(BeEmpty())
+		Expect(budget.acquire(key("b"), start)).To(Equal(2 * time.Second))
+	})
+
+	It("should charge a selector reconcile for every namespace it patches", func() {
+		f := newTestFixture()
+		reconciler, fakeClient, ctx := f.reconciler, f.client, f.ctx
+		budget := NewWriteBudget(1, 1)
+		reconciler.Clock = clocktesting.NewFakeClock(start)
+		reconciler.WriteBudget = budget
+		reconciler.SelectorNamespace = "platform"
+
+		Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform"}})).To(Succeed())
+		for i := 0; i < 5; i++ {
+			Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("team-%d", i),
+				Labels: map[string]string{"tenant": "true"},
+			}})).To(Succeed())
+		}
+		Expect(fakeClient.Create(ctx, &labelsv1alpha1.NamespaceLabel{
+			ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: "platform", Finalizers: []string{FinalizerName}},
+			Spec: labelsv1alpha1.NamespaceLabelSpec{
+				Labels:            map[string]string{"cost-center": "shared"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+			},
+		})).To(Succeed())
+
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key("platform")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		// The next reconcile waits at least for the five namespace patches
+		Expect(budget.acquire(key("other"), start)).To(BeNumerically(">", 5*time.Second))
+	})
+
+	It("should eventually reconcile every namespace under a tight budget", func() {
+		f := newTestFixture()
+		reconciler, fakeClient, ctx := f.reconciler, f.client, f.ctx
+		fakeClock := clocktesting.NewFakeClock(start)
+		// A first apply makes four writes, the validated annotation, the namespace patch, the last applied spec
+		// and the status, so with its admission it takes the five tokens the budget refills every second
+		reconciler.Clock = fakeClock
+		reconciler.WriteBudget = NewWriteBudget(5, 5)
+
+		const namespaces = 10
+		pending := map[string]bool{}
+		for i := 0; i < namespaces; i++ {
+			name := fmt.Sprintf("ns-%d", i)
+			Expect(fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})).To(Succeed())
+			Expect(fakeClient.Create(ctx, &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: StandardCRName, Namespace: name, Finalizers: []string{FinalizerName}},
+				Spec:       labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"app": name}},
+			})).To(Succeed())
+			pending[name] = true
+		}
+
+		// Drive the queue: every namespace is attempted at each tick until it has been admitted
+		for tick := 0; tick <= 2*namespaces && len(pending) > 0; tick++ {
+			admitted := 0
+			for i := 0; i < namespaces; i++ {
+				name := fmt.Sprintf("ns-%d", i)
+				if !pending[name] {
+					continue
+				}
+				result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key(name)})
+				Expect(err).NotTo(HaveOccurred())
+				if result.RequeueAfter == 0 {
+					delete(pending, name)
+					admitted++
+				}
+			}
+			Expect(admitted).To(BeNumerically("<=", 1), "budget admits at most one reconcile per second")
+			fakeClock.Step(time.Second)
+		}
+		Expect(pending).To(BeEmpty())
+
+		for i := 0; i < namespaces; i++ {
+			var ns corev1.Namespace
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("ns-%d", i)}, &ns)).To(Succeed())
+			Expect(ns.Labels).To(HaveKeyWithValue("app", fmt.Sprintf("ns-%d", i)))
+		}
+	})
+})
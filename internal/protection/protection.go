@@ -0,0 +1,219 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package protection matches label keys and values against protection patterns. The controller enforces
+// protection with it and the webhook previews it, so both always agree on what a pattern protects.
+package protection
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+)
+
+var (
+	// SystemNamespaces match the namespaces that get built-in protection whatever their CR configures
+	SystemNamespaces = CompilePatterns([]string{"kube-*"})
+	// SystemProtectedLabels match the label keys protected in fail mode on system namespaces
+	SystemProtectedLabels = CompilePatterns([]string{"kubernetes.io/*", "*.kubernetes.io/*"})
+)
+
+// Matcher matches label keys against a single protection pattern
+type Matcher struct {
+	// Pattern is the pattern as written, reported as the reason a key is protected
+	Pattern string
+	// Mode is the protection mode for keys matching this pattern
+	Mode labelsv1alpha1.ProtectionMode
+	// Negated marks a "!" exception, which exempts the keys it matches instead of protecting them
+	Negated bool
+
+	glob  string
+	regex *regexp.Regexp
+}
+
+// Compile prepares a single glob or regex pattern.
+// Empty patterns and regexes that fail to compile are reported as unusable, matching how malformed globs never match.
+func Compile(pattern string, mode labelsv1alpha1.ProtectionMode) (Matcher, bool) {
+	if pattern == "" {
+		return Matcher{}, false
+	}
+	if expr, ok := strings.CutPrefix(pattern, labelsv1alpha1.RegexPatternPrefix); ok {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return Matcher{}, false
+		}
+		return Matcher{Pattern: pattern, regex: re, Mode: mode}, true
+	}
+	// filepath.Match has no "**", so those globs are matched as their regex translation
+	if IsDoubleStarGlob(pattern) {
+		re, err := CompileDoubleStarGlob(pattern)
+		if err != nil {
+			return Matcher{}, false
+		}
+		return Matcher{Pattern: pattern, regex: re, Mode: mode}, true
+	}
+	return Matcher{Pattern: pattern, glob: pattern, Mode: mode}, true
+}
+
+// CompilePatterns prepares the patterns once so each label check stays cheap
+func CompilePatterns(patterns []string) []Matcher {
+	matchers := make([]Matcher, 0, len(patterns))
+	for _, pattern := range patterns {
+		if m, ok := Compile(pattern, ""); ok {
+			matchers = append(matchers, m)
+		}
+	}
+	return matchers
+}
+
+// CompileRules prepares the per-pattern rules followed by the plain patterns.
+// Rules without a mode, and all plain patterns, use defaultMode. Plain patterns prefixed with "!" become exceptions.
+func CompileRules(rules []labelsv1alpha1.ProtectedLabelRule, patterns []string,
+	defaultMode labelsv1alpha1.ProtectionMode) []Matcher {
+	matchers := make([]Matcher, 0, len(rules)+len(patterns))
+	for _, rule := range rules {
+		mode := rule.Mode
+		if mode == "" {
+			mode = defaultMode
+		}
+		if m, ok := Compile(rule.Pattern, mode); ok {
+			matchers = append(matchers, m)
+		}
+	}
+	for _, pattern := range patterns {
+		expr, negated := strings.CutPrefix(pattern, labelsv1alpha1.NegatedPatternPrefix)
+		if m, ok := Compile(expr, defaultMode); ok {
+			m.Negated = negated
+			matchers = append(matchers, m)
+		}
+	}
+	return matchers
+}
+
+// Matches reports whether the label key matches the pattern
+func (m Matcher) Matches(labelKey string) bool {
+	if m.regex != nil {
+		return m.regex.MatchString(labelKey)
+	}
+	// Use filepath.Match for glob pattern matching; malformed patterns never match
+	// so they cannot break protection
+	matched, err := filepath.Match(m.glob, labelKey)
+	return err == nil && matched
+}
+
+// Protecting returns the first rule or pattern protecting the label key. Exceptions take precedence
+// over every match, so a key matching any exception is never protected.
+func Protecting(labelKey string, matchers []Matcher) (Matcher, bool) {
+	var first Matcher
+	protected := false
+	for _, m := range matchers {
+		if !m.Matches(labelKey) {
+			continue
+		}
+		if m.Negated {
+			return Matcher{}, false
+		}
+		if !protected {
+			first, protected = m, true
+		}
+	}
+	return first, protected
+}
+
+// First returns the first compiled pattern matching the label key
+func First(labelKey string, matchers []Matcher) (Matcher, bool) {
+	for _, m := range matchers {
+		if m.Matches(labelKey) {
+			return m, true
+		}
+	}
+	return Matcher{}, false
+}
+
+// MatchesAny checks if a label key matches any of the compiled patterns
+func MatchesAny(labelKey string, matchers []Matcher) bool {
+	_, ok := First(labelKey, matchers)
+	return ok
+}
+
+// ActivePatterns returns the CR's protection patterns plus those of the tier selected by the
+// namespace's tier label. Without a tier label, or for a tier with no entry, only the base patterns apply.
+func ActivePatterns(spec labelsv1alpha1.NamespaceLabelSpec, nsLabels map[string]string) []string {
+	if spec.ProtectionTierLabel == "" {
+		return spec.ProtectedLabelPatterns
+	}
+	tier, ok := nsLabels[spec.ProtectionTierLabel]
+	if !ok {
+		return spec.ProtectedLabelPatterns
+	}
+	patterns := spec.ProtectedLabelPatterns
+	for _, tp := range spec.TierProtectedLabelPatterns {
+		if tp.Tier == tier {
+			patterns = append(slices.Clip(patterns), tp.Patterns...)
+		}
+	}
+	return patterns
+}
+
+// IsSystemNamespace reports whether the namespace is a system namespace
+func IsSystemNamespace(name string) bool {
+	return MatchesAny(name, SystemNamespaces)
+}
+
+// IsDoubleStarGlob reports whether a glob pattern uses "**", which filepath.Match does not support
+func IsDoubleStarGlob(pattern string) bool {
+	return strings.Contains(pattern, "**")
+}
+
+// CompileDoubleStarGlob translates a glob pattern into an anchored regular expression. "**" matches any
+// characters including "/", while "*", "?" and character classes keep their filepath.Match meaning.
+func CompileDoubleStarGlob(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*':
+			if strings.HasPrefix(pattern[i:], "**") {
+				expr.WriteString(".*")
+				i++
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		case '\\':
+			if i+1 == len(pattern) {
+				return nil, filepath.ErrBadPattern
+			}
+			i++
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end <= 0 || pattern[i+1:i+1+end] == "^" {
+				return nil, filepath.ErrBadPattern
+			}
+			expr.WriteString(pattern[i : i+end+2])
+			i += end + 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.Compile(expr.String())
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protection
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+)
+
+var _ = Describe("Protecting", func() {
+	It("should return the first rule or pattern matching the key", func() {
+		matchers := CompileRules([]labelsv1alpha1.ProtectedLabelRule{{Pattern: "example.com/owner", Mode: labelsv1alpha1.ProtectionModeFail}},
+			[]string{"example.com/*"}, labelsv1alpha1.ProtectionModeWarn)
+
+		m, ok := Protecting("example.com/owner", matchers)
+		Expect(ok).To(BeTrue())
+		Expect(m.Pattern).To(Equal("example.com/owner"))
+		Expect(m.Mode).To(Equal(labelsv1alpha1.ProtectionModeFail))
+
+		m, ok = Protecting("example.com/team", matchers)
+		Expect(ok).To(BeTrue())
+		Expect(m.Pattern).To(Equal("example.com/*"))
+		Expect(m.Mode).To(Equal(labelsv1alpha1.ProtectionModeWarn))
+	})
+
+	It("should let an exception win over every match", func() {
+		matchers := CompileRules([]labelsv1alpha1.ProtectedLabelRule{{Pattern: "example.com/*"}},
+			[]string{"regex:^example", "!example.com/public"}, labelsv1alpha1.ProtectionModeSkip)
+		_, ok := Protecting("example.com/public", matchers)
+		Expect(ok).To(BeFalse())
+		_, ok = Protecting("example.com/private", matchers)
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should drop unusable patterns", func() {
+		Expect(CompilePatterns([]string{"", "regex:(", "**/[abc", "team"})).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("System namespaces", func() {
+	DescribeTable("IsSystemNamespace",
+		func(name string, expected bool) {
+			Expect(IsSystemNamespace(name)).To(Equal(expected))
+		},
+		Entry("kube-system", "kube-system", true),
+		Entry("kube-public", "kube-public", true),
+		Entry("default", "default", false),
+		Entry("a kube- suffix", "my-kube-apps", false),
+	)
+
+	It("should protect kubernetes.io keys and their subdomains", func() {
+		Expect(MatchesAny("kubernetes.io/metadata.name", SystemProtectedLabels)).To(BeTrue())
+		Expect(MatchesAny("pod-security.kubernetes.io/enforce", SystemProtectedLabels)).To(BeTrue())
+		Expect(MatchesAny("example.com/team", SystemProtectedLabels)).To(BeFalse())
+	})
+})
+
+var _ = Describe("ActivePatterns", func() {
+	spec := labelsv1alpha1.NamespaceLabelSpec{
+		ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+		ProtectionTierLabel:    "quota-tier",
+		TierProtectedLabelPatterns: []labelsv1alpha1.TierProtectedLabelPatterns{
+			{Tier: "gold", Patterns: []string{"billing/*", "team"}},
+			{Tier: "silver", Patterns: []string{"billing/*"}},
+		},
+	}
+
+	DescribeTable("selects patterns by the namespace tier",
+		func(nsLabels map[string]string, expected []string) {
+			Expect(ActivePatterns(spec, nsLabels)).To(Equal(expected))
+		},
+		Entry("gold tier", map[string]string{"quota-tier": "gold"}, []string{"kubernetes.io/*", "billing/*", "team"}),
+		Entry("silver tier", map[string]string{"quota-tier": "silver"}, []string{"kubernetes.io/*", "billing/*"}),
+		Entry("unknown tier", map[string]string{"quota-tier": "bronze"}, []string{"kubernetes.io/*"}),
+		Entry("no tier label", map[string]string{}, []string{"kubernetes.io/*"}),
+	)
+
+	It("should not modify the base patterns", func() {
+		ActivePatterns(spec, map[string]string{"quota-tier": "gold"})
+		Expect(spec.ProtectedLabelPatterns).To(Equal([]string{"kubernetes.io/*"}))
+	})
+})
+
+var _ = Describe("Double star globs", func() {
+	DescribeTable("CompileDoubleStarGlob",
+		func(pattern, key string, expected bool) {
+			re, err := CompileDoubleStarGlob(pattern)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(re.MatchString(key)).To(Equal(expected))
+		},
+		Entry("** spans separators", "kubernetes.io/**", "kubernetes.io/foo/bar", true),
+		Entry("* stops at separators", "**/*", "example.com/a/b", true),
+		Entry("* alone stops at separators", "example.com/*", "example.com/a/b", false),
+		Entry("? matches one character", "team-?/**", "team-a/x", true),
+		Entry("dots are literal", "example.com/**", "exampleXcom/team", false),
+		Entry("character classes are kept", "k[0-9]s.io/**", "k8s.io/app", true),
+		Entry("escaped stars are literal", `a\*b/**`, "a*b/c", true),
+		Entry("the whole key must match", "**.example.com", "team.example.com/owner", false),
+	)
+
+	DescribeTable("malformed patterns",
+		func(pattern string) {
+			_, err := CompileDoubleStarGlob(pattern)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("unclosed class", "**/[abc"),
+		Entry("empty class", "**/[]"),
+		Entry("trailing escape", `**\`),
+	)
+})
@@ -20,9 +20,11 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -36,20 +38,79 @@ var namespacelabellog = logf.Log.WithName("namespacelabel-resource")
 const (
 	// StandardCRName is the required name for NamespaceLabel CRs (singleton pattern)
 	StandardCRName = "labels"
+
+	// FinalizerName is the controller's cleanup finalizer, injected at creation so it is present
+	// before any labels are applied
+	FinalizerName = "labels.shahaf.com/finalizer"
+
+	// reservedAnnotationPrefix is used by the operator's own tracking annotations on namespaces
+	reservedAnnotationPrefix = "labels.shahaf.com/"
+
+	// AllowReservedLabelsAnnoKey on a namespace set to "true" lets its NamespaceLabel set reserved label keys
+	AllowReservedLabelsAnnoKey = "labels.shahaf.com/allow-reserved-labels"
 )
 
-func SetupNamespaceLabelWebhookWithManager(mgr ctrl.Manager) error {
+// DefaultReservedLabelPrefixes are the label prefixes owned by Kubernetes and its tooling
+var DefaultReservedLabelPrefixes = []string{"kubernetes.io/", "k8s.io/"}
+
+// DefaultMaxLabels is the default limit on the number of spec.labels entries in a single NamespaceLabel
+const DefaultMaxLabels = 64
+
+// ListSeparator separates the items of a spec.listMergeKeys label value. A comma is not allowed in label values.
+const ListSeparator = "_"
+
+// MaxMultipleNameLength is the longest NamespaceLabel name allowed when a namespace may hold several, so the
+// controller's "labels.shahaf.com/applied-annotations.<name>" tracking key stays a valid annotation key
+const MaxMultipleNameLength = 43
+
+func SetupNamespaceLabelWebhookWithManager(mgr ctrl.Manager, reservedLabelPrefixes []string, maxLabels int, deniedValueSubstrings []string,
+	valueCharset ValueCharset, rejectSelfProtectedLabels, allowMultipleNamespaceLabels bool, defaultProtectedLabelPatterns,
+	mandatoryLabelPatterns []string) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&labelsv1alpha1.NamespaceLabel{}).
+		WithDefaulter(&NamespaceLabelCustomDefaulter{}).
 		WithValidator(&NamespaceLabelCustomValidator{
-			Client: mgr.GetClient(),
+			Client:                        mgr.GetClient(),
+			ReservedLabelPrefixes:         reservedLabelPrefixes,
+			MaxLabels:                     maxLabels,
+			DeniedValueSubstrings:         deniedValueSubstrings,
+			ValueCharset:                  valueCharset,
+			RejectSelfProtectedLabels:     rejectSelfProtectedLabels,
+			AllowMultipleNamespaceLabels:  allowMultipleNamespaceLabels,
+			DefaultProtectedLabelPatterns: defaultProtectedLabelPatterns,
+			MandatoryLabelPatterns:        mandatoryLabelPatterns,
 		}).
 		Complete()
 }
 
-// NOTE: Webhook validates create and update operations only. Deletion cleanup is handled by the controller's finalizer.
+// +kubebuilder:webhook:path=/mutate-labels-shahaf-com-v1alpha1-namespacelabel,mutating=true,failurePolicy=fail,sideEffects=None,groups=labels.shahaf.com,resources=namespacelabels,verbs=create,versions=v1alpha1,name=mnamespacelabel-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// NamespaceLabelCustomDefaulter fills in spec defaults and the cleanup finalizer when a NamespaceLabel
+// is created, so the effective values are visible on the stored object.
+type NamespaceLabelCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &NamespaceLabelCustomDefaulter{}
+
+// Default sets spec.protectionMode to skip when it is empty and adds the controller's finalizer
+func (d *NamespaceLabelCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	namespacelabel, ok := obj.(*labelsv1alpha1.NamespaceLabel)
+	if !ok {
+		return fmt.Errorf("expected a NamespaceLabel object but got %T", obj)
+	}
+	namespacelabellog.Info("Defaulting for NamespaceLabel", "name", namespacelabel.GetName(), "namespace", namespacelabel.GetNamespace())
+
+	if namespacelabel.Spec.ProtectionMode == "" {
+		namespacelabel.Spec.ProtectionMode = labelsv1alpha1.ProtectionModeSkip
+	}
+	controllerutil.AddFinalizer(namespacelabel, FinalizerName)
+	return nil
+}
+
+// NOTE: Deletion is never rejected, only warned about, so it is served by a separate webhook entry that ignores
+// failures; a webhook outage must not block deleting the NamespaceLabels of a terminating namespace.
 // NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
 // Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
 // +kubebuilder:webhook:path=/validate-labels-shahaf-com-v1alpha1-namespacelabel,mutating=false,failurePolicy=fail,sideEffects=None,groups=labels.shahaf.com,resources=namespacelabels,verbs=create;update,versions=v1alpha1,name=vnamespacelabel-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-labels-shahaf-com-v1alpha1-namespacelabel,mutating=false,failurePolicy=ignore,sideEffects=None,groups=labels.shahaf.com,resources=namespacelabels,verbs=delete,versions=v1alpha1,name=vnamespacelabel-delete-v1alpha1.kb.io,admissionReviewVersions=v1
 
 // NamespaceLabelCustomValidator struct is responsible for validating the NamespaceLabel resource
 // when it is created or updated.
@@ -58,6 +119,37 @@ func SetupNamespaceLabelWebhookWithManager(mgr ctrl.Manager) error {
 // as this struct is used only for temporary operations and does not need to be deeply copied.
 type NamespaceLabelCustomValidator struct {
 	Client client.Client
+
+	// ReservedLabelPrefixes are label prefixes a NamespaceLabel may only set when its namespace carries
+	// AllowReservedLabelsAnnoKey. A prefix also covers its subdomains, so "kubernetes.io/" reserves
+	// "app.kubernetes.io/". Empty disables the check.
+	ReservedLabelPrefixes []string
+
+	// MaxLabels is the most spec.labels entries a NamespaceLabel may hold. 0 disables the limit.
+	MaxLabels int
+
+	// DeniedValueSubstrings are substrings, such as "password", that no spec.labels value may contain, compared
+	// case-insensitively, since labels are readable by anyone who can list namespaces. Empty disables the check.
+	DeniedValueSubstrings []string
+
+	// ValueCharset restricts the characters of spec.labels values. ValueCharsetAny disables the check.
+	ValueCharset ValueCharset
+
+	// RejectSelfProtectedLabels rejects spec.labels keys matching the CR's own fail-mode protection instead of
+	// warning about them, since such a label fails every reconcile the namespace does not already agree with
+	RejectSelfProtectedLabels bool
+
+	// AllowMultipleNamespaceLabels admits any number of NamespaceLabels per namespace under any name up to
+	// MaxMultipleNameLength, matching the controller's --allow-multiple-namespace-labels
+	AllowMultipleNamespaceLabels bool
+
+	// DefaultProtectedLabelPatterns are the operator's default protection patterns, matching the controller's
+	// --default-protected-label-patterns, so the protection preview warns about the labels they protect
+	DefaultProtectedLabelPatterns []string
+
+	// MandatoryLabelPatterns are the labels every namespace must keep, matching the controller's
+	// --mandatory-label-patterns. A CR that sets a mandatory label under its own fail-mode protection is rejected.
+	MandatoryLabelPatterns []string
 }
 
 var _ webhook.CustomValidator = &NamespaceLabelCustomValidator{}
@@ -79,7 +171,15 @@ func (v *NamespaceLabelCustomValidator) ValidateCreate(ctx context.Context, obj
 		return nil, err
 	}
 
-	return nil, nil
+	// Validate spec contents (protection patterns, annotations, reserved label prefixes)
+	if err := v.validateSpec(ctx, namespacelabel); err != nil {
+		return nil, err
+	}
+
+	// Warn about an implicit protection mode, self-protected labels and labels protection would skip at reconcile
+	// without blocking the request
+	warnings := append(implicitProtectionModeWarnings(namespacelabel), selfProtectionWarnings(namespacelabel)...)
+	return append(warnings, v.protectionWarnings(ctx, namespacelabel)...), nil
 }
 
 func (v *NamespaceLabelCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -105,15 +205,36 @@ func (v *NamespaceLabelCustomValidator) ValidateUpdate(ctx context.Context, oldO
 		return nil, err
 	}
 
-	return nil, nil
+	// Validate spec contents (protection patterns, annotations, reserved label prefixes)
+	if err := v.validateSpec(ctx, namespacelabel); err != nil {
+		return nil, err
+	}
+
+	// Warn about an implicit protection mode, self-protected labels and labels protection would skip at reconcile
+	// without blocking the request
+	warnings := append(implicitProtectionModeWarnings(namespacelabel), selfProtectionWarnings(namespacelabel)...)
+	return append(warnings, v.protectionWarnings(ctx, namespacelabel)...), nil
 }
 
-// ValidateDelete implements webhook.CustomValidator interface but performs no validation.
-// Deletion cleanup is handled by the controller's finalizer logic.
+// ValidateDelete implements webhook.CustomValidator interface and always allows deletion.
+// Deletion cleanup is handled by the controller's finalizer logic; deleting the CR of a terminating namespace
+// only gets a warning that its labels are left for the namespace to take with it.
 func (v *NamespaceLabelCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
-	_, ok := obj.(*labelsv1alpha1.NamespaceLabel)
+	namespacelabel, ok := obj.(*labelsv1alpha1.NamespaceLabel)
 	if !ok {
 		return nil, fmt.Errorf("expected a NamespaceLabel object but got %T", obj)
 	}
-	return nil, nil
+
+	var ns corev1.Namespace
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: namespacelabel.Namespace}, &ns); err != nil {
+		namespacelabellog.V(1).Info("Skipping terminating namespace check", "namespace", namespacelabel.Namespace, "error", err.Error())
+		return nil, nil
+	}
+	if ns.DeletionTimestamp.IsZero() && ns.Status.Phase != corev1.NamespaceTerminating {
+		return nil, nil
+	}
+	namespacelabellog.Info("Deleting NamespaceLabel of a terminating namespace",
+		"name", namespacelabel.GetName(), "namespace", namespacelabel.GetNamespace())
+	return admission.Warnings{fmt.Sprintf(
+		"namespace '%s' is terminating; the NamespaceLabel is released without removing its labels", namespacelabel.Namespace)}, nil
 }
@@ -18,6 +18,10 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -25,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
 )
@@ -43,6 +48,41 @@ var _ = Describe("NamespaceLabel Webhook", Label("webhook"), func() {
 		Expect(corev1.AddToScheme(scheme)).To(Succeed())
 	})
 
+	Describe("Default", func() {
+		It("should default an empty protection mode to skip", func() {
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+			}
+			Expect((&NamespaceLabelCustomDefaulter{}).Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.ProtectionMode).To(Equal(labelsv1alpha1.ProtectionModeSkip))
+		})
+
+		It("should keep an explicit protection mode", func() {
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec:       labelsv1alpha1.NamespaceLabelSpec{ProtectionMode: labelsv1alpha1.ProtectionModeFail},
+			}
+			Expect((&NamespaceLabelCustomDefaulter{}).Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.ProtectionMode).To(Equal(labelsv1alpha1.ProtectionModeFail))
+		})
+
+		It("should add the finalizer to a freshly created CR", func() {
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+			}
+			Expect((&NamespaceLabelCustomDefaulter{}).Default(ctx, obj)).To(Succeed())
+			Expect(obj.Finalizers).To(Equal([]string{FinalizerName}))
+
+			By("not duplicating a finalizer that is already present")
+			Expect((&NamespaceLabelCustomDefaulter{}).Default(ctx, obj)).To(Succeed())
+			Expect(obj.Finalizers).To(Equal([]string{FinalizerName}))
+		})
+
+		It("should reject other object types", func() {
+			Expect((&NamespaceLabelCustomDefaulter{}).Default(ctx, &corev1.Namespace{})).NotTo(Succeed())
+		})
+	})
+
 	Describe("ValidateCreate", func() {
 		Context("When validating name", func() {
 			It("should allow creation with correct name 'labels'", func() {
@@ -55,40 +95,908 @@ var _ = Describe("NamespaceLabel Webhook", Label("webhook"), func() {
 						Namespace: "test-ns",
 					},
 					Spec: labelsv1alpha1.NamespaceLabelSpec{
-						Labels: map[string]string{"env": "test"},
+						Labels: map[string]string{"env": "test"},
+					},
+				}
+
+				warnings, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+			})
+
+			It("should reject creation with incorrect name", func() {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "invalid-name",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						Labels: map[string]string{"env": "test"},
+					},
+				}
+
+				warnings, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("NamespaceLabel resource must be named 'labels'"))
+				Expect(warnings).To(BeEmpty())
+			})
+		})
+
+		Context("When validating singleton pattern", func() {
+			It("should allow creation when no other NamespaceLabel exists", func() {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						Labels: map[string]string{"env": "test"},
+					},
+				}
+
+				warnings, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(BeEmpty())
+			})
+
+			It("should reject creation when another NamespaceLabel already exists", func() {
+				existing := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						Labels: map[string]string{"existing": "label"},
+					},
+				}
+
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(existing).
+					Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						Labels: map[string]string{"env": "test"},
+					},
+				}
+
+				warnings, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("only one NamespaceLabel resource is allowed per namespace"))
+				Expect(warnings).To(BeEmpty())
+			})
+		})
+
+		Context("When several NamespaceLabels are allowed per namespace", func() {
+			It("should admit another NamespaceLabel under any name", func() {
+				existing := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				}
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient, AllowMultipleNamespaceLabels: true}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "test-ns"},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						Labels:   map[string]string{"env": "test"},
+						Priority: 10,
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should reject a name too long for the tracking annotation keys", func() {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient, AllowMultipleNamespaceLabels: true}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", MaxMultipleNameLength+1), Namespace: "test-ns"},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("at most 43 are allowed"))
+			})
+		})
+	})
+
+	Describe("Protection pattern validation", func() {
+		DescribeTable("regex patterns",
+			func(patterns []string, expectErr bool) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						ProtectedLabelPatterns: patterns,
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if expectErr {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("invalid regex protection pattern"))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("glob patterns only", []string{"kubernetes.io/*", "*.k8s.io/*"}, false),
+			Entry("valid regex", []string{`regex:^.*\.secret\..*$`}, false),
+			Entry("invalid regex", []string{"kubernetes.io/*", "regex:^(unclosed"}, true),
+			Entry("valid regex exception", []string{"kubernetes.io/*", "!regex:^kubernetes\\.io/public$"}, false),
+		)
+
+		DescribeTable("exception patterns",
+			func(patterns []string, rules []labelsv1alpha1.ProtectedLabelRule, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						ProtectedLabelPatterns: patterns,
+						ProtectedLabelRules:    rules,
+						ProtectionMode:         labelsv1alpha1.ProtectionModeSkip,
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("glob exception", []string{"example.com/*", "!example.com/public"}, nil, ""),
+			Entry("bare exception", []string{"example.com/*", "!"}, nil,
+				"invalid protection exception '!': '!' must be followed by a glob or regex pattern, "+
+					"and keys it matches are never protected, whatever rule or pattern matches them"),
+			Entry("double negation", []string{"!!example.com/public"}, nil, "invalid protection exception '!!example.com/public'"),
+			Entry("malformed glob exception", []string{"!example.com/[unclosed"}, nil,
+				"invalid glob protection exception pattern 'example.com/[unclosed'"),
+			Entry("invalid regex exception", []string{"kubernetes.io/*", "!regex:^(unclosed"}, nil,
+				"invalid regex protection exception pattern 'regex:^(unclosed'"),
+			Entry("exception in a rule", nil, []labelsv1alpha1.ProtectedLabelRule{{Pattern: "!example.com/public"}},
+				"protection rule pattern '!example.com/public' cannot be an exception"),
+		)
+
+		It("should validate tier protection patterns", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					ProtectionTierLabel: "quota-tier",
+					TierProtectedLabelPatterns: []labelsv1alpha1.TierProtectedLabelPatterns{
+						{Tier: "gold", Patterns: []string{"team", "regex:^(unclosed"}},
+					},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid regex tier 'gold' protection pattern"))
+		})
+
+		It("should validate removal protection patterns", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "labels",
+					Namespace: "test-ns",
+				},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					RemovalProtectionPatterns: []string{"team/*", "regex:^owner$"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			obj.Spec.RemovalProtectionPatterns = []string{"team/[unclosed"}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid glob removal protection pattern"))
+		})
+
+		It("should accept ** in glob patterns", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					ProtectedLabelPatterns: []string{"kubernetes.io/**", "**.example.com/team-[a-z]*"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			obj.Spec.ProtectedLabelPatterns = []string{"**/[unclosed"}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid glob protection pattern"))
+		})
+
+		It("should validate protected value patterns", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					ProtectedValuePatterns: []string{"critical", "regex:^prod-.*$"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			obj.Spec.ProtectedValuePatterns = []string{"regex:^(unclosed"}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid regex protected value pattern"))
+		})
+
+		It("should validate allowed label patterns", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "labels",
+					Namespace: "test-ns",
+				},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					AllowedLabelPatterns: []string{"team.example.com/*", "regex:^cost-.*$"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			obj.Spec.AllowedLabelPatterns = []string{"regex:^(unclosed"}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid regex allowed label pattern"))
+		})
+
+		DescribeTable("match-all patterns",
+			func(spec labelsv1alpha1.NamespaceLabelSpec, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+				spec.ProtectionMode = labelsv1alpha1.ProtectionModeSkip
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+					Spec:       spec,
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				By("accepting it with allowMatchAll")
+				obj.Spec.AllowMatchAll = true
+				_, err = validator.ValidateCreate(ctx, obj)
+				Expect(err).NotTo(HaveOccurred())
+			},
+			Entry("bare star", labelsv1alpha1.NamespaceLabelSpec{ProtectedLabelPatterns: []string{"kubernetes.io/*", "*"}},
+				"protection pattern '*' matches everything, so it protects every label; set allowMatchAll if that is intended"),
+			Entry("double star", labelsv1alpha1.NamespaceLabelSpec{ProtectedLabelPatterns: []string{"**"}},
+				"protection pattern '**' matches everything"),
+			Entry("anchored catch-all regex", labelsv1alpha1.NamespaceLabelSpec{ProtectedLabelPatterns: []string{"regex:^.*$"}},
+				"protection pattern 'regex:^.*$' matches everything"),
+			Entry("rule pattern", labelsv1alpha1.NamespaceLabelSpec{ProtectedLabelRules: []labelsv1alpha1.ProtectedLabelRule{{Pattern: "*"}}},
+				"protection rule pattern '*' matches everything"),
+			Entry("tier pattern", labelsv1alpha1.NamespaceLabelSpec{
+				ProtectionTierLabel:        "quota-tier",
+				TierProtectedLabelPatterns: []labelsv1alpha1.TierProtectedLabelPatterns{{Tier: "gold", Patterns: []string{"*"}}},
+			}, "tier 'gold' protection pattern '*' matches everything"),
+			Entry("value pattern", labelsv1alpha1.NamespaceLabelSpec{ProtectedValuePatterns: []string{"regex:.+"}},
+				"protected value pattern 'regex:.+' matches everything"),
+			Entry("catch-all exception", labelsv1alpha1.NamespaceLabelSpec{ProtectedLabelPatterns: []string{"team", "!*"}}, ""),
+			Entry("narrower star pattern", labelsv1alpha1.NamespaceLabelSpec{ProtectedLabelPatterns: []string{"*.io/*", "team-*"}}, ""),
+		)
+
+		DescribeTable("checkGlob",
+			func(pattern string, valid bool) {
+				if valid {
+					Expect(checkGlob(pattern)).To(Succeed())
+				} else {
+					Expect(checkGlob(pattern)).To(MatchError(filepath.ErrBadPattern))
+				}
+			},
+			Entry("plain glob", "example.com/*", true),
+			Entry("character class", "team-[a-z]?", true),
+			Entry("unclosed class after a prefix", "example.com/[unclosed", false),
+			Entry("trailing escape", "team\\", false),
+			Entry("empty class", "team-[]", false),
+		)
+	})
+
+	Describe("Label template validation", func() {
+		DescribeTable("spec.labels",
+			func(labels map[string]string, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						Labels: labels,
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("plain values", map[string]string{"env": "prod"}, ""),
+			Entry("valid template", map[string]string{"team": "{{ .Namespace.Labels.team }}"}, ""),
+			Entry("unterminated template", map[string]string{"team": "{{ .Namespace.Labels.team"}, "invalid template for label 'team'"),
+			Entry("unknown function", map[string]string{"team": "{{ upper .Namespace.Name }}"}, "invalid template for label 'team'"),
+		)
+
+		It("should reject a labelsTemplate that does not parse", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					LabelsTemplate: "{{ range $k, $v := .Namespace.Labels }}{{ $k }}: {{ $v }}\n{{ end }}",
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			obj.Spec.LabelsTemplate = "team: {{ .Namespace.Labels.team"
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid labelsTemplate"))
+		})
+
+		It("should reject keys that become invalid once the key prefix is applied", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					Labels:    map[string]string{"env": "prod"},
+					KeyPrefix: "team.example.com/",
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			obj.Spec.Labels = map[string]string{"other.io/env": "prod"}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid prefixed label key 'team.example.com/other.io/env'"))
+		})
+
+		It("should reject label keys whose prefix is over the DNS subdomain limit with its length", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+			prefix := strings.Repeat("a", 63) + "." + strings.Repeat("b", 63) + "." + strings.Repeat("c", 63) + "." + strings.Repeat("d", 63)
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					Labels: map[string]string{prefix + "/env": "prod"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the prefix before '/' is 255 characters long, over the 253-character limit of a DNS subdomain"))
+
+			By("counting the key prefix towards the limit")
+			obj.Spec.Labels = map[string]string{strings.Repeat("e", 10) + "/env": "prod"}
+			obj.Spec.KeyPrefix = prefix[:250]
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid prefixed label key"))
+
+			By("rejecting other invalid keys as before")
+			obj.Spec.KeyPrefix = ""
+			obj.Spec.Labels = map[string]string{"bad key": "prod"}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid label key 'bad key'"))
+		})
+	})
+
+	Describe("Protection mode validation", func() {
+		DescribeTable("spec.protectedLabelRules",
+			func(mode labelsv1alpha1.ProtectionMode, rules []labelsv1alpha1.ProtectedLabelRule, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						ProtectionMode:      mode,
+						ProtectedLabelRules: rules,
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("per-pattern modes", labelsv1alpha1.ProtectionModeSkip, []labelsv1alpha1.ProtectedLabelRule{
+				{Pattern: "kubernetes.io/*", Mode: labelsv1alpha1.ProtectionModeFail},
+				{Pattern: "internal/*"},
+			}, ""),
+			Entry("unknown CR-wide mode", labelsv1alpha1.ProtectionMode("block"), nil, "invalid protection mode 'block'"),
+			Entry("unknown rule mode", labelsv1alpha1.ProtectionModeSkip, []labelsv1alpha1.ProtectedLabelRule{
+				{Pattern: "kubernetes.io/*", Mode: "block"},
+			}, "for pattern 'kubernetes.io/*'"),
+			Entry("empty rule pattern", labelsv1alpha1.ProtectionModeSkip, []labelsv1alpha1.ProtectedLabelRule{
+				{Mode: labelsv1alpha1.ProtectionModeFail},
+			}, "must not be empty"),
+			Entry("invalid rule regex", labelsv1alpha1.ProtectionModeSkip, []labelsv1alpha1.ProtectedLabelRule{
+				{Pattern: "regex:^(unclosed"},
+			}, "invalid regex protection rule pattern"),
+		)
+	})
+
+	Describe("Namespace selector validation", func() {
+		DescribeTable("spec.namespaceSelector",
+			func(selector *metav1.LabelSelector, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						NamespaceSelector: selector,
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("match labels", &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}}, ""),
+			Entry("unknown operator", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tenant", Operator: "Matches", Values: []string{"true"}},
+			}}, "invalid namespaceSelector"),
+			Entry("exists with values", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tenant", Operator: metav1.LabelSelectorOpExists, Values: []string{"true"}},
+			}}, "invalid namespaceSelector"),
+		)
+	})
+
+	Describe("TTL validation", func() {
+		DescribeTable("spec.ttlSeconds",
+			func(ttl int64, selector *metav1.LabelSelector, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						TTLSeconds:        ttl,
+						NamespaceSelector: selector,
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("positive ttl", int64(3600), nil, ""),
+			Entry("negative ttl", int64(-1), nil, "invalid ttlSeconds -1"),
+			Entry("ttl with selector", int64(3600), &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+				"not supported together with namespaceSelector"),
+		)
+	})
+
+	Describe("Export ConfigMap validation", func() {
+		DescribeTable("spec.exportConfigMap",
+			func(name string, selector *metav1.LabelSelector, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						ExportConfigMap:   name,
+						NamespaceSelector: selector,
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("valid name", "namespace-labels", nil, ""),
+			Entry("dotted name", "labels.export", nil, ""),
+			Entry("uppercase name", "Labels", nil, "invalid exportConfigMap 'Labels'"),
+			Entry("name with slash", "team/labels", nil, "invalid exportConfigMap 'team/labels'"),
+			Entry("export with selector", "namespace-labels", &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+				"not supported together with namespaceSelector"),
+		)
+	})
+
+	Describe("Hash label validation", func() {
+		DescribeTable("spec.hashLabels",
+			func(hashLabel labelsv1alpha1.HashLabelSpec, keyPrefix string, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						HashLabels: []labelsv1alpha1.HashLabelSpec{hashLabel},
+						KeyPrefix:  keyPrefix,
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("valid entry", labelsv1alpha1.HashLabelSpec{Key: "example.com/config-hash", ConfigMapName: "app.config"}, "", ""),
+			Entry("invalid key", labelsv1alpha1.HashLabelSpec{Key: "config hash", ConfigMapName: "cfg"}, "", "invalid hashLabels key 'config hash'"),
+			Entry("empty key", labelsv1alpha1.HashLabelSpec{ConfigMapName: "cfg"}, "", "invalid hashLabels key ''"),
+			Entry("key invalid once prefixed", labelsv1alpha1.HashLabelSpec{Key: "team/hash", ConfigMapName: "cfg"}, "example.com/", "invalid hashLabels key 'example.com/team/hash'"),
+			Entry("uppercase ConfigMap name", labelsv1alpha1.HashLabelSpec{Key: "config-hash", ConfigMapName: "Config"}, "", "invalid configMapName 'Config'"),
+			Entry("empty ConfigMap name", labelsv1alpha1.HashLabelSpec{Key: "config-hash"}, "", "invalid configMapName ''"),
+		)
+
+		It("should reject reserved hash label keys unless the namespace opted in", func() {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient, ReservedLabelPrefixes: DefaultReservedLabelPrefixes}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					HashLabels: []labelsv1alpha1.HashLabelSpec{{Key: "kubernetes.io/config-hash", ConfigMapName: "cfg"}},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("kubernetes.io/config-hash use a reserved prefix")))
+
+			ns.Annotations = map[string]string{AllowReservedLabelsAnnoKey: "true"}
+			Expect(fakeClient.Update(ctx, ns)).To(Succeed())
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should count hash labels towards the label limit, except those overriding a label", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient, MaxLabels: 2}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					Labels:     map[string]string{"env": "prod", "config-hash": "x"},
+					HashLabels: []labelsv1alpha1.HashLabelSpec{{Key: "config-hash", ConfigMapName: "cfg"}},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			obj.Spec.HashLabels = append(obj.Spec.HashLabels, labelsv1alpha1.HashLabelSpec{Key: "secret-hash", ConfigMapName: "cfg"})
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("too many labels: 3 exceeds limit 2")))
+		})
+	})
+
+	Describe("Conditional namespace validation", func() {
+		DescribeTable("spec.conditionalOnNamespace",
+			func(cond *labelsv1alpha1.NamespaceCondition, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						ConditionalOnNamespace: cond,
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("label presence", &labelsv1alpha1.NamespaceCondition{Name: "flags", LabelKey: "example.com/rollout"}, ""),
+			Entry("label value", &labelsv1alpha1.NamespaceCondition{Name: "flags", LabelKey: "rollout", LabelValue: "on"}, ""),
+			Entry("invalid namespace name", &labelsv1alpha1.NamespaceCondition{Name: "Flags", LabelKey: "rollout"},
+				"invalid conditionalOnNamespace name"),
+			Entry("missing label key", &labelsv1alpha1.NamespaceCondition{Name: "flags"},
+				"invalid conditionalOnNamespace labelKey"),
+			Entry("invalid label value", &labelsv1alpha1.NamespaceCondition{Name: "flags", LabelKey: "rollout", LabelValue: "not valid"},
+				"invalid conditionalOnNamespace labelValue"),
+		)
+	})
+
+	Describe("Overwrite policy validation", func() {
+		DescribeTable("spec.overwritePolicy",
+			func(policy labelsv1alpha1.OverwritePolicy, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+					Spec:       labelsv1alpha1.NamespaceLabelSpec{OverwritePolicy: policy},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("unset", labelsv1alpha1.OverwritePolicy(""), ""),
+			Entry("always", labelsv1alpha1.OverwritePolicyAlways, ""),
+			Entry("ifOwned", labelsv1alpha1.OverwritePolicyIfOwned, ""),
+			Entry("unknown policy", labelsv1alpha1.OverwritePolicy("never"), "invalid overwrite policy 'never'"),
+		)
+	})
+
+	Describe("Inheritance validation", func() {
+		DescribeTable("spec.inheritFrom and spec.inheritKeys",
+			func(from string, keys []string, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+					Spec:       labelsv1alpha1.NamespaceLabelSpec{InheritFrom: from, InheritKeys: keys},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("source and keys", "parent", []string{"team", "example.com/cost-center"}, ""),
+			Entry("keys without a source", "", []string{"team"}, "inheritKeys requires inheritFrom"),
+			Entry("source without keys", "parent", nil, "inheritFrom requires at least one inheritKeys entry"),
+			Entry("invalid source", "Parent", []string{"team"}, "invalid inheritFrom namespace"),
+			Entry("invalid key", "parent", []string{"bad key"}, "invalid inheritKeys entry 'bad key'"),
+		)
+	})
+
+	Describe("List merge validation", func() {
+		DescribeTable("spec.labels values of listMergeKeys",
+			func(value, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						Labels:        map[string]string{"teams": value, "note": "a,b"},
+						ListMergeKeys: []string{"teams", "absent"},
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("single item", "backend", ""),
+			Entry("several items", "backend_ops", ""),
+			Entry("template", "{{ .Name }}", ""),
+			Entry("comma-separated items", "backend,ops", "invalid value 'backend,ops' for list-merge label 'teams'"),
+			Entry("empty item", "backend__ops", "items separated by '_' must not be empty"),
+		)
+	})
+
+	Describe("Remove labels validation", func() {
+		DescribeTable("spec.removeLabels",
+			func(prefix string, remove []string, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						Labels:       map[string]string{"team": "payments"},
+						KeyPrefix:    prefix,
+						RemoveLabels: remove,
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("unrelated keys", "", []string{"legacy-team", "example.com/owner"}, ""),
+			Entry("key also in labels", "", []string{"team"}, "label 'team' is in both labels and removeLabels"),
+			Entry("prefixed key also in labels", "acme.io/", []string{"acme.io/team"}, "label 'team' is in both labels and removeLabels"),
+			Entry("invalid key", "", []string{"bad key"}, "invalid removeLabels entry 'bad key'"),
+		)
+
+		It("should reject removing a reserved label unless the namespace opted in", func() {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient, ReservedLabelPrefixes: DefaultReservedLabelPrefixes}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					RemoveLabels: []string{"pod-security.kubernetes.io/enforce", "legacy-team"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("label keys pod-security.kubernetes.io/enforce use a reserved prefix"))
+
+			By("admitting it once the namespace opts in")
+			ns.Annotations = map[string]string{AllowReservedLabelsAnnoKey: "true"}
+			Expect(fakeClient.Update(ctx, ns)).To(Succeed())
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("Reconcile interval validation", func() {
+		DescribeTable("spec.reconcileInterval",
+			func(interval *metav1.Duration, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						ReconcileInterval: interval,
 					},
 				}
 
-				warnings, err := validator.ValidateCreate(ctx, obj)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(warnings).To(BeEmpty())
-			})
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("unset", nil, ""),
+			Entry("zero", &metav1.Duration{}, ""),
+			Entry("positive", &metav1.Duration{Duration: 30 * time.Second}, ""),
+			Entry("negative", &metav1.Duration{Duration: -time.Minute}, "must not be negative"),
+		)
+	})
 
-			It("should reject creation with incorrect name", func() {
+	Describe("Annotation validation", func() {
+		DescribeTable("spec.annotations",
+			func(annotations map[string]string, errSubstring string) {
 				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
 				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
 
 				obj := &labelsv1alpha1.NamespaceLabel{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      "invalid-name",
+						Name:      "labels",
 						Namespace: "test-ns",
 					},
 					Spec: labelsv1alpha1.NamespaceLabelSpec{
-						Labels: map[string]string{"env": "test"},
+						Annotations: annotations,
 					},
 				}
 
-				warnings, err := validator.ValidateCreate(ctx, obj)
-				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("NamespaceLabel resource must be named 'labels'"))
-				Expect(warnings).To(BeEmpty())
-			})
-		})
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("valid keys", map[string]string{"cost-center": "1234", "example.com/contact": "a@b.c"}, ""),
+			Entry("invalid key", map[string]string{"bad key!": "x"}, "invalid annotation key"),
+			Entry("reserved prefix", map[string]string{"labels.shahaf.com/applied": "x"}, "reserved prefix"),
+			Entry("oversized values", map[string]string{"big": strings.Repeat("x", 256*1024)}, "annotations are too large"),
+		)
+	})
 
-		Context("When validating singleton pattern", func() {
-			It("should allow creation when no other NamespaceLabel exists", func() {
+	Describe("Label count validation", func() {
+		labelsOfSize := func(n int) map[string]string {
+			labels := make(map[string]string, n)
+			for i := 0; i < n; i++ {
+				labels[fmt.Sprintf("key-%d", i)] = "v"
+			}
+			return labels
+		}
+
+		DescribeTable("spec.labels",
+			func(count, limit int, errSubstring string) {
 				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
-				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient, MaxLabels: limit}
 
 				obj := &labelsv1alpha1.NamespaceLabel{
 					ObjectMeta: metav1.ObjectMeta{
@@ -96,31 +1004,67 @@ var _ = Describe("NamespaceLabel Webhook", Label("webhook"), func() {
 						Namespace: "test-ns",
 					},
 					Spec: labelsv1alpha1.NamespaceLabelSpec{
-						Labels: map[string]string{"env": "test"},
+						Labels: labelsOfSize(count),
 					},
 				}
 
-				warnings, err := validator.ValidateCreate(ctx, obj)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(warnings).To(BeEmpty())
-			})
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("exactly at the limit", DefaultMaxLabels, DefaultMaxLabels, ""),
+			Entry("one over the limit", DefaultMaxLabels+1, DefaultMaxLabels, "too many labels: 65 exceeds limit 64"),
+			Entry("limit disabled", 1000, 0, ""),
+		)
+	})
 
-			It("should reject creation when another NamespaceLabel already exists", func() {
-				existing := &labelsv1alpha1.NamespaceLabel{
+	Describe("Label entries validation", func() {
+		DescribeTable("spec.labelEntries",
+			func(labels map[string]string, entries []labelsv1alpha1.LabelEntry, limit int, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient, MaxLabels: limit}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      "labels",
 						Namespace: "test-ns",
 					},
 					Spec: labelsv1alpha1.NamespaceLabelSpec{
-						Labels: map[string]string{"existing": "label"},
+						Labels:       labels,
+						LabelEntries: entries,
 					},
 				}
 
-				fakeClient := fake.NewClientBuilder().
-					WithScheme(scheme).
-					WithObjects(existing).
-					Build()
-				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("entries next to labels", map[string]string{"env": "prod"},
+				[]labelsv1alpha1.LabelEntry{{Key: "zone", Value: "eu"}, {Key: "app", Value: "web"}}, 0, ""),
+			Entry("a key in both labels and labelEntries", map[string]string{"env": "prod"},
+				[]labelsv1alpha1.LabelEntry{{Key: "env", Value: "dev"}}, 0, "label 'env' is set more than once across labels and labelEntries"),
+			Entry("a key listed twice", nil,
+				[]labelsv1alpha1.LabelEntry{{Key: "zone", Value: "eu"}, {Key: "zone", Value: "us"}}, 0, "label 'zone' is set more than once"),
+			Entry("an invalid entry key", nil,
+				[]labelsv1alpha1.LabelEntry{{Key: "bad key", Value: "x"}}, 0, "invalid label key 'bad key'"),
+			Entry("entries counted towards the limit", map[string]string{"env": "prod"},
+				[]labelsv1alpha1.LabelEntry{{Key: "zone", Value: "eu"}}, 1, "too many labels: 2 exceeds limit 1"),
+		)
+	})
+
+	Describe("Denied value substring validation", func() {
+		DescribeTable("spec.labels values",
+			func(labels map[string]string, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient, DeniedValueSubstrings: []string{"password", "Secret"}}
 
 				obj := &labelsv1alpha1.NamespaceLabel{
 					ObjectMeta: metav1.ObjectMeta{
@@ -128,15 +1072,441 @@ var _ = Describe("NamespaceLabel Webhook", Label("webhook"), func() {
 						Namespace: "test-ns",
 					},
 					Spec: labelsv1alpha1.NamespaceLabelSpec{
-						Labels: map[string]string{"env": "test"},
+						Labels: labels,
 					},
 				}
 
-				warnings, err := validator.ValidateCreate(ctx, obj)
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("values without denied substrings", map[string]string{"env": "prod", "secret-store": "vault"}, ""),
+			Entry("a denied substring", map[string]string{"db": "password123"},
+				"value of label 'db' contains the denied substring 'password'"),
+			Entry("a denied substring in another case", map[string]string{"token": "my-SECRET-value"},
+				"value of label 'token' contains the denied substring 'Secret'"),
+			Entry("a denied substring in a template", map[string]string{"env": "{{ .Name }}-password"},
+				"contains the denied substring 'password'"),
+		)
+
+		It("should allow any value when no substrings are denied", func() {
+			validator = &NamespaceLabelCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+			_, err := validator.ValidateCreate(ctx, &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec:       labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"db": "password123"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("Label value charset validation", func() {
+		DescribeTable("spec.labels values under ascii-printable",
+			func(labels map[string]string, errSubstring string) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient, ValueCharset: ValueCharsetASCIIPrintable}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+					Spec:       labelsv1alpha1.NamespaceLabelSpec{Labels: labels},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("ASCII values", map[string]string{"env": "prod", "team": "Payments_2.0"}, ""),
+			Entry("a non-ASCII value", map[string]string{"team": "café"},
+				`value of label 'team' contains 'é', outside the ascii-printable charset`),
+			Entry("a control character", map[string]string{"team": "pay\tments"},
+				`value of label 'team' contains '\t', outside the ascii-printable charset`),
+			Entry("a template, checked once rendered", map[string]string{"team": "{{ .Name }}"}, ""),
+		)
+
+		It("should allow any value without a charset", func() {
+			validator = &NamespaceLabelCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+			_, err := validator.ValidateCreate(ctx, &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec:       labelsv1alpha1.NamespaceLabelSpec{Labels: map[string]string{"team": "café"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should only accept known charsets", func() {
+			charset, err := ParseValueCharset("ascii-printable")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(charset).To(Equal(ValueCharsetASCIIPrintable))
+			_, err = ParseValueCharset("utf-8")
+			Expect(err).To(MatchError(ContainSubstring("unknown label value charset 'utf-8'")))
+		})
+	})
+
+	Describe("Label key collision validation", func() {
+		DescribeTable("LabelKeyCollisions",
+			func(spec labelsv1alpha1.NamespaceLabelSpec, expected [][]string) {
+				Expect(LabelKeyCollisions(spec)).To(Equal(expected))
+			},
+			Entry("distinct keys", labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"env": "prod", "team": "a"},
+			}, nil),
+			Entry("keys differing by case", labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"Env": "prod", "env": "dev", "ENV": "qa"},
+			}, [][]string{{"ENV", "Env", "env"}}),
+			Entry("keys differing by surrounding whitespace", labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"team": "a", " team": "b"},
+			}, [][]string{{" team", "team"}}),
+			Entry("several groups", labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"Team": "a", "team": "b", "env": "prod", "Env": "dev"},
+			}, [][]string{{"Env", "env"}, {"Team", "team"}}),
+			Entry("hash label overriding the same key", labelsv1alpha1.NamespaceLabelSpec{
+				Labels:     map[string]string{"config-hash": "x"},
+				HashLabels: []labelsv1alpha1.HashLabelSpec{{Key: "config-hash", ConfigMapName: "cfg"}},
+			}, nil),
+			Entry("hash label differing by case", labelsv1alpha1.NamespaceLabelSpec{
+				Labels:     map[string]string{"config-hash": "x"},
+				HashLabels: []labelsv1alpha1.HashLabelSpec{{Key: "Config-Hash", ConfigMapName: "cfg"}},
+			}, [][]string{{"Config-Hash", "config-hash"}}),
+		)
+
+		It("should reject a spec with colliding keys", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					Labels: map[string]string{"Env": "prod", "env": "dev"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("ambiguous label keys differ only by case or whitespace: Env, env"))
+		})
+	})
+
+	Describe("Reserved label prefix validation", func() {
+		DescribeTable("spec.labels",
+			func(labels map[string]string, keyPrefix string, optIn bool, errSubstring string) {
+				ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}}
+				if optIn {
+					ns.Annotations = map[string]string{AllowReservedLabelsAnnoKey: "true"}
+				}
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient, ReservedLabelPrefixes: DefaultReservedLabelPrefixes}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+					Spec: labelsv1alpha1.NamespaceLabelSpec{
+						Labels:    labels,
+						KeyPrefix: keyPrefix,
+					},
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if errSubstring != "" {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errSubstring))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("unreserved keys", map[string]string{"env": "prod", "example.com/team": "a"}, "", false, ""),
+			Entry("reserved prefix", map[string]string{"kubernetes.io/metadata.name": "x"}, "", false, "kubernetes.io/metadata.name"),
+			Entry("reserved subdomain", map[string]string{"app.kubernetes.io/managed-by": "me"}, "", false, "reserved prefix"),
+			Entry("reserved through the key prefix", map[string]string{"owner": "me"}, "k8s.io/", false, "k8s.io/owner"),
+			Entry("lookalike domain", map[string]string{"notkubernetes.io/owner": "me"}, "", false, ""),
+			Entry("namespace opted in", map[string]string{"app.kubernetes.io/managed-by": "me"}, "", true, ""),
+		)
+
+		It("should reject reserved inheritKeys unless the namespace opted in", func() {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient, ReservedLabelPrefixes: DefaultReservedLabelPrefixes}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					InheritFrom: "parent",
+					InheritKeys: []string{"team", "app.kubernetes.io/part-of"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("app.kubernetes.io/part-of use a reserved prefix")))
+
+			ns.Annotations = map[string]string{AllowReservedLabelsAnnoKey: "true"}
+			Expect(fakeClient.Update(ctx, ns)).To(Succeed())
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not check reserved prefixes when none are configured", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					Labels: map[string]string{"app.kubernetes.io/managed-by": "me"},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("Mandatory label validation", func() {
+		DescribeTable("should reject mandatory labels under the CR's own fail-mode protection",
+			func(spec labelsv1alpha1.NamespaceLabelSpec, expected string) {
+				validator = &NamespaceLabelCustomValidator{
+					Client:                 fake.NewClientBuilder().WithScheme(scheme).Build(),
+					MandatoryLabelPatterns: []string{"cost-center", "corp.io/*"},
+				}
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+					Spec:       spec,
+				}
+
+				_, err := validator.ValidateCreate(ctx, obj)
+				if expected == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("only one NamespaceLabel resource is allowed per namespace"))
-				Expect(warnings).To(BeEmpty())
+				Expect(err.Error()).To(ContainSubstring(expected))
+			},
+			Entry("mandatory key under a fail-mode pattern", labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"cost-center": "42", "env": "prod"},
+				ProtectedLabelPatterns: []string{"cost-*"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+			}, "mandatory labels cost-center match the CR's own fail-mode protection"),
+			Entry("prefixed mandatory key under a fail-mode rule", labelsv1alpha1.NamespaceLabelSpec{
+				Labels:              map[string]string{"owner": "me"},
+				KeyPrefix:           "corp.io/",
+				ProtectedLabelRules: []labelsv1alpha1.ProtectedLabelRule{{Pattern: "corp.io/*", Mode: labelsv1alpha1.ProtectionModeFail}},
+				ProtectionMode:      labelsv1alpha1.ProtectionModeSkip,
+			}, "mandatory labels corp.io/owner match the CR's own fail-mode protection"),
+			Entry("mandatory key under a skip-mode pattern", labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"cost-center": "42"},
+				ProtectedLabelPatterns: []string{"cost-*"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeSkip,
+			}, ""),
+			Entry("fail-mode protection of a label that is not mandatory", labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"env": "prod"},
+				ProtectedLabelPatterns: []string{"env"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+			}, ""),
+		)
+	})
+
+	Describe("Protection warnings", func() {
+		DescribeTable("should warn about labels protection would skip without rejecting",
+			func(nsLabels map[string]string, spec labelsv1alpha1.NamespaceLabelSpec, expected []string) {
+				ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns", Labels: nsLabels}}
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+					Spec:       spec,
+				}
+
+				warnings, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(HaveLen(len(expected)))
+				for i, substring := range expected {
+					Expect(warnings[i]).To(ContainSubstring(substring))
+				}
+
+				By("returning the same warnings on update")
+				warnings, err = validator.ValidateUpdate(ctx, obj, obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(HaveLen(len(expected)))
+			},
+			Entry("no protection", map[string]string{"owner": "platform"}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels: map[string]string{"owner": "me"},
+			}, nil),
+			Entry("protected label with the same value", map[string]string{"owner": "me"}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"owner": "me"},
+				ProtectedLabelPatterns: []string{"owner"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeSkip,
+			}, nil),
+			Entry("protected label with a different value", map[string]string{"owner": "platform", "team": "a"}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"owner": "me", "team": "b", "env": "prod"},
+				ProtectedLabelPatterns: []string{"owner", "regex:^te.*$"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeSkip,
+			}, []string{
+				"protected label 'owner' would be skipped: namespace 'test-ns' has 'platform', spec requests 'me'",
+				"protected label 'team' would be skipped",
+			}),
+			Entry("fail-mode rule", map[string]string{"owner": "platform"}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:              map[string]string{"owner": "me"},
+				ProtectedLabelRules: []labelsv1alpha1.ProtectedLabelRule{{Pattern: "owner", Mode: labelsv1alpha1.ProtectionModeFail}},
+			}, []string{"label 'owner' matches the CR's own fail-mode protection", "protected label 'owner' would fail the reconcile"}),
+			Entry("protectCreation on a missing label", nil, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"owner": "me"},
+				ProtectedLabelPatterns: []string{"owner"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeSkip,
+				ProtectCreation:        true,
+			}, []string{"protectCreation forbids creating it"}),
+			Entry("tier pattern and key prefix", map[string]string{"tier": "gold", "corp.io/owner": "platform"}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:              map[string]string{"owner": "me"},
+				KeyPrefix:           "corp.io/",
+				ProtectionTierLabel: "tier",
+				TierProtectedLabelPatterns: []labelsv1alpha1.TierProtectedLabelPatterns{
+					{Tier: "gold", Patterns: []string{"corp.io/*"}},
+				},
+			}, []string{"protected label 'corp.io/owner' would be skipped"}),
+			Entry("protected value", map[string]string{"tier": "critical"}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"tier": "standard"},
+				ProtectedValuePatterns: []string{"critical"},
+			}, []string{"protected label 'tier' would be skipped: namespace 'test-ns' has 'critical', spec requests 'standard'"}),
+			Entry("templated value", map[string]string{"owner": "platform"}, labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"owner": "{{ .Namespace.Name }}"},
+				ProtectedLabelPatterns: []string{"owner"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeSkip,
+			}, nil),
+		)
+
+		It("should warn about labels the default patterns protect despite the CR's exceptions", func() {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns", Labels: map[string]string{
+				"openshift.io/team": "platform", "openshift.io/owner": "platform"}}}
+			validator = &NamespaceLabelCustomValidator{
+				Client:                        fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns).Build(),
+				DefaultProtectedLabelPatterns: []string{"openshift.io/*"},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					Labels:                 map[string]string{"openshift.io/team": "me", "openshift.io/owner": "me"},
+					ProtectedLabelPatterns: []string{"!openshift.io/team"},
+					ProtectionMode:         labelsv1alpha1.ProtectionModeWarn,
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(Equal(admission.Warnings{
+				"protected label 'openshift.io/owner' would be skipped: namespace 'test-ns' has 'platform', spec requests 'me'",
+				"protected label 'openshift.io/team' would be skipped: namespace 'test-ns' has 'platform', spec requests 'me'",
+			}))
+		})
+
+		It("should warn that changing a kubernetes.io label fails on a system namespace", func() {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-apps", Labels: map[string]string{
+				"pod-security.kubernetes.io/enforce": "restricted", "team": "platform"}}}
+			validator = &NamespaceLabelCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns).Build()}
+
+			warnings, err := validator.ValidateCreate(ctx, &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "kube-apps"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					Labels: map[string]string{"pod-security.kubernetes.io/enforce": "privileged", "team": "me"},
+				},
 			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(Equal(admission.Warnings{
+				"protected label 'pod-security.kubernetes.io/enforce' would fail the reconcile: " +
+					"namespace 'kube-apps' has 'restricted', spec requests 'privileged'",
+			}))
+		})
+
+		DescribeTable("should flag labels matching the CR's own fail-mode protection",
+			func(spec labelsv1alpha1.NamespaceLabelSpec, expected []string) {
+				validator = &NamespaceLabelCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+					Spec:       spec,
+				}
+
+				warnings, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(HaveLen(len(expected)))
+				for i, substring := range expected {
+					Expect(warnings[i]).To(ContainSubstring(substring))
+				}
+
+				By("rejecting them when configured to")
+				validator.RejectSelfProtectedLabels = true
+				_, err = validator.ValidateCreate(ctx, obj)
+				if len(expected) == 0 {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+				Expect(err).To(HaveOccurred())
+				for _, substring := range expected {
+					Expect(err.Error()).To(ContainSubstring(substring))
+				}
+			},
+			Entry("fail-mode pattern", labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"kubernetes.io/team": "a", "env": "prod"},
+				ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+			}, []string{"label 'kubernetes.io/team' matches the CR's own fail-mode protection, so the reconcile fails whenever the namespace has a different value"}),
+			Entry("fail-mode rule with key prefix and protectCreation", labelsv1alpha1.NamespaceLabelSpec{
+				Labels:              map[string]string{"owner": "me"},
+				KeyPrefix:           "corp.io/",
+				ProtectedLabelRules: []labelsv1alpha1.ProtectedLabelRule{{Pattern: "corp.io/*", Mode: labelsv1alpha1.ProtectionModeFail}},
+				ProtectionMode:      labelsv1alpha1.ProtectionModeSkip,
+				ProtectCreation:     true,
+			}, []string{"label 'corp.io/owner' matches the CR's own fail-mode protection and protectCreation forbids creating it"}),
+			Entry("skip mode", labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"kubernetes.io/team": "a"},
+				ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeSkip,
+			}, nil),
+			Entry("excepted key", labelsv1alpha1.NamespaceLabelSpec{
+				Labels:                 map[string]string{"kubernetes.io/team": "a"},
+				ProtectedLabelPatterns: []string{"kubernetes.io/*", "!kubernetes.io/team"},
+				ProtectionMode:         labelsv1alpha1.ProtectionModeFail,
+			}, nil),
+		)
+
+		It("should warn when protection patterns are set without a protection mode", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					Labels:                 map[string]string{"env": "prod"},
+					ProtectedLabelPatterns: []string{"kubernetes.io/*"},
+				},
+			}
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("protectedLabelPatterns is set without protectionMode")))
+
+			By("staying quiet once the mode is explicit")
+			obj.Spec.ProtectionMode = labelsv1alpha1.ProtectionModeSkip
+			warnings, err = validator.ValidateUpdate(ctx, obj, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should not warn when the namespace does not exist", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+			obj := &labelsv1alpha1.NamespaceLabel{
+				ObjectMeta: metav1.ObjectMeta{Name: "labels", Namespace: "test-ns"},
+				Spec: labelsv1alpha1.NamespaceLabelSpec{
+					Labels:                 map[string]string{"owner": "me"},
+					ProtectedLabelPatterns: []string{"owner"},
+					ProtectionMode:         labelsv1alpha1.ProtectionModeSkip,
+					ProtectCreation:        true,
+				},
+			}
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
 		})
 	})
 
@@ -223,6 +1593,36 @@ var _ = Describe("NamespaceLabel Webhook", Label("webhook"), func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(warnings).To(BeEmpty())
 		})
+
+		DescribeTable("should allow deletion and warn only when the namespace is terminating",
+			func(ns *corev1.Namespace, warns bool) {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ns).Build()
+				validator = &NamespaceLabelCustomValidator{Client: fakeClient}
+
+				obj := &labelsv1alpha1.NamespaceLabel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "labels",
+						Namespace: "test-ns",
+					},
+				}
+
+				warnings, err := validator.ValidateDelete(ctx, obj)
+				Expect(err).NotTo(HaveOccurred())
+				if warns {
+					Expect(warnings).To(ConsistOf(ContainSubstring("namespace 'test-ns' is terminating")))
+				} else {
+					Expect(warnings).To(BeEmpty())
+				}
+			},
+			Entry("active namespace", &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}}, false),
+			Entry("terminating namespace", &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-ns"},
+				Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+			}, true),
+			Entry("namespace with a deletion timestamp", &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name: "test-ns", DeletionTimestamp: &metav1.Time{Time: time.Now()}, Finalizers: []string{"kubernetes"},
+			}}, true),
+		)
 	})
 
 	Describe("Type validation", func() {
@@ -0,0 +1,149 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	"github.com/sbahar619/namespace-label-operator/internal/protection"
+)
+
+// protectionWarnings previews the controller's protection check, by key and by current value and including the
+// default patterns and system namespace policy, against the CR's namespace as it is now and warns about every label that would be skipped or fail the reconcile. It never rejects the request:
+// templated values are not rendered, a selector CR is not previewed, and lookup errors yield no warnings.
+func (v *NamespaceLabelCustomValidator) protectionWarnings(ctx context.Context, nl *labelsv1alpha1.NamespaceLabel) admission.Warnings {
+	if v.Client == nil || nl.Spec.NamespaceSelector != nil {
+		return nil
+	}
+	var ns corev1.Namespace
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: nl.Namespace}, &ns); err != nil {
+		namespacelabellog.V(1).Info("Skipping protection preview", "namespace", nl.Namespace, "error", err.Error())
+		return nil
+	}
+
+	labels := SpecLabels(nl.Spec)
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	valueMatchers := protection.CompilePatterns(nl.Spec.ProtectedValuePatterns)
+	system := protection.IsSystemNamespace(ns.Name)
+	var warnings admission.Warnings
+	for _, key := range keys {
+		value := labels[key]
+		if IsLabelTemplate(value) {
+			continue
+		}
+		applied := nl.Spec.KeyPrefix + key
+		existing, hasExisting := ns.Labels[applied]
+		mode, protected := protectionModeFor(applied, nl.Spec, ns.Labels, v.DefaultProtectedLabelPatterns)
+		if !protected && hasExisting && existing != value && protection.MatchesAny(existing, valueMatchers) {
+			mode, protected = nl.Spec.ProtectionMode, true
+		}
+		// System namespaces fail on a changed kubernetes.io label whatever the CR configures
+		if hasExisting && existing != value && system && protection.MatchesAny(applied, protection.SystemProtectedLabels) {
+			mode, protected = labelsv1alpha1.ProtectionModeFail, true
+		}
+		if !protected {
+			continue
+		}
+
+		outcome := "would be skipped"
+		if mode == labelsv1alpha1.ProtectionModeFail {
+			outcome = "would fail the reconcile"
+		}
+		switch {
+		case hasExisting && existing != value:
+			warnings = append(warnings, fmt.Sprintf("protected label '%s' %s: namespace '%s' has '%s', spec requests '%s'",
+				applied, outcome, nl.Namespace, existing, value))
+		case !hasExisting && nl.Spec.ProtectCreation:
+			warnings = append(warnings, fmt.Sprintf("protected label '%s' %s: protectCreation forbids creating it on namespace '%s'",
+				applied, outcome, nl.Namespace))
+		}
+	}
+	return warnings
+}
+
+// selfProtectedLabels returns, sorted and as applied, the spec.labels keys the CR's own rules and patterns protect in
+// fail mode. Tier patterns depend on the namespace and are left to protectionWarnings.
+func selfProtectedLabels(spec labelsv1alpha1.NamespaceLabelSpec) []string {
+	var keys []string
+	for key := range SpecLabels(spec) {
+		applied := spec.KeyPrefix + key
+		if mode, protected := protectionModeFor(applied, spec, nil, nil); protected && mode == labelsv1alpha1.ProtectionModeFail {
+			keys = append(keys, applied)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// selfProtectionMessage explains why a label the CR protects from itself keeps failing the reconcile
+func selfProtectionMessage(key string, protectCreation bool) string {
+	if protectCreation {
+		return fmt.Sprintf("label '%s' matches the CR's own fail-mode protection and protectCreation forbids creating it, "+
+			"so the CR can only reconcile while the namespace already has the requested value", key)
+	}
+	return fmt.Sprintf("label '%s' matches the CR's own fail-mode protection, "+
+		"so the reconcile fails whenever the namespace has a different value", key)
+}
+
+// selfProtectionWarnings warns about every label the CR protects from itself in fail mode
+func selfProtectionWarnings(nl *labelsv1alpha1.NamespaceLabel) admission.Warnings {
+	var warnings admission.Warnings
+	for _, key := range selfProtectedLabels(nl.Spec) {
+		warnings = append(warnings, selfProtectionMessage(key, nl.Spec.ProtectCreation))
+	}
+	return warnings
+}
+
+// implicitProtectionModeWarnings warns when protectedLabelPatterns is set without protectionMode, since the
+// silent skip default may not be what the user expects. Defaulting normally fills the mode in before
+// validation, so this only fires for CRs that reach the validator undefaulted.
+func implicitProtectionModeWarnings(nl *labelsv1alpha1.NamespaceLabel) admission.Warnings {
+	if len(nl.Spec.ProtectedLabelPatterns) == 0 || nl.Spec.ProtectionMode != "" {
+		return nil
+	}
+	return admission.Warnings{"protectedLabelPatterns is set without protectionMode, so protected labels are skipped silently; " +
+		"set protectionMode to skip, warn or fail explicitly"}
+}
+
+// protectionModeFor returns the mode of the first rule or pattern protecting the key, following the
+// controller's order: rules, then the operator's default patterns, protectedLabelPatterns and the patterns of the
+// namespace's tier. A key matching a "!" exception in those patterns is not protected, except by the default
+// patterns, which the CR's exceptions cannot lift.
+func protectionModeFor(key string, spec labelsv1alpha1.NamespaceLabelSpec, nsLabels map[string]string,
+	defaults []string) (labelsv1alpha1.ProtectionMode, bool) {
+	patterns := append(slices.Clip(defaults), protection.ActivePatterns(spec, nsLabels)...)
+	if m, ok := protection.Protecting(key, protection.CompileRules(spec.ProtectedLabelRules, patterns, spec.ProtectionMode)); ok {
+		return m.Mode, true
+	}
+	if m, ok := protection.Protecting(key, protection.CompileRules(nil, defaults, spec.ProtectionMode)); ok {
+		return m.Mode, true
+	}
+	return "", false
+}
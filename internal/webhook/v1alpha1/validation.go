@@ -19,22 +19,46 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	labelsv1alpha1 "github.com/sbahar619/namespace-label-operator/api/v1alpha1"
+	"github.com/sbahar619/namespace-label-operator/internal/protection"
 )
 
-// validateName ensures the NamespaceLabel CR follows the singleton naming pattern
+// validateName ensures the NamespaceLabel CR follows the singleton naming pattern. When several are allowed per
+// namespace any name fits within the controller's per-CR tracking annotation keys instead.
 func (v *NamespaceLabelCustomValidator) validateName(nl *labelsv1alpha1.NamespaceLabel) error {
+	if v.AllowMultipleNamespaceLabels {
+		if len(nl.Name) > MaxMultipleNameLength {
+			return fmt.Errorf("NamespaceLabel name '%s' is %d characters long; at most %d are allowed when a namespace may hold several NamespaceLabels",
+				nl.Name, len(nl.Name), MaxMultipleNameLength)
+		}
+		return nil
+	}
 	if nl.Name != StandardCRName {
 		return fmt.Errorf("NamespaceLabel resource must be named '%s' for singleton pattern enforcement. Found name: '%s'", StandardCRName, nl.Name)
 	}
 	return nil
 }
 
-// validateSingleton ensures only one NamespaceLabel CR exists per namespace
+// validateSingleton ensures only one NamespaceLabel CR exists per namespace, unless several are allowed
 func (v *NamespaceLabelCustomValidator) validateSingleton(ctx context.Context, nl *labelsv1alpha1.NamespaceLabel, oldNL *labelsv1alpha1.NamespaceLabel) error {
+	if v.AllowMultipleNamespaceLabels {
+		return nil
+	}
+
 	// For updates, if the name hasn't changed, we're updating the same resource
 	if oldNL != nil && oldNL.Name == nl.Name && oldNL.Namespace == nl.Namespace {
 		return nil
@@ -63,3 +87,681 @@ func (v *NamespaceLabelCustomValidator) validateSingleton(ctx context.Context, n
 
 	return nil
 }
+
+// ValidateSpec runs the spec checks, so the controller can re-check CRs that may have been admitted while the
+// webhook was unavailable. The flag-dependent checks only match the webhook's when v is configured like it.
+func (v *NamespaceLabelCustomValidator) ValidateSpec(ctx context.Context, nl *labelsv1alpha1.NamespaceLabel) error {
+	return v.validateSpec(ctx, nl)
+}
+
+// validateSpec validates the contents of the NamespaceLabel spec
+func (v *NamespaceLabelCustomValidator) validateSpec(ctx context.Context, nl *labelsv1alpha1.NamespaceLabel) error {
+	if err := v.validateLabels(nl); err != nil {
+		return err
+	}
+	if err := v.validateHashLabels(nl); err != nil {
+		return err
+	}
+	if err := v.validateDeniedValues(nl); err != nil {
+		return err
+	}
+	if err := v.validateReservedLabels(ctx, nl); err != nil {
+		return err
+	}
+	if err := v.validateProtectionPatterns(nl); err != nil {
+		return err
+	}
+	if err := v.validateMatchAll(nl); err != nil {
+		return err
+	}
+	if err := v.validateProtectionMode(nl); err != nil {
+		return err
+	}
+	if err := v.validateOverwritePolicy(nl); err != nil {
+		return err
+	}
+	if err := v.validateNamespaceSelector(nl); err != nil {
+		return err
+	}
+	if err := v.validateReconcileInterval(nl); err != nil {
+		return err
+	}
+	if err := v.validateTTL(nl); err != nil {
+		return err
+	}
+	if err := v.validateExportConfigMap(nl); err != nil {
+		return err
+	}
+	if err := v.validateConditionalOnNamespace(nl); err != nil {
+		return err
+	}
+	if err := v.validateInheritance(nl); err != nil {
+		return err
+	}
+	if err := v.validateRemoveLabels(nl); err != nil {
+		return err
+	}
+	if err := v.validateListMergeValues(nl); err != nil {
+		return err
+	}
+	if err := v.validateSelfProtection(nl); err != nil {
+		return err
+	}
+	if err := v.validateMandatoryLabels(nl); err != nil {
+		return err
+	}
+	return v.validateAnnotations(nl)
+}
+
+// validateSelfProtection rejects labels the CR protects from itself in fail mode when RejectSelfProtectedLabels
+// is set; otherwise they are only warned about
+func (v *NamespaceLabelCustomValidator) validateSelfProtection(nl *labelsv1alpha1.NamespaceLabel) error {
+	if !v.RejectSelfProtectedLabels {
+		return nil
+	}
+	keys := selfProtectedLabels(nl.Spec)
+	if len(keys) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(keys))
+	for _, key := range keys {
+		messages = append(messages, selfProtectionMessage(key, nl.Spec.ProtectCreation))
+	}
+	return fmt.Errorf("%s", strings.Join(messages, "; "))
+}
+
+// validateMandatoryLabels rejects mandatory labels the CR sets under its own fail-mode protection. The CR would
+// fail every reconcile in which the namespace lacks the label or has a different value, so it could never put a
+// missing mandatory label in place.
+func (v *NamespaceLabelCustomValidator) validateMandatoryLabels(nl *labelsv1alpha1.NamespaceLabel) error {
+	mandatory := protection.CompilePatterns(v.MandatoryLabelPatterns)
+	if len(mandatory) == 0 {
+		return nil
+	}
+	var keys []string
+	for _, key := range selfProtectedLabels(nl.Spec) {
+		if protection.MatchesAny(key, mandatory) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return fmt.Errorf("mandatory labels %s match the CR's own fail-mode protection, so the CR fails whenever a namespace "+
+		"lacks them or has another value; exclude them from protection or protect them in skip or warn mode", strings.Join(keys, ", "))
+}
+
+// IsLabelTemplate reports whether a label value is a template evaluated against the target namespace
+func IsLabelTemplate(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+// ParseLabelTemplate parses a templated label value. Missing map keys are errors so a reference to an
+// absent namespace label fails instead of rendering "<no value>".
+func ParseLabelTemplate(key, value string) (*template.Template, error) {
+	return template.New(key).Option("missingkey=error").Parse(value)
+}
+
+// SpecLabels returns spec.labels together with spec.labelEntries, the labels the CR sets as written
+func SpecLabels(spec labelsv1alpha1.NamespaceLabelSpec) map[string]string {
+	if len(spec.LabelEntries) == 0 {
+		return spec.Labels
+	}
+	labels := make(map[string]string, len(spec.Labels)+len(spec.LabelEntries))
+	for key, value := range spec.Labels {
+		labels[key] = value
+	}
+	for _, entry := range spec.LabelEntries {
+		labels[entry.Key] = entry.Value
+	}
+	return labels
+}
+
+// SpecLabelKeys returns the keys of SpecLabels together with the hashLabels keys, sorted and without duplicates:
+// every key the CR sets whose value is known without reading the namespace
+func SpecLabelKeys(spec labelsv1alpha1.NamespaceLabelSpec) []string {
+	labels := SpecLabels(spec)
+	keys := make([]string, 0, len(labels)+len(spec.HashLabels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	for _, hl := range spec.HashLabels {
+		if _, ok := labels[hl.Key]; !ok && !slices.Contains(keys, hl.Key) {
+			keys = append(keys, hl.Key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateLabels ensures the label count, hash labels included, is within MaxLabels, no key is set twice across
+// labels and labelEntries, templated label values and labelsTemplate parse, keys are valid once the key prefix is
+// applied and plain values fit ValueCharset; rendered labels are checked by the controller
+func (v *NamespaceLabelCustomValidator) validateLabels(nl *labelsv1alpha1.NamespaceLabel) error {
+	labels := SpecLabels(nl.Spec)
+	if count := len(SpecLabelKeys(nl.Spec)); v.MaxLabels > 0 && count > v.MaxLabels {
+		return fmt.Errorf("too many labels: %d exceeds limit %d", count, v.MaxLabels)
+	}
+	seen := map[string]bool{}
+	for _, entry := range nl.Spec.LabelEntries {
+		if _, ok := nl.Spec.Labels[entry.Key]; ok || seen[entry.Key] {
+			return fmt.Errorf("label '%s' is set more than once across labels and labelEntries", entry.Key)
+		}
+		seen[entry.Key] = true
+	}
+	if collisions := LabelKeyCollisions(nl.Spec); len(collisions) > 0 {
+		groups := make([]string, 0, len(collisions))
+		for _, keys := range collisions {
+			groups = append(groups, strings.Join(keys, ", "))
+		}
+		return fmt.Errorf("ambiguous label keys differ only by case or whitespace: %s", strings.Join(groups, "; "))
+	}
+	for key, value := range labels {
+		if errs := labelKeyErrors(nl.Spec.KeyPrefix + key); len(errs) > 0 {
+			if nl.Spec.KeyPrefix != "" {
+				return fmt.Errorf("invalid prefixed label key '%s': %s", nl.Spec.KeyPrefix+key, strings.Join(errs, "; "))
+			}
+			return fmt.Errorf("invalid label key '%s': %s", key, strings.Join(errs, "; "))
+		}
+		if !IsLabelTemplate(value) {
+			if r, invalid := v.ValueCharset.InvalidRune(value); invalid {
+				return fmt.Errorf("value of label '%s' contains %q, outside the %s charset", key, r, v.ValueCharset)
+			}
+			continue
+		}
+		if _, err := ParseLabelTemplate(key, value); err != nil {
+			return fmt.Errorf("invalid template for label '%s': %w", key, err)
+		}
+	}
+	if nl.Spec.LabelsTemplate != "" {
+		if _, err := ParseLabelTemplate("labelsTemplate", nl.Spec.LabelsTemplate); err != nil {
+			return fmt.Errorf("invalid labelsTemplate: %w", err)
+		}
+	}
+	return nil
+}
+
+// labelKeyErrors explains why key is not a valid qualified label key. A prefix over the DNS subdomain limit
+// is reported on its own with its length, as the generic message hides which part of a long key is at fault.
+func labelKeyErrors(key string) []string {
+	if prefix, _, ok := strings.Cut(key, "/"); ok && len(prefix) > validation.DNS1123SubdomainMaxLength {
+		return []string{fmt.Sprintf("the prefix before '/' is %d characters long, over the %d-character limit of a DNS subdomain",
+			len(prefix), validation.DNS1123SubdomainMaxLength)}
+	}
+	return validation.IsQualifiedName(key)
+}
+
+// validateHashLabels ensures every hashLabels key is a valid label key once the key prefix is applied and every
+// configMapName is a valid ConfigMap name, so an invalid entry cannot make each namespace update fail
+func (v *NamespaceLabelCustomValidator) validateHashLabels(nl *labelsv1alpha1.NamespaceLabel) error {
+	for _, hl := range nl.Spec.HashLabels {
+		if errs := labelKeyErrors(nl.Spec.KeyPrefix + hl.Key); len(errs) > 0 {
+			return fmt.Errorf("invalid hashLabels key '%s': %s", nl.Spec.KeyPrefix+hl.Key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsDNS1123Subdomain(hl.ConfigMapName); len(errs) > 0 {
+			return fmt.Errorf("invalid configMapName '%s' for hash label '%s': %s", hl.ConfigMapName, hl.Key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// LabelKeyCollisions returns the groups of distinct label keys from labels, labelEntries and hashLabels that are equal
+// once case and surrounding whitespace are ignored. A hash label repeating a labels key exactly is an
+// intended override, not a collision. Keys and groups are sorted.
+func LabelKeyCollisions(spec labelsv1alpha1.NamespaceLabelSpec) [][]string {
+	byNormalized := map[string][]string{}
+	add := func(key string) {
+		normalized := strings.ToLower(strings.TrimSpace(spec.KeyPrefix + key))
+		if !slices.Contains(byNormalized[normalized], key) {
+			byNormalized[normalized] = append(byNormalized[normalized], key)
+		}
+	}
+	for key := range SpecLabels(spec) {
+		add(key)
+	}
+	for _, hl := range spec.HashLabels {
+		add(hl.Key)
+	}
+
+	var collisions [][]string
+	for _, keys := range byNormalized {
+		if len(keys) > 1 {
+			sort.Strings(keys)
+			collisions = append(collisions, keys)
+		}
+	}
+	sort.Slice(collisions, func(i, j int) bool { return collisions[i][0] < collisions[j][0] })
+	return collisions
+}
+
+// validateDeniedValues rejects label values containing any of DeniedValueSubstrings, ignoring case.
+// Templated values are checked as written; their rendered values are not known until reconcile.
+func (v *NamespaceLabelCustomValidator) validateDeniedValues(nl *labelsv1alpha1.NamespaceLabel) error {
+	if len(v.DeniedValueSubstrings) == 0 {
+		return nil
+	}
+	labels := SpecLabels(nl.Spec)
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if denied, ok := v.deniedSubstring(labels[key]); ok {
+			return fmt.Errorf("value of label '%s' contains the denied substring '%s'", key, denied)
+		}
+	}
+	return nil
+}
+
+// deniedSubstring returns the first of DeniedValueSubstrings the value contains, ignoring case
+func (v *NamespaceLabelCustomValidator) deniedSubstring(value string) (string, bool) {
+	value = strings.ToLower(value)
+	for _, denied := range v.DeniedValueSubstrings {
+		if denied != "" && strings.Contains(value, strings.ToLower(denied)) {
+			return denied, true
+		}
+	}
+	return "", false
+}
+
+// ValidateRenderedLabels runs the checks admission cannot apply to labels only known once rendered at reconcile,
+// such as those of spec.labelsTemplate: reserved prefixes unless ns opted in with AllowReservedLabelsAnnoKey,
+// DeniedValueSubstrings, and MaxLabels counting them together with the spec's own labels. Keys are checked as
+// applied, with spec.keyPrefix. A nil validator checks nothing.
+func (v *NamespaceLabelCustomValidator) ValidateRenderedLabels(spec labelsv1alpha1.NamespaceLabelSpec, rendered map[string]string,
+	ns *corev1.Namespace) error {
+	if v == nil || len(rendered) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(rendered))
+	for key := range rendered {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if ns.Annotations[AllowReservedLabelsAnnoKey] != "true" {
+		var reserved []string
+		for _, key := range keys {
+			if isReservedLabelKey(spec.KeyPrefix+key, v.ReservedLabelPrefixes) {
+				reserved = append(reserved, spec.KeyPrefix+key)
+			}
+		}
+		if len(reserved) > 0 {
+			return fmt.Errorf("label keys %s use a reserved prefix; annotate namespace '%s' with %s=true to allow them",
+				strings.Join(reserved, ", "), ns.Name, AllowReservedLabelsAnnoKey)
+		}
+	}
+	for _, key := range keys {
+		if denied, ok := v.deniedSubstring(rendered[key]); ok {
+			return fmt.Errorf("value of label '%s' contains the denied substring '%s'", key, denied)
+		}
+	}
+	specKeys := SpecLabelKeys(spec)
+	count := len(specKeys)
+	for _, key := range keys {
+		if _, found := slices.BinarySearch(specKeys, key); !found {
+			count++
+		}
+	}
+	if v.MaxLabels > 0 && count > v.MaxLabels {
+		return fmt.Errorf("too many labels: %d exceeds limit %d", count, v.MaxLabels)
+	}
+	return nil
+}
+
+// validateReservedLabels rejects label keys under a reserved prefix unless the CR's namespace opted in
+// with AllowReservedLabelsAnnoKey. Label and hash label keys are checked as applied, with spec.keyPrefix.
+// spec.inheritKeys are checked as listed, as they are copied unprefixed, and so are spec.removeLabels keys, since
+// removing a reserved label is no less a change to it than setting one.
+func (v *NamespaceLabelCustomValidator) validateReservedLabels(ctx context.Context, nl *labelsv1alpha1.NamespaceLabel) error {
+	var reserved []string
+	for _, key := range SpecLabelKeys(nl.Spec) {
+		if isReservedLabelKey(nl.Spec.KeyPrefix+key, v.ReservedLabelPrefixes) {
+			reserved = append(reserved, nl.Spec.KeyPrefix+key)
+		}
+	}
+	for _, key := range append(slices.Clip(nl.Spec.InheritKeys), nl.Spec.RemoveLabels...) {
+		if isReservedLabelKey(key, v.ReservedLabelPrefixes) && !slices.Contains(reserved, key) {
+			reserved = append(reserved, key)
+		}
+	}
+	if len(reserved) == 0 {
+		return nil
+	}
+
+	var ns corev1.Namespace
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: nl.Namespace}, &ns); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to check namespace '%s' for reserved label opt-in: %w", nl.Namespace, err)
+	}
+	if ns.Annotations[AllowReservedLabelsAnnoKey] == "true" {
+		return nil
+	}
+	sort.Strings(reserved)
+	return fmt.Errorf("label keys %s use a reserved prefix; annotate namespace '%s' with %s=true to allow them",
+		strings.Join(reserved, ", "), nl.Namespace, AllowReservedLabelsAnnoKey)
+}
+
+// IsReservedLabelKey reports whether the key falls under one of ReservedLabelPrefixes. A nil validator reserves nothing.
+func (v *NamespaceLabelCustomValidator) IsReservedLabelKey(key string) bool {
+	return v != nil && isReservedLabelKey(key, v.ReservedLabelPrefixes)
+}
+
+// isReservedLabelKey reports whether the key's prefix is a reserved prefix or one of its subdomains
+func isReservedLabelKey(key string, reservedPrefixes []string) bool {
+	domain, _, ok := strings.Cut(key, "/")
+	if !ok {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		reserved := strings.TrimSuffix(prefix, "/")
+		if domain == reserved || strings.HasSuffix(domain, "."+reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateAnnotations ensures annotation keys are qualified names outside the operator's own prefix
+// and that the values fit within the API server's annotation size limit
+func (v *NamespaceLabelCustomValidator) validateAnnotations(nl *labelsv1alpha1.NamespaceLabel) error {
+	var totalSize int
+	for key, value := range nl.Spec.Annotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid annotation key '%s': %s", key, strings.Join(errs, "; "))
+		}
+		if strings.HasPrefix(key, reservedAnnotationPrefix) {
+			return fmt.Errorf("annotation key '%s' uses the reserved prefix '%s'", key, reservedAnnotationPrefix)
+		}
+		totalSize += len(key) + len(value)
+	}
+	if totalSize > apivalidation.TotalAnnotationSizeLimitB {
+		return fmt.Errorf("annotations are too large: %d bytes exceeds limit %d", totalSize, apivalidation.TotalAnnotationSizeLimitB)
+	}
+	return nil
+}
+
+// validateProtectionPatterns ensures every protection and removal protection pattern is usable:
+// regex patterns must compile, glob patterns must be well-formed and "!" exceptions must wrap a pattern
+func (v *NamespaceLabelCustomValidator) validateProtectionPatterns(nl *labelsv1alpha1.NamespaceLabel) error {
+	if err := validateProtectionPatternList("protection", nl.Spec.ProtectedLabelPatterns); err != nil {
+		return err
+	}
+	rulePatterns := make([]string, 0, len(nl.Spec.ProtectedLabelRules))
+	for _, rule := range nl.Spec.ProtectedLabelRules {
+		if rule.Pattern == "" {
+			return fmt.Errorf("protection rule pattern must not be empty")
+		}
+		if strings.HasPrefix(rule.Pattern, labelsv1alpha1.NegatedPatternPrefix) {
+			return fmt.Errorf("protection rule pattern '%s' cannot be an exception: list it in protectedLabelPatterns, "+
+				"where an exception overrides every rule and pattern", rule.Pattern)
+		}
+		rulePatterns = append(rulePatterns, rule.Pattern)
+	}
+	if err := validatePatternList("protection rule", rulePatterns); err != nil {
+		return err
+	}
+	for _, tp := range nl.Spec.TierProtectedLabelPatterns {
+		if err := validateProtectionPatternList(fmt.Sprintf("tier '%s' protection", tp.Tier), tp.Patterns); err != nil {
+			return err
+		}
+	}
+	if err := validatePatternList("protected value", nl.Spec.ProtectedValuePatterns); err != nil {
+		return err
+	}
+	if err := validatePatternList("allowed label", nl.Spec.AllowedLabelPatterns); err != nil {
+		return err
+	}
+	return validatePatternList("removal protection", nl.Spec.RemovalProtectionPatterns)
+}
+
+// validateMatchAll rejects protection patterns that match everything, unless spec.allowMatchAll opts in
+func (v *NamespaceLabelCustomValidator) validateMatchAll(nl *labelsv1alpha1.NamespaceLabel) error {
+	if nl.Spec.AllowMatchAll {
+		return nil
+	}
+	check := func(kind string, patterns []string) error {
+		for _, pattern := range patterns {
+			if isMatchAllPattern(pattern) {
+				return fmt.Errorf("%s pattern '%s' matches everything, so it protects every label; "+
+					"set allowMatchAll if that is intended", kind, pattern)
+			}
+		}
+		return nil
+	}
+	if err := check("protection", nl.Spec.ProtectedLabelPatterns); err != nil {
+		return err
+	}
+	for _, rule := range nl.Spec.ProtectedLabelRules {
+		if err := check("protection rule", []string{rule.Pattern}); err != nil {
+			return err
+		}
+	}
+	for _, tp := range nl.Spec.TierProtectedLabelPatterns {
+		if err := check(fmt.Sprintf("tier '%s' protection", tp.Tier), tp.Patterns); err != nil {
+			return err
+		}
+	}
+	return check("protected value", nl.Spec.ProtectedValuePatterns)
+}
+
+// validateProtectionMode ensures the CR-wide mode and every per-pattern mode is a known mode
+func (v *NamespaceLabelCustomValidator) validateProtectionMode(nl *labelsv1alpha1.NamespaceLabel) error {
+	if !isValidProtectionMode(nl.Spec.ProtectionMode) {
+		return fmt.Errorf("invalid protection mode '%s': must be one of skip, warn, fail", nl.Spec.ProtectionMode)
+	}
+	for _, rule := range nl.Spec.ProtectedLabelRules {
+		if !isValidProtectionMode(rule.Mode) {
+			return fmt.Errorf("invalid protection mode '%s' for pattern '%s': must be one of skip, warn, fail", rule.Mode, rule.Pattern)
+		}
+	}
+	return nil
+}
+
+// validateOverwritePolicy ensures the overwrite policy is a known policy; empty falls back to always
+func (v *NamespaceLabelCustomValidator) validateOverwritePolicy(nl *labelsv1alpha1.NamespaceLabel) error {
+	switch nl.Spec.OverwritePolicy {
+	case "", labelsv1alpha1.OverwritePolicyAlways, labelsv1alpha1.OverwritePolicyIfOwned:
+		return nil
+	}
+	return fmt.Errorf("invalid overwrite policy '%s': must be one of always, ifOwned", nl.Spec.OverwritePolicy)
+}
+
+// validateNamespaceSelector ensures the selector converts to a label selector; whether this CR may use
+// a selector at all is decided by the controller's configured selector namespace
+func (v *NamespaceLabelCustomValidator) validateNamespaceSelector(nl *labelsv1alpha1.NamespaceLabel) error {
+	if nl.Spec.NamespaceSelector == nil {
+		return nil
+	}
+	if _, err := metav1.LabelSelectorAsSelector(nl.Spec.NamespaceSelector); err != nil {
+		return fmt.Errorf("invalid namespaceSelector: %w", err)
+	}
+	return nil
+}
+
+// validateTTL ensures ttlSeconds is not negative and is only set on a CR labeling its own namespace
+func (v *NamespaceLabelCustomValidator) validateTTL(nl *labelsv1alpha1.NamespaceLabel) error {
+	if nl.Spec.TTLSeconds < 0 {
+		return fmt.Errorf("invalid ttlSeconds %d: must not be negative", nl.Spec.TTLSeconds)
+	}
+	if nl.Spec.TTLSeconds > 0 && nl.Spec.NamespaceSelector != nil {
+		return fmt.Errorf("ttlSeconds is not supported together with namespaceSelector")
+	}
+	return nil
+}
+
+// validateExportConfigMap ensures exportConfigMap is a valid ConfigMap name and is only set on a CR labeling its own namespace
+func (v *NamespaceLabelCustomValidator) validateExportConfigMap(nl *labelsv1alpha1.NamespaceLabel) error {
+	name := nl.Spec.ExportConfigMap
+	if name == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return fmt.Errorf("invalid exportConfigMap '%s': %s", name, strings.Join(errs, "; "))
+	}
+	if nl.Spec.NamespaceSelector != nil {
+		return fmt.Errorf("exportConfigMap is not supported together with namespaceSelector")
+	}
+	return nil
+}
+
+// validateConditionalOnNamespace ensures the control namespace name, label key and value are well-formed
+func (v *NamespaceLabelCustomValidator) validateConditionalOnNamespace(nl *labelsv1alpha1.NamespaceLabel) error {
+	cond := nl.Spec.ConditionalOnNamespace
+	if cond == nil {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(cond.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid conditionalOnNamespace name '%s': %s", cond.Name, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsQualifiedName(cond.LabelKey); len(errs) > 0 {
+		return fmt.Errorf("invalid conditionalOnNamespace labelKey '%s': %s", cond.LabelKey, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(cond.LabelValue); len(errs) > 0 {
+		return fmt.Errorf("invalid conditionalOnNamespace labelValue '%s': %s", cond.LabelValue, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateInheritance ensures inheritFrom and inheritKeys are set together and name a valid namespace and label keys
+func (v *NamespaceLabelCustomValidator) validateInheritance(nl *labelsv1alpha1.NamespaceLabel) error {
+	if nl.Spec.InheritFrom == "" && len(nl.Spec.InheritKeys) == 0 {
+		return nil
+	}
+	if nl.Spec.InheritFrom == "" {
+		return fmt.Errorf("inheritKeys requires inheritFrom")
+	}
+	if len(nl.Spec.InheritKeys) == 0 {
+		return fmt.Errorf("inheritFrom requires at least one inheritKeys entry")
+	}
+	if errs := validation.IsDNS1123Label(nl.Spec.InheritFrom); len(errs) > 0 {
+		return fmt.Errorf("invalid inheritFrom namespace '%s': %s", nl.Spec.InheritFrom, strings.Join(errs, "; "))
+	}
+	for _, key := range nl.Spec.InheritKeys {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid inheritKeys entry '%s': %s", key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// validateRemoveLabels ensures removeLabels holds valid label keys and none of them is also set by labels,
+// with or without the key prefix, since the operator would otherwise add and delete the same label
+func (v *NamespaceLabelCustomValidator) validateRemoveLabels(nl *labelsv1alpha1.NamespaceLabel) error {
+	for _, key := range nl.Spec.RemoveLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid removeLabels entry '%s': %s", key, strings.Join(errs, "; "))
+		}
+	}
+	for key := range SpecLabels(nl.Spec) {
+		if slices.Contains(nl.Spec.RemoveLabels, key) || slices.Contains(nl.Spec.RemoveLabels, nl.Spec.KeyPrefix+key) {
+			return fmt.Errorf("label '%s' is in both labels and removeLabels", key)
+		}
+	}
+	return nil
+}
+
+// validateListMergeValues ensures every listMergeKeys value in labels is a valid label value made of non-empty
+// ListSeparator-separated items, so it can be merged with other items into a valid value. Templated values are
+// only known at reconcile, and the merged value may still exceed the length limit once other items are added.
+func (v *NamespaceLabelCustomValidator) validateListMergeValues(nl *labelsv1alpha1.NamespaceLabel) error {
+	labels := SpecLabels(nl.Spec)
+	for _, key := range nl.Spec.ListMergeKeys {
+		value, ok := labels[key]
+		if !ok || IsLabelTemplate(value) {
+			continue
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid value '%s' for list-merge label '%s': %s", value, key, strings.Join(errs, "; "))
+		}
+		if slices.Contains(strings.Split(value, ListSeparator), "") {
+			return fmt.Errorf("invalid value '%s' for list-merge label '%s': items separated by '%s' must not be empty",
+				value, key, ListSeparator)
+		}
+	}
+	return nil
+}
+
+// validateReconcileInterval ensures the per-CR resync interval is not negative; zero falls back to the global interval
+func (v *NamespaceLabelCustomValidator) validateReconcileInterval(nl *labelsv1alpha1.NamespaceLabel) error {
+	if nl.Spec.ReconcileInterval != nil && nl.Spec.ReconcileInterval.Duration < 0 {
+		return fmt.Errorf("invalid reconcileInterval '%s': must not be negative", nl.Spec.ReconcileInterval.Duration)
+	}
+	return nil
+}
+
+// isValidProtectionMode accepts the known modes and empty, which falls back to the default
+func isValidProtectionMode(mode labelsv1alpha1.ProtectionMode) bool {
+	switch mode {
+	case "", labelsv1alpha1.ProtectionModeSkip, labelsv1alpha1.ProtectionModeWarn, labelsv1alpha1.ProtectionModeFail:
+		return true
+	}
+	return false
+}
+
+// validateProtectionPatternList validates key protection patterns, which may be "!" exceptions of another pattern
+func validateProtectionPatternList(kind string, patterns []string) error {
+	for _, pattern := range patterns {
+		expr, negated := strings.CutPrefix(pattern, labelsv1alpha1.NegatedPatternPrefix)
+		if !negated {
+			continue
+		}
+		if expr == "" || strings.HasPrefix(expr, labelsv1alpha1.NegatedPatternPrefix) {
+			return fmt.Errorf("invalid %s exception '%s': '!' must be followed by a glob or regex pattern, "+
+				"and keys it matches are never protected, whatever rule or pattern matches them", kind, pattern)
+		}
+		if err := validatePatternList(kind+" exception", []string{expr}); err != nil {
+			return err
+		}
+	}
+	return validatePatternList(kind, slices.DeleteFunc(slices.Clone(patterns), func(pattern string) bool {
+		return strings.HasPrefix(pattern, labelsv1alpha1.NegatedPatternPrefix)
+	}))
+}
+
+func validatePatternList(kind string, patterns []string) error {
+	for _, pattern := range patterns {
+		expr, ok := strings.CutPrefix(pattern, labelsv1alpha1.RegexPatternPrefix)
+		if !ok {
+			var err error
+			if protection.IsDoubleStarGlob(pattern) {
+				_, err = protection.CompileDoubleStarGlob(pattern)
+			} else {
+				err = checkGlob(pattern)
+			}
+			if err != nil {
+				return fmt.Errorf("invalid glob %s pattern '%s': %w", kind, pattern, err)
+			}
+			continue
+		}
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("invalid regex %s pattern '%s': %w", kind, pattern, err)
+		}
+	}
+	return nil
+}
+
+// globSampleKeys are keys of different shapes a glob is test-matched against, so a malformed part of the
+// pattern is reported even if matching one particular key would stop before reaching it
+var globSampleKeys = []string{"", "a", "env", "example.com/key", "kubernetes.io/metadata.name", "a.b-c_d/e.f-g_h"}
+
+// checkGlob returns filepath.ErrBadPattern if matching the glob against any of globSampleKeys reports it
+func checkGlob(pattern string) error {
+	for _, key := range globSampleKeys {
+		if _, err := filepath.Match(pattern, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isMatchAllPattern reports whether a pattern is one of the catch-all spellings: a glob made only of "*",
+// or an empty, ".*" or ".+" regex, optionally anchored
+func isMatchAllPattern(pattern string) bool {
+	if expr, ok := strings.CutPrefix(pattern, labelsv1alpha1.RegexPatternPrefix); ok {
+		expr = strings.TrimSuffix(strings.TrimPrefix(expr, "^"), "$")
+		return expr == "" || expr == ".*" || expr == ".+"
+	}
+	return pattern != "" && strings.Trim(pattern, "*") == ""
+}
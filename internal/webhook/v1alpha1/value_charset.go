@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "fmt"
+
+// ValueCharset restricts the characters label values may use, on top of Kubernetes label value syntax
+type ValueCharset string
+
+const (
+	// ValueCharsetAny leaves label values unrestricted
+	ValueCharsetAny ValueCharset = ""
+	// ValueCharsetASCIIPrintable allows only printable ASCII, space through tilde
+	ValueCharsetASCIIPrintable ValueCharset = "ascii-printable"
+)
+
+// ParseValueCharset returns the charset named by s, which is empty or "ascii-printable"
+func ParseValueCharset(s string) (ValueCharset, error) {
+	switch charset := ValueCharset(s); charset {
+	case ValueCharsetAny, ValueCharsetASCIIPrintable:
+		return charset, nil
+	default:
+		return "", fmt.Errorf("unknown label value charset '%s', must be empty or '%s'", s, ValueCharsetASCIIPrintable)
+	}
+}
+
+// InvalidRune returns the first rune of value outside the charset and true, or false when value fits it
+func (c ValueCharset) InvalidRune(value string) (rune, bool) {
+	if c != ValueCharsetASCIIPrintable {
+		return 0, false
+	}
+	for _, r := range value {
+		if r < ' ' || r > '~' {
+			return r, true
+		}
+	}
+	return 0, false
+}
@@ -109,7 +109,7 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = SetupNamespaceLabelWebhookWithManager(mgr)
+	err = SetupNamespaceLabelWebhookWithManager(mgr, DefaultReservedLabelPrefixes, DefaultMaxLabels, nil, ValueCharsetAny, false, false, nil, nil)
 	Expect(err).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:webhook